@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"embed"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
@@ -28,6 +30,8 @@ func Execute(defaultConfig embed.FS) {
 		initCommand(os.Args[2:])
 	case "generate":
 		generateCommand(os.Args[2:])
+	case "validate":
+		validateCommand(os.Args[2:])
 	case "version", "--version", "-v":
 		fmt.Printf("gqlschemagen %s\n", generator.GetVersion())
 		os.Exit(0)
@@ -45,6 +49,7 @@ func printUsage() {
 	fmt.Fprintf(os.Stderr, "Usage:\n")
 	fmt.Fprintf(os.Stderr, "  gqlschemagen init [options]              Create default configuration file\n")
 	fmt.Fprintf(os.Stderr, "  gqlschemagen generate [options]          Generate GraphQL schema from Go structs\n")
+	fmt.Fprintf(os.Stderr, "  gqlschemagen validate [options]          Report schema problems without writing files\n")
 	fmt.Fprintf(os.Stderr, "  gqlschemagen help                        Show this help message\n\n")
 	fmt.Fprintf(os.Stderr, "Run 'gqlschemagen <command> --help' for more information on a command.\n")
 }
@@ -109,22 +114,77 @@ func initCommand(args []string) {
 	fmt.Println("Edit this file to customize your schema generation settings.")
 }
 
+// loadCLIConfig loads the --config file, falling back to smart defaults when the default file doesn't exist.
+// A comma-separated list of files is merged in order, later files overriding earlier ones.
+func loadCLIConfig(configFile string, status io.Writer) *generator.Config {
+	if configFile == "" {
+		// Initialize with smart defaults if no config file specified
+		fmt.Fprintln(status, "No config file specified, using smart defaults")
+		return generator.NewConfigWithDefaults()
+	}
+
+	if strings.Contains(configFile, ",") {
+		var paths []string
+		for _, path := range strings.Split(configFile, ",") {
+			if path = strings.TrimSpace(path); path == "" {
+				continue
+			}
+			if _, err := os.Stat(path); err != nil {
+				log.Fatalf("config file not found: %s", path)
+			}
+			paths = append(paths, path)
+		}
+		cfg, err := generator.LoadConfigFromFiles(paths...)
+		if err != nil {
+			log.Fatalf("failed to load config files: %v", err)
+		}
+		fmt.Fprintf(status, "Loaded config from %s\n", strings.Join(paths, ", "))
+		return cfg
+	}
+
+	// Load config from YAML file if it exists
+	if _, err := os.Stat(configFile); err == nil {
+		cfg, err := generator.LoadConfigFromFile(configFile)
+		if err != nil {
+			log.Fatalf("failed to load config file: %v", err)
+		}
+		fmt.Fprintf(status, "Loaded config from %s\n", configFile)
+		return cfg
+	} else if configFile != "gqlschemagen.yml" {
+		// Only error if a non-default config file was specified but not found
+		log.Fatalf("config file not found: %s", configFile)
+	}
+
+	// Initialize with smart defaults if default file doesn't exist
+	fmt.Fprintln(status, "No config file found, using smart defaults")
+	return generator.NewConfigWithDefaults()
+}
+
 func generateCommand(args []string) {
 	fs := flag.NewFlagSet("generate", flag.ExitOnError)
 	fs.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: gqlschemagen generate [options]\n\n")
 		fmt.Fprintf(os.Stderr, "Generate GraphQL schema from Go structs.\n\n")
 		fmt.Fprintf(os.Stderr, "Required flags:\n")
-		fmt.Fprintf(os.Stderr, "  --pkg, -p <path>              		Root package dir to scan\n\n")
+		fmt.Fprintf(os.Stderr, "  --pkg, -p <path>              		Root package dir (or single .go file) to scan\n\n")
 		fmt.Fprintf(os.Stderr, "Optional flags:\n")
-		fmt.Fprintf(os.Stderr, "  --config, -c <file>           		Path to config file (default: gqlschemagen.yml)\n")
+		fmt.Fprintf(os.Stderr, "  --config, -c <file>           		Path to config file, or comma-separated files merged in order (default: gqlschemagen.yml)\n")
+		fmt.Fprintf(os.Stderr, "  --build-tags <tags>           		Comma-separated build tags used to select scanned files\n")
 		fmt.Fprintf(os.Stderr, "  --watch, -w                   		Watch for changes and regenerate automatically\n")
-		fmt.Fprintf(os.Stderr, "  --out, -o <path>              		Output directory or file path (default: graph/schema)\n")
+		fmt.Fprintf(os.Stderr, "  --dry-run                     		Report files that would change without writing (exit 1 if any)\n")
+		fmt.Fprintf(os.Stderr, "  --check                       		Fail listing stale files if the generated files are not current (exit 1 if any)\n")
+		fmt.Fprintf(os.Stderr, "  --verbose, -V                 		Log parsed directives, field decisions and output files to stderr\n")
+		fmt.Fprintf(os.Stderr, "  --out, -o <path>              		Output directory or file path, '-' for stdout (default: graph/schema)\n")
+		fmt.Fprintf(os.Stderr, "  --format <format>             		Output format: graphql or json (manifest of generated types) (default: graphql)\n")
 		fmt.Fprintf(os.Stderr, "  --output-file-name, ofn <name>     Output file name for single strategy (default: gqlschemagen.graphqls)\n")
 		fmt.Fprintf(os.Stderr, "  --output-file-extension <ext> 		Output file extension for multiple/package strategies (default: .graphqls)\n")
 		fmt.Fprintf(os.Stderr, "  --strategy, -s <strategy>     		Generation strategy: single or multiple (default: single)\n")
 		fmt.Fprintf(os.Stderr, "  --skip-existing               		Skip generating files that already exist\n")
 		fmt.Fprintf(os.Stderr, "  --field-case, -case <case>       	Field name case: camel, snake, pascal, original, none (default: camel)\n")
+		fmt.Fprintf(os.Stderr, "  --field-order <order>         		Field order: source, alpha, json, required-first (default: source)\n")
+		fmt.Fprintf(os.Stderr, "  --type-order <order>          		Type order: topological, alphabetical, source (default: topological)\n")
+		fmt.Fprintf(os.Stderr, "  --type-name-case <case>       		Type and input name case: pascal, snake, screaming (default: pascal)\n")
+		fmt.Fprintf(os.Stderr, "  --map-as-pairs                		Render maps as key-value pair lists (default: false)\n")
 		fmt.Fprintf(os.Stderr, "  --use-json-tag                		Use json tag for field names (default: true)\n")
 		fmt.Fprintf(os.Stderr, "  --use-gqlgen-directives, -gqlgen   Generate @goModel and @goField directives (default: false)\n")
 		fmt.Fprintf(os.Stderr, "  --model-path, -m <path>       		Base path for @goModel directive\n")
@@ -142,19 +202,30 @@ func generateCommand(args []string) {
 	processedArgs := preprocessArgs(args)
 
 	// Required flags
-	pkg := fs.String("pkg", "", "root package dir to scan (required)")
+	pkg := fs.String("pkg", "", "root package dir or single .go file to scan (required)")
 	fs.StringVar(pkg, "p", "", "short for --pkg")
 
 	// Optional flags
 	configFile := fs.String("config", "gqlschemagen.yml", "path to config file")
 	fs.StringVar(configFile, "c", "gqlschemagen.yml", "short for --config")
 
+	buildTags := fs.String("build-tags", "", "comma-separated build tags used to select scanned files")
+
+	dryRun := fs.Bool("dry-run", false, "report the files that would be written without writing them")
+
+	check := fs.Bool("check", false, "fail if the generated files on disk are not current, without writing them")
+
+	verbose := fs.Bool("verbose", false, "log parsed directives, field decisions and output files to stderr")
+	fs.BoolVar(verbose, "V", false, "short for --verbose")
+
 	watch := fs.Bool("watch", false, "watch for changes and regenerate automatically")
 	fs.BoolVar(watch, "w", false, "short for --watch")
 
 	out := fs.String("out", "", "output directory or file path")
 	fs.StringVar(out, "o", "", "short for --out")
 
+	format := fs.String("format", "graphql", "output format: graphql or json")
+
 	outputFileName := fs.String("output-file-name", "", "output file name for single strategy")
 	fs.StringVar(outputFileName, "ofn", "", "short for --output-file-name")
 
@@ -168,6 +239,13 @@ func generateCommand(args []string) {
 	fieldCase := fs.String("field-case", "camel", "field name case: camel, snake, pascal, original, or none")
 	fs.StringVar(fieldCase, "case", "camel", "short for --field-case")
 
+	fieldOrder := fs.String("field-order", "source", "field order: source, alpha, json, or required-first")
+	typeOrder := fs.String("type-order", "topological", "type order: topological, alphabetical, or source")
+
+	typeNameCase := fs.String("type-name-case", "pascal", "type and input name case: pascal, snake, or screaming")
+
+	mapAsPairs := fs.Bool("map-as-pairs", false, "render maps as lists of key-value pair types")
+
 	useJsonTag := fs.Bool("use-json-tag", true, "use json tag for field names (priority: gql tag > json tag > struct field)")
 
 	useGqlGenDirectives := fs.Bool("use-gqlgen-directives", false, "generate @goModel and @goField directives for gqlgen")
@@ -192,43 +270,32 @@ func generateCommand(args []string) {
 
 	includeEmptyTypes := fs.Bool("include-empty-types", false, "include types with no fields in the schema")
 
+	defaultNullable := fs.Bool("default-nullable", false, "render fields nullable unless they are tagged required")
+
 	err := fs.Parse(processedArgs)
 	if err != nil {
 		log.Fatalf("Failed to parse flags: %v", err)
 	}
 
-	// Initialize config
-	var cfg *generator.Config
-
-	// Load config from YAML file if it exists
-	if *configFile != "" {
-		if _, err := os.Stat(*configFile); err == nil {
-			cfg, err = generator.LoadConfigFromFile(*configFile)
-			if err != nil {
-				log.Fatalf("failed to load config file: %v", err)
-			}
-			fmt.Printf("Loaded config from %s\n", *configFile)
-		} else if *configFile != "gqlschemagen.yml" {
-			// Only error if a non-default config file was specified but not found
-			log.Fatalf("config file not found: %s", *configFile)
-		} else {
-			// Initialize with smart defaults if default file doesn't exist
-			cfg = generator.NewConfigWithDefaults()
-			fmt.Println("No config file found, using smart defaults")
-		}
-	} else {
-		// Initialize with smart defaults if no config file specified
-		cfg = generator.NewConfigWithDefaults()
-		fmt.Println("No config file specified, using smart defaults")
+	// Status messages go to stderr when the schema is piped to stdout
+	status := os.Stdout
+	if *out == generator.OutputStdout {
+		status = os.Stderr
 	}
 
+	cfg := loadCLIConfig(*configFile, status)
+
 	// Override config with CLI flags (only if they were explicitly set)
 	fs.Visit(func(f *flag.Flag) {
 		switch f.Name {
 		case "pkg", "p":
 			cfg.Packages = []string{*pkg}
+		case "build-tags":
+			cfg.BuildTags = strings.Split(*buildTags, ",")
 		case "out", "o":
 			cfg.Output = *out
+		case "format":
+			cfg.Format = generator.OutputFormat(*format)
 		case "output-file-name", "ofn":
 			cfg.OutputFileName = *outputFileName
 		case "output-file-extension":
@@ -239,6 +306,14 @@ func generateCommand(args []string) {
 			cfg.SkipExisting = *skipExisting
 		case "field-case", "case":
 			cfg.FieldCase = generator.FieldCase(*fieldCase)
+		case "field-order":
+			cfg.FieldOrder = generator.FieldOrder(*fieldOrder)
+		case "type-order":
+			cfg.TypeOrder = generator.TypeOrder(*typeOrder)
+		case "type-name-case":
+			cfg.TypeNameCase = generator.TypeNameCase(*typeNameCase)
+		case "map-as-pairs":
+			cfg.MapAsPairs = *mapAsPairs
 		case "use-json-tag":
 			cfg.UseJsonTag = *useJsonTag
 		case "gqlgen", "use-gqlgen-directives":
@@ -261,6 +336,14 @@ func generateCommand(args []string) {
 			cfg.SchemaFileName = *schemaFileName
 		case "include-empty-types":
 			cfg.IncludeEmptyTypes = *includeEmptyTypes
+		case "default-nullable":
+			cfg.DefaultNullable = *defaultNullable
+		case "dry-run":
+			cfg.DryRun = *dryRun
+		case "check":
+			cfg.CheckOnly = *check
+		case "verbose", "V":
+			cfg.Verbose = *verbose
 		case "watch", "w":
 			cfg.CLI.Watcher.Enabled = *watch
 		}
@@ -277,8 +360,88 @@ func generateCommand(args []string) {
 
 	// Generate schema
 	if err := generator.Generate(cfg); err != nil {
+		if errors.Is(err, generator.ErrSchemaChanged) {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
 		log.Fatalf("generation failed: %v", err)
 	}
 
-	fmt.Println("done")
+	if cfg.Output == generator.OutputStdout {
+		status = os.Stderr
+	}
+	fmt.Fprintln(status, "done")
+}
+
+func validateCommand(args []string) {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gqlschemagen validate [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Parse the packages and report every problem the generated schema would have, without writing files.\n")
+		fmt.Fprintf(os.Stderr, "Exits 1 if any problem is an error.\n\n")
+		fmt.Fprintf(os.Stderr, "Optional flags:\n")
+		fmt.Fprintf(os.Stderr, "  --pkg, -p <path>              		Root package dir (or single .go file) to scan\n")
+		fmt.Fprintf(os.Stderr, "  --config, -c <file>           		Path to config file, or comma-separated files merged in order (default: gqlschemagen.yml)\n")
+		fmt.Fprintf(os.Stderr, "  --build-tags <tags>           		Comma-separated build tags used to select scanned files\n")
+		fmt.Fprintf(os.Stderr, "  --strategy, -s <strategy>     		Generation strategy: single, multiple or package\n")
+		fmt.Fprintf(os.Stderr, "  --verbose, -V                 		Log parsed directives, field decisions and output files to stderr\n")
+	}
+
+	// Preprocess args
+	processedArgs := preprocessArgs(args)
+
+	pkg := fs.String("pkg", "", "root package dir or single .go file to scan")
+	fs.StringVar(pkg, "p", "", "short for --pkg")
+
+	configFile := fs.String("config", "gqlschemagen.yml", "path to config file")
+	fs.StringVar(configFile, "c", "gqlschemagen.yml", "short for --config")
+
+	buildTags := fs.String("build-tags", "", "comma-separated build tags used to select scanned files")
+
+	strategy := fs.String("strategy", "single", "generation strategy: single, multiple or package")
+	fs.StringVar(strategy, "s", "single", "short for --strategy")
+
+	verbose := fs.Bool("verbose", false, "log parsed directives, field decisions and output files to stderr")
+	fs.BoolVar(verbose, "V", false, "short for --verbose")
+
+	err := fs.Parse(processedArgs)
+	if err != nil {
+		log.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	cfg := loadCLIConfig(*configFile, os.Stdout)
+
+	// Override config with CLI flags (only if they were explicitly set)
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "pkg", "p":
+			cfg.Packages = []string{*pkg}
+		case "build-tags":
+			cfg.BuildTags = strings.Split(*buildTags, ",")
+		case "strategy", "s":
+			cfg.GenStrategy = generator.GenStrategy(*strategy)
+		case "verbose", "V":
+			cfg.Verbose = *verbose
+		}
+	})
+
+	diagnostics, err := generator.ValidateSchema(cfg)
+	if err != nil {
+		log.Fatalf("validation failed: %v", err)
+	}
+
+	errorCount := 0
+	for _, d := range diagnostics {
+		if d.Severity == generator.SeverityError {
+			errorCount++
+		}
+		fmt.Printf("%s: %s\n\n", d.Severity, strings.TrimSpace(d.Message))
+	}
+	warningCount := len(diagnostics) - errorCount
+
+	if errorCount > 0 {
+		fmt.Fprintf(os.Stderr, "validation failed: %d error(s), %d warning(s)\n", errorCount, warningCount)
+		os.Exit(1)
+	}
+	fmt.Printf("schema is valid (%d warning(s))\n", warningCount)
 }
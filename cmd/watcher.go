@@ -7,6 +7,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -16,10 +17,12 @@ import (
 
 // Watcher manages file system watching and regeneration
 type Watcher struct {
-	config        *generator.Config
-	watcher       *fsnotify.Watcher
-	debounceTimer *time.Timer
-	debounceDelay time.Duration
+	config          *generator.Config
+	watcher         *fsnotify.Watcher
+	debounceTimer   *time.Timer
+	debounceDelay   time.Duration
+	additionalPaths []string
+	mu              sync.Mutex
 }
 
 // StartWatch initializes the watcher and monitors for changes
@@ -55,7 +58,7 @@ func StartWatch(cfg *generator.Config) error {
 
 	// Add all package directories to watch
 	for _, pkg := range cfg.Packages {
-		if err := w.addRecursive(pkg); err != nil {
+		if err := w.addRecursive(generator.PackageRoot(pkg)); err != nil {
 			return fmt.Errorf("failed to watch %s: %w", pkg, err)
 		}
 	}
@@ -64,6 +67,10 @@ func StartWatch(cfg *generator.Config) error {
 	for _, path := range cfg.CLI.Watcher.AdditionalPaths {
 		if err := w.addRecursive(path); err != nil {
 			log.Printf("Warning: failed to watch additional path %s: %v", path, err)
+			continue
+		}
+		if absPath, err := filepath.Abs(path); err == nil {
+			w.additionalPaths = append(w.additionalPaths, absPath)
 		}
 	}
 
@@ -81,20 +88,7 @@ func StartWatch(cfg *generator.Config) error {
 				return nil
 			}
 
-			// Only watch Go files
-			if !strings.HasSuffix(event.Name, ".go") {
-				continue
-			}
-
-			// Ignore generated schema files
-			if strings.Contains(event.Name, cfg.Output) {
-				continue
-			}
-
-			// React to file changes
-			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) != 0 {
-				w.scheduleRegeneration(event.Name)
-			}
+			w.handleEvent(event)
 
 		case err, ok := <-watcher.Errors:
 			if !ok {
@@ -109,8 +103,35 @@ func StartWatch(cfg *generator.Config) error {
 	}
 }
 
+// handleEvent watches directories created after startup and schedules a regeneration for relevant changes
+// Directories are handled first: they are never relevant themselves, but the files created in them are
+func (w *Watcher) handleEvent(event fsnotify.Event) {
+	if event.Op&fsnotify.Create != 0 {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			if absPath, err := filepath.Abs(event.Name); err == nil && w.shouldWatch(absPath) {
+				if err := w.addRecursive(absPath); err != nil {
+					log.Printf("Warning: failed to watch %s: %v", event.Name, err)
+				}
+			}
+			return
+		}
+	}
+
+	if !w.isRelevant(event.Name) {
+		return
+	}
+
+	// React to file changes
+	if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) != 0 {
+		w.scheduleRegeneration(event.Name)
+	}
+}
+
 // scheduleRegeneration debounces file changes and triggers regeneration
 func (w *Watcher) scheduleRegeneration(changedFile string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
 	// Reset timer if it exists
 	if w.debounceTimer != nil {
 		w.debounceTimer.Stop()
@@ -136,7 +157,7 @@ func (w *Watcher) regenerate(changedFile string) {
 	fmt.Printf("[%s] 🔄 Change detected: %s\n", timestamp, relPath)
 	fmt.Println("Regenerating schema...")
 
-	// Run generation
+	// Run generation; errors are reported and the watcher keeps running
 	err := generator.Generate(w.config)
 	if err != nil {
 		fmt.Printf("\n❌ Generation failed: %v\n", err)
@@ -148,6 +169,7 @@ func (w *Watcher) regenerate(changedFile string) {
 }
 
 // addRecursive adds a directory and all its subdirectories to the watcher
+// A single .go file is watched directly
 func (w *Watcher) addRecursive(path string) error {
 	// Make path absolute
 	absPath, err := filepath.Abs(path)
@@ -155,6 +177,10 @@ func (w *Watcher) addRecursive(path string) error {
 		return err
 	}
 
+	if info, err := os.Stat(absPath); err == nil && !info.IsDir() {
+		return w.watcher.Add(absPath)
+	}
+
 	return filepath.Walk(absPath, func(walkPath string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -188,20 +214,70 @@ func (w *Watcher) shouldWatch(path string) bool {
 		return false
 	}
 
-	// Check against ignore patterns from config
-	for _, pattern := range w.config.CLI.Watcher.IgnorePatterns {
-		if base == pattern {
-			return false
-		}
+	if w.isIgnored(path) {
+		return false
 	}
 
 	// Skip the output directory to avoid watching generated files
-	if w.config.Output != "" {
-		absOutput, _ := filepath.Abs(w.config.Output)
-		if strings.HasPrefix(path, absOutput) {
-			return false
+	return !w.isOutput(path)
+}
+
+// isRelevant reports whether a change to path should trigger regeneration.
+// Go files are always relevant; other files only inside AdditionalPaths.
+func (w *Watcher) isRelevant(path string) bool {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		absPath = path
+	}
+
+	if w.isIgnored(absPath) || w.isOutput(absPath) {
+		return false
+	}
+
+	if strings.HasSuffix(absPath, ".go") {
+		return true
+	}
+
+	for _, additional := range w.additionalPaths {
+		if absPath == additional || strings.HasPrefix(absPath, additional+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isIgnored reports whether any element of path matches one of the ignore patterns.
+// Patterns use filepath.Match syntax, so "vendor" and "*_test.go" both work.
+func (w *Watcher) isIgnored(path string) bool {
+	// Match relative to the config dir so parent directories of the project don't count
+	if w.config.ConfigDir != "" {
+		if rel, err := filepath.Rel(w.config.ConfigDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
 		}
 	}
 
-	return true
+	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+		if part == "" {
+			continue
+		}
+		for _, pattern := range w.config.CLI.Watcher.IgnorePatterns {
+			if matched, _ := filepath.Match(pattern, part); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isOutput reports whether path is the generated schema output (file or directory)
+func (w *Watcher) isOutput(path string) bool {
+	if w.config.Output == "" {
+		return false
+	}
+	absOutput, err := filepath.Abs(w.config.Output)
+	if err != nil {
+		return false
+	}
+	return path == absOutput || strings.HasPrefix(path, absOutput+string(filepath.Separator))
 }
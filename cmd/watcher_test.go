@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pablor21/gqlschemagen/generator"
+)
+
+// nextEvent handles the watcher events until one for path arrives
+func nextEvent(t *testing.T, w *Watcher, path string) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-w.watcher.Events:
+			w.handleEvent(event)
+			if event.Name == path {
+				return
+			}
+		case err := <-w.watcher.Errors:
+			t.Fatalf("Watcher error: %v", err)
+		case <-timeout:
+			t.Fatalf("No event for %s", path)
+		}
+	}
+}
+
+func TestWatcherWatchesNewDirectories(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := generator.NewConfig()
+	cfg.ConfigDir = tmpDir
+	cfg.Output = filepath.Join(tmpDir, "graph")
+	cfg.CLI.Watcher.IgnorePatterns = []string{"vendor"}
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatalf("Failed to create watcher: %v", err)
+	}
+	defer func() {
+		_ = fsw.Close()
+	}()
+
+	// A long debounce keeps the test from regenerating
+	w := &Watcher{config: cfg, watcher: fsw, debounceDelay: time.Hour}
+	if err := w.addRecursive(tmpDir); err != nil {
+		t.Fatalf("addRecursive failed: %v", err)
+	}
+
+	models := filepath.Join(tmpDir, "models")
+	vendor := filepath.Join(tmpDir, "vendor")
+	for _, dir := range []string{vendor, models} {
+		if err := os.Mkdir(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	nextEvent(t, w, models)
+
+	watched := fsw.WatchList()
+	if !slices.Contains(watched, models) {
+		t.Errorf("Expected the new directory to be watched, got %v", watched)
+	}
+	if slices.Contains(watched, vendor) {
+		t.Errorf("Expected ignored directories to stay unwatched, got %v", watched)
+	}
+
+	// Go files created in the new directory trigger a regeneration
+	file := filepath.Join(models, "user.go")
+	if err := os.WriteFile(file, []byte("package models\n"), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", file, err)
+	}
+	nextEvent(t, w, file)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.debounceTimer == nil {
+		t.Fatal("Expected a regeneration to be scheduled")
+	}
+	w.debounceTimer.Stop()
+}
@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAnyFieldsMapToAnyScalar(t *testing.T) {
+	src := `package models
+
+type Metadata interface{}
+
+type Handler interface {
+	Handle() error
+}
+
+// @gqlType
+// @gqlInput(name:"EventInput")
+type Event struct {
+	Name     string      ` + "`gql:\"name\"`" + `
+	Data     any         ` + "`gql:\"data\"`" + `
+	Raw      interface{} ` + "`gql:\"raw\"`" + `
+	Extra    *any        ` + "`gql:\"extra\"`" + `
+	Items    []any       ` + "`gql:\"items\"`" + `
+	Metadata Metadata    ` + "`gql:\"metadata\"`" + `
+	Handler  Handler     ` + "`gql:\"handler\"`" + `
+}
+`
+
+	generate := func(t *testing.T, configure func(*Config)) string {
+		t.Helper()
+		tmpDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(tmpDir, "event.go"), []byte(src), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+
+		p := NewParser()
+		if err := p.Walk(tmpDir); err != nil {
+			t.Fatalf("Walk() error = %v", err)
+		}
+
+		config := NewConfig()
+		config.Output = filepath.Join(tmpDir, "schema.graphqls")
+		config.GenStrategy = GenStrategySingle
+		config.AutoGenerate.OutOfScopeTypes = OutOfScopeFail
+		configure(config)
+
+		files, err := NewGenerator(p, config).Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		return files[config.Output]
+	}
+
+	t.Run("default scalar", func(t *testing.T) {
+		schema := generate(t, func(*Config) {})
+
+		for _, want := range []string{
+			"  data: JSON!",
+			"  raw: JSON!",
+			"  extra: JSON!",
+			"  items: [JSON!]!",
+			"  metadata: JSON!",
+		} {
+			if strings.Count(schema, want) != 2 {
+				t.Errorf("Expected %q in both the type and the input, got:\n%s", want, schema)
+			}
+		}
+
+		// Interfaces with methods are skipped by default
+		if strings.Contains(schema, "handler") {
+			t.Errorf("Expected handler field to be skipped, got:\n%s", schema)
+		}
+	})
+
+	t.Run("custom scalar and interfaces", func(t *testing.T) {
+		schema := generate(t, func(c *Config) {
+			c.AnyScalar = "Any"
+			c.InterfacesAsAnyScalar = true
+		})
+
+		for _, want := range []string{"  data: Any!", "  raw: Any!", "  metadata: Any!", "  handler: Any!"} {
+			if strings.Count(schema, want) != 2 {
+				t.Errorf("Expected %q in both the type and the input, got:\n%s", want, schema)
+			}
+		}
+		if strings.Contains(schema, "JSON") {
+			t.Errorf("Expected no JSON scalar with any_scalar: Any, got:\n%s", schema)
+		}
+	})
+}
@@ -23,15 +23,20 @@ type TypeNode struct {
 	HasInputDirective bool // Has explicit @gqlInput
 	ShouldGenType     bool // Should be generated as type
 	ShouldGenInput    bool // Should be generated as input
+	Ignored           bool // Has @gqlIgnore, never generated nor traversed
 	Depth             int  // Distance from nearest annotated type
 	References        []string
 	ReferencedBy      []string
+	// InputSources lists the structs its inputs take their fields from (@gqlInput from:), when any input uses from
+	InputSources []string
 }
 
 // DependencyGraph tracks type relationships
 type DependencyGraph struct {
 	Nodes map[string]*TypeNode
 	Edges map[string][]string // fromType -> []toTypes
+	// Truncated lists the referenced types left out because AutoGenerate.MaxTypes was reached
+	Truncated []string
 }
 
 // NewDependencyGraph creates a new dependency graph
@@ -82,6 +87,48 @@ func (g *DependencyGraph) AddEdge(fromType, toType string) {
 	}
 }
 
+// MarkIgnored flags a type as ignored (@gqlIgnore): it is neither generated nor traversed,
+// so types only reachable through it are not auto-generated either
+func (g *DependencyGraph) MarkIgnored(typeName string) {
+	node, exists := g.Nodes[typeName]
+	if !exists {
+		return
+	}
+	node.Ignored = true
+	node.IsAnnotated = false
+	node.ShouldGenType = false
+	node.ShouldGenInput = false
+}
+
+// inputSources returns the structs the inputs of a type take their fields from, the type itself for inputs without from:
+func inputSources(typeName string, d StructDirectives) []string {
+	var sources []string
+	for _, inputDef := range d.Inputs {
+		source := inputDef.FromType
+		if source == "" {
+			source = typeName
+		}
+		if !contains(sources, source) {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// inputReferences returns the types the inputs of typeName reference: the fields of its
+// input sources (@gqlInput from:) when set, or its own fields
+func (g *DependencyGraph) inputReferences(typeName string) []string {
+	node := g.Nodes[typeName]
+	if node == nil || len(node.InputSources) == 0 {
+		return g.Edges[typeName]
+	}
+	var refs []string
+	for _, source := range node.InputSources {
+		refs = append(refs, g.Edges[source]...)
+	}
+	return refs
+}
+
 // BuildDependencyGraph constructs a dependency graph from parsed structs
 func (g *Generator) BuildDependencyGraph() *DependencyGraph {
 	graph := NewDependencyGraph()
@@ -103,11 +150,25 @@ func (g *Generator) BuildDependencyGraph() *DependencyGraph {
 			isGenericAlias = true
 		}
 
-		isAnnotated := directives.HasTypeDirective || directives.HasInputDirective || directives.HasIncludeDirective || isGenericAlias
-		hasType := directives.HasTypeDirective || isGenericAlias
-		hasInput := directives.HasInputDirective || isGenericAlias
+		// Interfaces are output types, so their field types are auto-generated as types
+		isAnnotated := directives.HasTypeDirective || directives.HasInputDirective || directives.HasInterfaceDirective || directives.HasIncludeDirective || isGenericAlias
+		hasType := directives.HasTypeDirective || directives.HasInterfaceDirective || isGenericAlias
+		hasInput := directives.HasInputDirective || isGenericAlias || g.genInputFor(directives)
 
 		graph.AddNode(typeName, packagePath, isAnnotated, hasType, hasInput, directives.HasIncludeDirective)
+
+		// @gqlIgnore types stay in the graph so references to them are known, but are never generated
+		if directives.SkipType {
+			graph.MarkIgnored(typeName)
+		}
+
+		// Inputs built with from: take their fields from another struct, which the input context follows
+		for _, inputDef := range directives.Inputs {
+			if inputDef.FromType != "" {
+				graph.Nodes[typeName].InputSources = inputSources(typeName, directives)
+				break
+			}
+		}
 	}
 
 	// Add enums as annotated nodes (enums are always types, never inputs)
@@ -127,6 +188,10 @@ func (g *Generator) BuildDependencyGraph() *DependencyGraph {
 			continue
 		}
 		for _, field := range structType.Fields.List {
+			// Excluded types never become nodes of the graph
+			if g.isExcludedType(field.Type) {
+				continue
+			}
 			// For embedded fields, only extract nested references, not the embedded type itself
 			// Embedded types don't need to be generated separately - their fields are inlined
 			if field.Names == nil { // Embedded field
@@ -138,7 +203,7 @@ func (g *Generator) BuildDependencyGraph() *DependencyGraph {
 				}
 			} else {
 				// For named fields, extract the field type (which SHOULD be generated)
-				referencedTypes := g.extractTypeReferences(field.Type)
+				referencedTypes := g.fieldReferences(field)
 				for _, refType := range referencedTypes {
 					if refType != "" && refType != typeName {
 						graph.AddEdge(typeName, refType)
@@ -152,71 +217,38 @@ func (g *Generator) BuildDependencyGraph() *DependencyGraph {
 }
 
 // extractTypeReferences extracts all type names referenced in an AST expression
+// It shares resolveFieldType with the field renderer, so only types the rendered field uses are followed
 func (g *Generator) extractTypeReferences(expr ast.Expr) []string {
-	var types []string
-
-	switch t := expr.(type) {
-	case *ast.Ident:
-		// Simple type: User, string, int, etc.
-		if !isBuiltinType(t.Name) {
-			types = append(types, t.Name)
-		}
-
-	case *ast.StarExpr:
-		// Pointer: *User
-		types = append(types, g.extractTypeReferences(t.X)...)
-
-	case *ast.ArrayType:
-		// Slice/Array: []User, []*User
-		types = append(types, g.extractTypeReferences(t.Elt)...)
-
-	case *ast.SelectorExpr:
-		// Package-qualified: pkg.User
-		if ident, ok := t.X.(*ast.Ident); ok {
-			// Try to find the type in our structs
-			typeName := t.Sel.Name
-			if ts, exists := g.P.StructTypes[typeName]; exists {
-				if _, ok := ts.Type.(*ast.StructType); ok {
-					types = append(types, typeName)
-				}
-			}
-			// Also check with package prefix
-			fullName := ident.Name + "." + typeName
-			if ts, exists := g.P.StructTypes[fullName]; exists {
-				if _, ok := ts.Type.(*ast.StructType); ok {
-					types = append(types, fullName)
-				}
-			}
-		}
-
-	case *ast.MapType:
-		// Map: map[string]User
-		types = append(types, g.extractTypeReferences(t.Key)...)
-		types = append(types, g.extractTypeReferences(t.Value)...)
-
-	case *ast.IndexExpr:
-		// Generic with single type param: Connection[T]
-		baseTypes := g.extractTypeReferences(t.X)
-		types = append(types, baseTypes...)
-		argTypes := g.extractTypeReferences(t.Index)
-		types = append(types, argTypes...)
+	_, refs := g.resolveFieldType(expr)
+	return refs
+}
 
-	case *ast.IndexListExpr:
-		// Generic with multiple type params: Map[K, V]
-		baseTypes := g.extractTypeReferences(t.X)
-		types = append(types, baseTypes...)
-		for _, index := range t.Indices {
-			argTypes := g.extractTypeReferences(index)
-			types = append(types, argTypes...)
+// fieldReferences extracts the type names a struct field references
+// Maps are a scalar unless rendered as key-value pairs, which reference the key and value types
+func (g *Generator) fieldReferences(field *ast.Field) []string {
+	if mapType := g.pairsMapType(field.Type, ParseFieldOptions(field, g.Config)); mapType != nil {
+		return append(g.extractTypeReferences(mapType.Key), g.extractTypeReferences(mapType.Value)...)
+	}
+	if inline := g.inlineStructType(field.Type); inline != nil {
+		// The synthesized type depends on whatever the struct's own fields reference
+		var refs []string
+		for _, f := range inline.Fields.List {
+			refs = append(refs, g.fieldReferences(f)...)
 		}
+		return refs
 	}
-
-	return types
+	return g.extractTypeReferences(field.Type)
 }
 
 // extractEmbeddedTypeReferences recursively extracts type references from an embedded type's fields
 // This is needed to catch dependencies in generic types like Connection[T] which embeds Edge[T] and references PageInfo
 func (g *Generator) extractEmbeddedTypeReferences(expr ast.Expr) []string {
+	return g.extractEmbeddedTypeReferencesVisited(expr, make(map[string]bool))
+}
+
+// extractEmbeddedTypeReferencesVisited is extractEmbeddedTypeReferences with a guard against
+// embedding cycles (e.g., type Node struct { *Node })
+func (g *Generator) extractEmbeddedTypeReferencesVisited(expr ast.Expr, visited map[string]bool) []string {
 	var types []string
 
 	// First, extract type arguments from generic embedded types (e.g., Connection[Comment])
@@ -290,9 +322,10 @@ func (g *Generator) extractEmbeddedTypeReferences(expr ast.Expr) []string {
 		embeddedTypeName = t.Sel.Name
 	}
 
-	if embeddedTypeName == "" {
+	if embeddedTypeName == "" || visited[embeddedTypeName] {
 		return types
 	}
+	visited[embeddedTypeName] = true
 
 	// Look up the embedded type
 	typeSpec, exists := g.P.StructTypes[embeddedTypeName]
@@ -307,12 +340,12 @@ func (g *Generator) extractEmbeddedTypeReferences(expr ast.Expr) []string {
 
 	// Extract references from all fields of the embedded type
 	for _, field := range structType.Fields.List {
-		fieldRefs := g.extractTypeReferences(field.Type)
+		fieldRefs := g.fieldReferences(field)
 		types = append(types, fieldRefs...)
 
 		// Recursively handle nested embedded fields
 		if field.Names == nil {
-			nestedRefs := g.extractEmbeddedTypeReferences(field.Type)
+			nestedRefs := g.extractEmbeddedTypeReferencesVisited(field.Type, visited)
 			types = append(types, nestedRefs...)
 		}
 	}
@@ -336,7 +369,7 @@ func (g *DependencyGraph) MarkTypesForGeneration(config *Config) {
 	case AutoGenAll:
 		// Generate everything as both types and inputs
 		for _, node := range g.Nodes {
-			if !g.isExcluded(node, config) {
+			if !node.Ignored && !g.isExcluded(node, config) {
 				if !node.HasInputDirective {
 					node.ShouldGenType = true
 				}
@@ -364,7 +397,24 @@ func (g *DependencyGraph) markReferencedTypes(config *Config) {
 	inputQueue := []string{}                    // Inputs that reference other types
 	visited := make(map[string]map[string]bool) // typeName -> map["type"|"input"]bool
 
-	for typeName, node := range g.Nodes {
+	// Types are added in BFS order until the max_types cap is reached, the rest are reported
+	// Seeds are visited in name order so the same types are kept on every run
+	added := make(map[string]bool)
+	skipped := make(map[string]bool)
+	allow := func(typeName string) bool {
+		if added[typeName] {
+			return true
+		}
+		if maxTypes := config.AutoGenerate.MaxTypes; maxTypes > 0 && len(added) >= maxTypes {
+			skipped[typeName] = true
+			return false
+		}
+		added[typeName] = true
+		return true
+	}
+
+	for _, typeName := range sortedKeys(g.Nodes) {
+		node := g.Nodes[typeName]
 		if node.IsAnnotated {
 			node.Depth = 0
 			visited[typeName] = make(map[string]bool)
@@ -409,8 +459,12 @@ func (g *DependencyGraph) markReferencedTypes(config *Config) {
 				continue
 			}
 
-			// Check exclusion patterns
-			if g.isExcluded(refNode, config) {
+			// Check @gqlIgnore and exclusion patterns
+			if refNode.Ignored || g.isExcluded(refNode, config) {
+				continue
+			}
+
+			if !allow(refType) {
 				continue
 			}
 
@@ -426,39 +480,40 @@ func (g *DependencyGraph) markReferencedTypes(config *Config) {
 	}
 
 	// BFS traversal for input context
+	// MaxDepth is not applied here: an input is only usable if every nested struct it
+	// references also has an input variant, so the full transitive closure is generated
 	for len(inputQueue) > 0 {
 		current := inputQueue[0]
 		inputQueue = inputQueue[1:]
 
-		currentNode := g.Nodes[current]
-		currentDepth := currentNode.Depth
-
-		// Check depth limit
-		maxDepth := config.AutoGenerate.MaxDepth
-		if maxDepth > 0 && currentDepth >= maxDepth {
-			continue
-		}
+		currentDepth := g.Nodes[current].Depth
 
 		// Process references - they should also be inputs
-		for _, refType := range g.Edges[current] {
+		for _, refType := range g.inputReferences(current) {
 			refNode, exists := g.Nodes[refType]
 			if !exists {
 				continue
 			}
 
-			// Skip if already visited in input context with lower or equal depth
-			if visited[refType] != nil && visited[refType]["input"] && refNode.Depth <= currentDepth+1 {
+			// Skip if already visited in input context
+			if visited[refType] != nil && visited[refType]["input"] {
 				continue
 			}
 
-			// Check exclusion patterns
-			if g.isExcluded(refNode, config) {
+			// Check @gqlIgnore and exclusion patterns
+			if refNode.Ignored || g.isExcluded(refNode, config) {
+				continue
+			}
+
+			if !allow(refType) {
 				continue
 			}
 
 			// Mark for generation as input
 			refNode.ShouldGenInput = true
-			refNode.Depth = currentDepth + 1
+			if refNode.Depth < 0 || refNode.Depth > currentDepth+1 {
+				refNode.Depth = currentDepth + 1
+			}
 			if visited[refType] == nil {
 				visited[refType] = make(map[string]bool)
 			}
@@ -466,13 +521,15 @@ func (g *DependencyGraph) markReferencedTypes(config *Config) {
 			inputQueue = append(inputQueue, refType)
 		}
 	}
+
+	g.Truncated = sortedKeys(skipped)
 }
 
 // markByPatterns marks types matching inclusion patterns
 func (g *DependencyGraph) markByPatterns(config *Config) {
 	for _, node := range g.Nodes {
 		// Annotated types already have their generation flags set
-		if node.IsAnnotated {
+		if node.IsAnnotated || node.Ignored {
 			continue
 		}
 
@@ -567,6 +624,11 @@ func (g *Generator) applyAutoGeneration(graph *DependencyGraph) {
 	// We just need to mark which ones should actually generate schemas
 
 	for typeName, node := range graph.Nodes {
+		// Skip types with @gqlIgnore or @gqlskip, even when reachable from annotated types
+		if node.Ignored {
+			continue
+		}
+
 		// Skip if type doesn't exist in parser's StructTypes
 		typeSpec, exists := g.P.StructTypes[typeName]
 		if !exists {
@@ -583,14 +645,9 @@ func (g *Generator) applyAutoGeneration(graph *DependencyGraph) {
 		genDecl := g.P.TypeToDecl[typeName]
 		directives := ParseDirectives(typeSpec, genDecl)
 
-		// Skip types with @gqlIgnore or @gqlskip
-		if directives.SkipType {
-			continue
-		}
-
 		// Auto-generate as type if needed and not explicitly annotated with @gqlType
-		// Types with @GqlInclude are eligible for auto-generation
-		if node.ShouldGenType && !directives.HasTypeDirective {
+		// Types with @GqlInclude are eligible for auto-generation, @gqlInterface/@gqlUnion structs are already emitted
+		if node.ShouldGenType && !directives.HasTypeDirective && !directives.HasInterfaceDirective && !directives.HasUnionDirective {
 			g.AutoGeneratedTypes[typeName] = true
 		}
 
@@ -601,3 +658,37 @@ func (g *Generator) applyAutoGeneration(graph *DependencyGraph) {
 		}
 	}
 }
+
+// applyGenInputs marks every @gqlType struct without @gqlInput for input generation (gen_inputs)
+// The inputs are generated like auto-generated ones, so they take the default input name
+func (g *Generator) applyGenInputs() {
+	for _, typeName := range g.P.TypeNames {
+		typeSpec, exists := g.P.StructTypes[typeName]
+		if !exists {
+			continue
+		}
+		if _, isStruct := typeSpec.Type.(*ast.StructType); !isStruct {
+			continue
+		}
+		if typeSpec.TypeParams != nil && typeSpec.TypeParams.NumFields() > 0 {
+			continue
+		}
+		if g.genInputFor(ParseDirectives(typeSpec, g.P.TypeToDecl[typeName])) {
+			g.AutoGeneratedInputs[typeName] = true
+		}
+	}
+}
+
+// genInputFor reports whether gen_inputs adds an input for a struct with these directives
+func (g *Generator) genInputFor(d StructDirectives) bool {
+	if !g.Config.GenInputs || d.SkipType || !d.HasTypeDirective || d.HasInputDirective {
+		return false
+	}
+	// Extensions such as extend type Query have no input counterpart
+	for _, typeDef := range d.Types {
+		if !typeDef.Extend {
+			return true
+		}
+	}
+	return false
+}
@@ -319,3 +319,258 @@ type UpdateUserInput struct {
 		t.Error("Expected Settings to be marked as auto-generated input")
 	}
 }
+
+// TestAutoGenNestedInputsRecursive tests that every struct reachable from an input gets an
+// input variant (regardless of max_depth) and that nested fields reference the *Input names
+func TestAutoGenNestedInputsRecursive(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	modelsFile := `package models
+
+type Address struct {
+	Street string
+}
+
+type OrderItem struct {
+	SKU     string
+	Qty     int
+	Address *Address
+}
+
+/**
+ * @gqlInput(name:"CreateOrderInput")
+ */
+type Order struct {
+	ID    string
+	Items []OrderItem
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "order.go"), []byte(modelsFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	parser := NewParser()
+	if err := parser.Walk(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+	config.AutoGenerate.Enabled = true
+	config.AutoGenerate.Strategy = AutoGenReferenced
+	config.AutoGenerate.MaxDepth = 1
+
+	gen := NewGenerator(parser, config)
+	if err := gen.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(config.Output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := string(content)
+
+	expected := []string{
+		"items: [OrderItemInput!]!",
+		"input OrderItemInput {",
+		"address: AddressInput!",
+		"input AddressInput {",
+	}
+	for _, want := range expected {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain %q, got:\n%s", want, schema)
+		}
+	}
+}
+
+// TestInputListOfTypeWithoutInputIsSkipped tests that a list field whose element type has
+// no input variant is skipped instead of rendering an invalid "[]!" type
+func TestInputListOfTypeWithoutInputIsSkipped(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	modelsFile := `package models
+
+type OrderItem struct {
+	SKU string
+}
+
+/**
+ * @gqlInput(name:"CreateOrderInput")
+ */
+type Order struct {
+	ID    string
+	Items []OrderItem ` + "`gql:\"optional\"`" + `
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "order.go"), []byte(modelsFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	parser := NewParser()
+	if err := parser.Walk(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+	config.AutoGenerate.Enabled = false
+
+	gen := NewGenerator(parser, config)
+	if err := gen.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(config.Output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := string(content)
+
+	if strings.Contains(schema, "items:") {
+		t.Errorf("Expected items field to be skipped, got:\n%s", schema)
+	}
+}
+
+// TestInputRequiredObjectFieldWithoutInputFails tests that required input fields referencing
+// object types without an input fail generation instead of being dropped from the schema
+func TestInputRequiredObjectFieldWithoutInputFails(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	modelsFile := `package models
+
+type Status string
+
+const (
+	StatusOpen Status = "open"
+)
+
+type Address struct {
+	Street string
+}
+
+type OrderItem struct {
+	SKU string
+}
+
+/**
+ * @gqlInput(name:"CreateOrderInput")
+ */
+type Order struct {
+	ID       string
+	Status   Status
+	Address  Address
+	Items    []OrderItem
+	Billing  *Address ` + "`gql:\"optional\"`" + `
+	Internal Address  ` + "`gql:\"-\"`" + `
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "order.go"), []byte(modelsFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	parser := NewParser()
+	if err := parser.Walk(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+	config.AutoGenerate.Enabled = false
+
+	gen := NewGenerator(parser, config)
+	err := gen.Run()
+	if err == nil {
+		t.Fatal("Expected generation to fail for required object fields without inputs")
+	}
+
+	msg := err.Error()
+	for _, want := range []string{
+		"CreateOrderInput.address (Go: Order.Address) references Address",
+		"CreateOrderInput.items (Go: Order.Items) references OrderItem",
+		"@gqlInput",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected error to contain %q, got:\n%s", want, msg)
+		}
+	}
+	for _, unwanted := range []string{"status", "billing", "internal"} {
+		if strings.Contains(msg, "CreateOrderInput."+unwanted) {
+			t.Errorf("Did not expect field %q to be reported, got:\n%s", unwanted, msg)
+		}
+	}
+
+	if _, statErr := os.Stat(config.Output); !os.IsNotExist(statErr) {
+		t.Error("Expected no schema file to be written")
+	}
+
+	// With auto-generation the nested inputs are generated and the check passes
+	config.AutoGenerate.Enabled = true
+	gen = NewGenerator(parser, config)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Expected auto-generated nested inputs to satisfy the check, got: %v", err)
+	}
+}
+
+// TestSelfReferentialInput tests that self-referencing inputs (a common filter pattern)
+// are generated without infinite recursion and reference themselves by their own name
+func TestSelfReferentialInput(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	modelsFile := `package models
+
+/**
+ * @gqlInput
+ */
+type WhereInput struct {
+	And   []*WhereInput
+	Not   *WhereInput
+	Field string
+}
+
+/**
+ * @gqlInput
+ */
+type Node struct {
+	*Node
+	Children []*Node
+	Name     string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "filter.go"), []byte(modelsFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	parser := NewParser()
+	if err := parser.Walk(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+
+	gen := NewGenerator(parser, config)
+	if err := gen.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(config.Output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := string(content)
+
+	expected := []string{
+		"input WhereInput {\n  and: [WhereInput!]!\n  not: WhereInput\n  field: String!\n}",
+		"input NodeInput {\n  children: [NodeInput!]!\n  name: String!\n}",
+	}
+	for _, want := range expected {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain:\n%s\ngot:\n%s", want, schema)
+		}
+	}
+}
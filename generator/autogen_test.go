@@ -158,6 +158,84 @@ type Root struct {
 	}
 }
 
+func TestAutoGenerateMaxTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := `package models
+
+type Level5 struct {
+	Value string
+}
+
+type Level4 struct {
+	Next *Level5
+}
+
+type Level3 struct {
+	Next *Level4
+}
+
+type Level2 struct {
+	Next *Level3
+}
+
+type Level1 struct {
+	Next  *Level2
+	Other *Sibling
+}
+
+type Sibling struct {
+	Name string
+}
+
+// @gqlType
+type Root struct {
+	Child *Level1
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	parser := NewParser()
+	if err := parser.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+	config.AutoGenerate.Enabled = true
+	config.AutoGenerate.Strategy = AutoGenReferenced
+	config.AutoGenerate.MaxDepth = 0 // unlimited, only the cap stops the traversal
+	config.AutoGenerate.MaxTypes = 3
+
+	gen := NewGenerator(parser, config)
+	graph := gen.BuildDependencyGraph()
+	graph.MarkTypesForGeneration(config)
+
+	// Breadth-first: Level1, then its references Level2 and Sibling, Level3 is the first one cut
+	if got := strings.Join(graph.Truncated, ","); got != "Level3" {
+		t.Errorf("Expected Level3 to be reported as truncated, got %q", got)
+	}
+
+	files, err := gen.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[config.Output]
+
+	for _, name := range []string{"Root", "Level1", "Level2", "Sibling"} {
+		if !strings.Contains(schema, "type "+name+" {") {
+			t.Errorf("Schema should contain %s, got:\n%s", name, schema)
+		}
+	}
+	for _, name := range []string{"Level3", "Level4", "Level5"} {
+		if strings.Contains(schema, "type "+name+" {") {
+			t.Errorf("Schema should NOT contain %s (over max_types), got:\n%s", name, schema)
+		}
+	}
+}
+
 func TestAutoGenerateDisabled(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -281,3 +359,80 @@ type Main struct {
 		t.Error("Schema should NOT contain IgnoredType (has @gqlIgnore)")
 	}
 }
+
+func TestAutoGenerateSkipsTypesReachableOnlyThroughGqlIgnore(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	content := `package models
+
+// @gqlIgnore
+type InternalDTO struct {
+	Secret string
+	Detail *InternalDetail
+}
+
+type InternalDetail struct {
+	Value string
+}
+
+type Shared struct {
+	Value string
+}
+
+// @gqlType
+// @gqlInput(name:"MainInput")
+type Main struct {
+	Name   string
+	DTO    *InternalDTO
+	Shared Shared
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	for _, strategy := range []AutoGenerateStrategy{AutoGenReferenced, AutoGenAll} {
+		t.Run(string(strategy), func(t *testing.T) {
+			p := NewParser()
+			if err := p.Walk(tmpDir); err != nil {
+				t.Fatalf("Walk() error = %v", err)
+			}
+
+			config := NewConfig()
+			config.Output = filepath.Join(tmpDir, "schema.graphqls")
+			config.GenStrategy = GenStrategySingle
+			config.AutoGenerate.Enabled = true
+			config.AutoGenerate.Strategy = strategy
+			config.AutoGenerate.OutOfScopeTypes = OutOfScopeExclude
+
+			gen := NewGenerator(p, config)
+			graph := gen.BuildDependencyGraph()
+			if node := graph.Nodes["InternalDTO"]; node == nil || !node.Ignored {
+				t.Fatalf("Expected InternalDTO to be marked ignored in the dependency graph")
+			}
+
+			files, err := gen.Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			schema := files[config.Output]
+
+			if !strings.Contains(schema, "type Shared") || !strings.Contains(schema, "input SharedInput") {
+				t.Errorf("Expected the referenced Shared type and input, got:\n%s", schema)
+			}
+			for _, unwanted := range []string{"InternalDTO", "dto:"} {
+				if strings.Contains(schema, unwanted) {
+					t.Errorf("Schema should not contain %q, got:\n%s", unwanted, schema)
+				}
+			}
+			// With referenced, types only reachable through the ignored type are not generated
+			// (all generates every struct, so InternalDetail is expected there)
+			if strategy == AutoGenReferenced && strings.Contains(schema, "InternalDetail") {
+				t.Errorf("Schema should not contain InternalDetail, got:\n%s", schema)
+			}
+			if gen.AutoGeneratedTypes["InternalDTO"] || gen.AutoGeneratedInputs["InternalDTO"] {
+				t.Error("InternalDTO must not be auto-generated")
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeBuildTagFixtures(t *testing.T) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"public.go": `package models
+
+// @gqlType
+type Public struct {
+	ID string
+}
+`,
+		"experimental.go": `//go:build experimental
+
+package models
+
+// @gqlType
+type Experimental struct {
+	ID string
+}
+`,
+		"stable.go": `//go:build !experimental
+
+package models
+
+// @gqlType
+type Stable struct {
+	ID string
+}
+`,
+		"excluded.go": `// @gqlExclude
+package models
+
+// @gqlType
+type Excluded struct {
+	ID string
+}
+`,
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return tmpDir
+}
+
+func TestBuildTagsSelectFiles(t *testing.T) {
+	tests := []struct {
+		name      string
+		buildTags []string
+		want      map[string]bool
+	}{
+		{
+			name:      "no build tags scans every file",
+			buildTags: nil,
+			want:      map[string]bool{"Public": true, "Experimental": true, "Stable": true, "Excluded": false},
+		},
+		{
+			name:      "experimental tag",
+			buildTags: []string{"experimental"},
+			want:      map[string]bool{"Public": true, "Experimental": true, "Stable": false, "Excluded": false},
+		},
+		{
+			name:      "unrelated tag",
+			buildTags: []string{"public"},
+			want:      map[string]bool{"Public": true, "Experimental": false, "Stable": true, "Excluded": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := writeBuildTagFixtures(t)
+
+			p := NewParser()
+			p.BuildTags = tt.buildTags
+			if err := p.Walk(tmpDir); err != nil {
+				t.Fatalf("Walk() error = %v", err)
+			}
+
+			for typeName, want := range tt.want {
+				_, got := p.StructTypes[typeName]
+				if got != want {
+					t.Errorf("type %s scanned = %v, want %v", typeName, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGqlExcludeOnFirstTypeDocIsNotFileLevel(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := `package models
+
+// @gqlExclude
+type First struct {
+	ID string
+}
+
+// @gqlType
+type Second struct {
+	ID string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	if _, ok := p.StructTypes["Second"]; !ok {
+		t.Error("Expected file to be scanned when @gqlExclude is a type doc comment")
+	}
+}
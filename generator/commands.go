@@ -26,7 +26,65 @@ func GenerateFromConfigFile(configPath string) error {
 }
 
 // Generate runs the schema generation with the provided configuration
+// All cfg.Packages are parsed before enum constants are matched, so enum values
+// declared in another file or package are always picked up
 func Generate(cfg *Config) error {
+	engine, err := newGeneratorFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Generate schema
+	if err := engine.Run(); err != nil {
+		return fmt.Errorf("generation error: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateSchema parses the configured packages and collects every problem the generated schema
+// would have, without writing any files (see Generator.Validate)
+// The error is only set when the configuration is invalid or the packages cannot be parsed
+func ValidateSchema(cfg *Config) ([]Diagnostic, error) {
+	engine, err := newGeneratorFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return engine.Validate(), nil
+}
+
+// GenerateToString runs the schema generation for the single-file strategy and
+// returns the schema instead of writing it to disk. No generated-code header or
+// keep sections are added, which makes the output suitable for golden-file tests.
+func GenerateToString(cfg *Config) (string, error) {
+	if cfg.GenStrategy != "" && cfg.GenStrategy != GenStrategySingle {
+		return "", fmt.Errorf("GenerateToString requires the 'single' strategy, got '%s'", cfg.GenStrategy)
+	}
+	cfg.GenStrategy = GenStrategySingle
+
+	engine, err := newGeneratorFromConfig(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	fileContents, err := engine.Build()
+	if err != nil {
+		return "", fmt.Errorf("generation error: %w", err)
+	}
+
+	// Namespaces split the output even with the single strategy
+	if len(fileContents) > 1 {
+		return "", fmt.Errorf("GenerateToString produced %d files (namespaces are not supported)", len(fileContents))
+	}
+	for _, content := range fileContents {
+		return content, nil
+	}
+	return "", nil
+}
+
+// newGeneratorFromConfig normalizes and validates the configuration, parses all
+// configured packages and returns a generator ready to run
+func newGeneratorFromConfig(cfg *Config) (*Generator, error) {
 	// Normalize configuration
 	cfg.Normalize()
 
@@ -44,25 +102,16 @@ func Generate(cfg *Config) error {
 
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("config validation error: %w", err)
+		return nil, fmt.Errorf("config validation error: %w", err)
 	}
 
-	// Parse all packages
+	// Parse all packages, enum constants are matched afterwards (supports cross-package enums)
 	parser := NewParser()
-	for _, pkgPath := range cfg.Packages {
-		if err := parser.Walk(PkgDir(pkgPath)); err != nil {
-			return fmt.Errorf("parse error for package %s: %w", pkgPath, err)
-		}
+	parser.BuildTags = cfg.BuildTags
+	parser.ExcludePaths = cfg.ExcludePaths
+	if err := parser.ParsePackages(cfg.Packages); err != nil {
+		return nil, err
 	}
 
-	// Match enum constants after all packages are parsed (supports cross-package enums)
-	parser.MatchEnumConstants()
-
-	// Generate schema
-	engine := NewGenerator(parser, cfg)
-	if err := engine.Run(); err != nil {
-		return fmt.Errorf("generation error: %w", err)
-	}
-
-	return nil
+	return NewGenerator(parser, cfg), nil
 }
@@ -0,0 +1,307 @@
+package generator
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateToString(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlType
+type User struct {
+	ID   string
+	Name string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "user.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Packages = []string{tmpDir}
+	cfg.GenStrategy = GenStrategySingle
+	cfg.Output = filepath.Join(tmpDir, "out", "schema.graphqls")
+
+	schema, err := GenerateToString(cfg)
+	if err != nil {
+		t.Fatalf("GenerateToString failed: %v", err)
+	}
+
+	expected := "type User {\n  id: String!\n  name: String!\n}\n"
+	if !strings.Contains(schema, expected) {
+		t.Errorf("Expected schema to contain:\n%s\ngot:\n%s", expected, schema)
+	}
+	if strings.Contains(schema, "Code generated") {
+		t.Errorf("Schema should not contain the generated-code header, got:\n%s", schema)
+	}
+	if FileExists(cfg.Output) {
+		t.Errorf("GenerateToString should not write %s", cfg.Output)
+	}
+}
+
+func TestGenerateToStringRejectsMultipleStrategy(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Packages = []string{t.TempDir()}
+	cfg.GenStrategy = GenStrategyMultiple
+
+	if _, err := GenerateToString(cfg); err == nil {
+		t.Error("Expected error for non-single strategy")
+	}
+}
+
+func TestGenerateFromSingleFileMatchesEnums(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlEnum
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusInactive Status = "inactive"
+)
+
+// @gqlType
+type Account struct {
+	ID     string
+	Status Status
+}
+`
+	file := filepath.Join(tmpDir, "account.go")
+	if err := os.WriteFile(file, []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Packages = []string{file}
+	cfg.GenStrategy = GenStrategySingle
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+
+	if err := Generate(cfg); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	content, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	schema := string(content)
+
+	for _, want := range []string{"enum Status {", "ACTIVE", "INACTIVE", "status: Status!"} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain %q, got:\n%s", want, schema)
+		}
+	}
+}
+
+// writeModelsModule writes a module with a models subpackage holding User and Post,
+// and changes into its directory until the test ends
+func writeModelsModule(t *testing.T) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.24\n",
+		"models/user.go": `package models
+
+// @gqlType
+type User struct {
+	ID string
+}
+`,
+		"models/post.go": `package models
+
+// @gqlType
+type Post struct {
+	Title string
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	originalWd, _ := os.Getwd()
+	t.Cleanup(func() {
+		_ = os.Chdir(originalWd)
+	})
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("Failed to change directory to tmpDir: %v", err)
+	}
+	return tmpDir
+}
+
+// generateWithoutConfig generates the schema of pkg the way `generate --pkg <pkg>` does without a config file
+func generateWithoutConfig(t *testing.T, tmpDir, pkg string) string {
+	t.Helper()
+	cfg := NewConfigWithDefaults()
+	cfg.Packages = []string{pkg}
+	cfg.GenStrategy = GenStrategySingle
+	cfg.Output = "./graph/schema.graphqls"
+	cfg.UseGqlGenDirectives = true
+
+	if err := Generate(cfg); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "graph", "schema.graphqls"))
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	return string(content)
+}
+
+func TestGenerateFromSingleFileInModule(t *testing.T) {
+	tmpDir := writeModelsModule(t)
+	schema := generateWithoutConfig(t, tmpDir, "./models/user.go")
+
+	// @goModel points at the package containing the file, not the module root
+	if want := `type User @goModel(model: "example.com/app/models.User")`; !strings.Contains(schema, want) {
+		t.Errorf("Expected schema to contain %q, got:\n%s", want, schema)
+	}
+	if strings.Contains(schema, "Post") {
+		t.Errorf("Expected only the types of user.go, got:\n%s", schema)
+	}
+}
+
+func TestGenerateFromPackageDirWithoutConfig(t *testing.T) {
+	tmpDir := writeModelsModule(t)
+	schema := generateWithoutConfig(t, tmpDir, "./models")
+
+	// Smart defaults set model_path to the module path, which package directories keep using
+	for _, want := range []string{
+		`type User @goModel(model: "example.com/app.User")`,
+		`type Post @goModel(model: "example.com/app.Post")`,
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain %q, got:\n%s", want, schema)
+		}
+	}
+
+	if cfg := NewConfigWithDefaults(); cfg.ModelPath != "example.com/app" {
+		t.Errorf("Expected smart defaults to set model_path to the module path, got %q", cfg.ModelPath)
+	}
+}
+
+func TestGenerateMatchesEnumsAcrossPackages(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"types/status.go": `package types
+
+// @gqlEnum
+type Status string
+
+// @gqlType
+type Order struct {
+	ID     string
+	Status Status
+}
+`,
+		"constants/status_values.go": `package constants
+
+import "../types"
+
+const (
+	StatusPending types.Status = "pending"
+	StatusShipped types.Status = "shipped"
+)
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	cfg := NewConfig()
+	cfg.Packages = []string{filepath.Join(tmpDir, "types"), filepath.Join(tmpDir, "constants")}
+	cfg.GenStrategy = GenStrategySingle
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+
+	if err := Generate(cfg); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	content, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	schema := string(content)
+
+	for _, want := range []string{"enum Status {", "PENDING", "SHIPPED", "status: Status!"} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain %q, got:\n%s", want, schema)
+		}
+	}
+}
+
+func TestGenerateToStdout(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlType
+type User struct {
+	ID string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "user.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Packages = []string{tmpDir}
+	cfg.GenStrategy = GenStrategySingle
+	cfg.Output = OutputStdout
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	genErr := Generate(cfg)
+	os.Stdout = stdout
+	_ = w.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read stdout: %v", err)
+	}
+	if genErr != nil {
+		t.Fatalf("Generate failed: %v", genErr)
+	}
+
+	expected := "type User {\n  id: String!\n}\n"
+	if !strings.Contains(string(out), expected) {
+		t.Errorf("Expected stdout to contain:\n%s\ngot:\n%s", expected, string(out))
+	}
+	if FileExists(OutputStdout) {
+		t.Errorf("Generate should not write a file named %q", OutputStdout)
+	}
+}
+
+func TestGenerateToStdoutRejectsMultipleStrategy(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Packages = []string{t.TempDir()}
+	cfg.GenStrategy = GenStrategyMultiple
+	cfg.Output = OutputStdout
+
+	err := Generate(cfg)
+	if err == nil || !strings.Contains(err.Error(), "single") {
+		t.Errorf("Expected stdout output to require the single strategy, got %v", err)
+	}
+}
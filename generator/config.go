@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"gopkg.in/yaml.v3"
 )
@@ -23,6 +26,61 @@ const (
 	FieldCaseNone     FieldCase = "none" // Keep struct field name untouched
 )
 
+// TypeNameCase determines how type and input names without an explicit name are formatted
+type TypeNameCase string
+
+const (
+	TypeNameCasePascal    TypeNameCase = "pascal"    // Keep the Go name (UserProfile)
+	TypeNameCaseSnake     TypeNameCase = "snake"     // user_profile
+	TypeNameCaseScreaming TypeNameCase = "screaming" // USER_PROFILE
+)
+
+// FieldOrder determines the order in which fields are emitted
+type FieldOrder string
+
+const (
+	FieldOrderSource FieldOrder = "source" // Keep struct declaration order
+	FieldOrderAlpha  FieldOrder = "alpha"  // Sort by GraphQL field name
+	FieldOrderJSON   FieldOrder = "json"   // Sort by json tag name (falls back to GraphQL field name)
+	// Non-null fields first, then nullable ones, each sorted by GraphQL field name
+	FieldOrderRequiredFirst FieldOrder = "required-first"
+)
+
+// TypeOrder determines the order in which types and inputs are emitted
+type TypeOrder string
+
+const (
+	// Dependency layers: leaf types first, aggregate types last, alphabetical within a layer
+	TypeOrderTopological  TypeOrder = "topological"
+	TypeOrderAlphabetical TypeOrder = "alphabetical" // Sort by Go type name
+	TypeOrderSource       TypeOrder = "source"       // Source file, then declaration order
+)
+
+// DuplicateFieldPolicy determines which field is kept when several fields of a type share a GraphQL name
+type DuplicateFieldPolicy string
+
+const (
+	DuplicateFieldsFirst DuplicateFieldPolicy = "first" // Keep the shallowest field, the first one in struct order among equally deep fields
+	DuplicateFieldsLast  DuplicateFieldPolicy = "last"  // Keep the last field, at the position of the first one
+)
+
+// EnumValueSort determines the order of the values of generated enums
+type EnumValueSort string
+
+const (
+	EnumValueSortDeclaration  EnumValueSort = "declaration"  // Go const declaration order
+	EnumValueSortAlphabetical EnumValueSort = "alphabetical" // Sort by GraphQL value name
+)
+
+// TypeDeprecationMode determines how @gqlDeprecated on a type or enum is rendered
+// GraphQL has no @deprecated for type definitions, so the deprecation is carried by fields or the description
+type TypeDeprecationMode string
+
+const (
+	TypeDeprecationFields      TypeDeprecationMode = "fields"      // @deprecated on every field (every value for enums)
+	TypeDeprecationDescription TypeDeprecationMode = "description" // "[DEPRECATED] reason" prepended to the description
+)
+
 // GenStrategy determines output file strategy
 type GenStrategy string
 
@@ -32,26 +90,132 @@ const (
 	GenStrategyPackage  GenStrategy = "package"
 )
 
+// OutputFormat determines what Run writes
+type OutputFormat string
+
+const (
+	FormatGraphQL OutputFormat = "graphql" // GraphQL schema files
+	FormatJSON    OutputFormat = "json"    // JSON manifest of the generated schema items
+)
+
+// ManifestFileName is the file the JSON manifest is written to when Output is a directory
+const ManifestFileName = "gqlschemagen.json"
+
+// OutputStdout as Output writes the single-file schema to stdout instead of a file
+const OutputStdout = "-"
+
 // Config controls how the schema generator behaves
 type Config struct {
-	// Packages to scan for Go structs (supports glob: /models/**/*.go)
+	// Packages to scan for Go structs (supports globs: ./models/**/*.go, ./**/models)
 	Packages []string `yaml:"packages"`
 
-	// Output directory or file path
+	// Build tags used to select which files are scanned (e.g., ["experimental"])
+	// When set, files whose //go:build constraints don't match are skipped
+	// Default: [] (build constraints are not evaluated)
+	BuildTags []string `yaml:"build_tags"`
+
+	// Globs of files and directories the parser never reads (e.g., ["**/mock_*.go", "internal/testdata/**"])
+	// Matched against the path relative to each scanned package and against the file's base name;
+	// "**" matches any number of directories. Packages loaded on demand are matched by base name
+	// and by the path relative to the working directory
+	// Default: [] (every .go file is scanned)
+	ExcludePaths []string `yaml:"exclude_paths"`
+
+	// Output directory or file path ("-" writes the schema to stdout, single strategy only)
 	Output string `yaml:"output"`
 
+	// Output format: "graphql" writes the schema files, "json" writes a manifest of every generated
+	// type, input, interface, union, enum and scalar (Go type, package, GraphQL name, fields) instead
+	// The manifest goes to Output when it ends with ".json", otherwise to Output/gqlschemagen.json
+	// Default: "graphql"
+	Format OutputFormat `yaml:"format"`
+
 	// Output file name (for single strategy, default: "gqlschemagen.graphqls")
 	OutputFileName string `yaml:"output_file_name"`
 
 	// Output file extension (for multiple/package strategies, default: ".graphqls")
 	OutputFileExtension string `yaml:"output_file_extension"`
 
+	// Indent is the indentation of fields, enum values and their descriptions in the generated schema
+	// Only spaces and tabs are allowed, e.g. "    " or "\t"
+	// Default: "  " (two spaces)
+	Indent string `yaml:"indent"`
+
+	// SchemaExtensions are the extensions that mark Output as a schema file rather than a directory
+	// Default: [".graphqls", ".graphql", ".gql"] (see DefaultSchemaExtensions)
+	SchemaExtensions []string `yaml:"schema_extensions"`
+
+	// PackageOutputMap maps package import-path globs to output subdirectories (package strategy only)
+	// Example: {"*/internal/billing": "billing"} writes that package to Output/billing/
+	// Patterns also match any trailing part of the import path; the most specific pattern wins
+	PackageOutputMap map[string]string `yaml:"package_output_map"`
+
 	// Field name case transformation (camel, snake, pascal)
 	FieldCase FieldCase `yaml:"field_case"`
 
+	// Case of type and input names without an explicit name (pascal, snake, screaming)
+	// Applied after prefix/suffix stripping and addition; @goModel keeps the Go type name
+	// Default: "pascal"
+	TypeNameCase TypeNameCase `yaml:"type_name_case"`
+
+	// Field order in generated types and inputs (source, alpha, json, required-first)
+	// Default: "source" (struct declaration order)
+	FieldOrder FieldOrder `yaml:"field_order"`
+
+	// Order of types and inputs within a file (topological, alphabetical, source)
+	// Scalars and enums are always emitted first
+	// Default: "topological" (leaf types first, grouped by dependency layer)
+	TypeOrder TypeOrder `yaml:"type_order"`
+
+	// Field kept when several fields share a GraphQL name, e.g. an ID in two embedded structs (first, last)
+	// Collisions are reported as warnings
+	// Default: "first"
+	DuplicateFields DuplicateFieldPolicy `yaml:"duplicate_fields"`
+
+	// Order of enum values (declaration, alphabetical)
+	// GraphQL enums carry no numeric value, so sorting iota enums is safe
+	// Default: "declaration"
+	EnumValueSort EnumValueSort `yaml:"enum_value_sort"`
+
+	// How @gqlDeprecated on a type or enum is rendered (fields, description)
+	// Fields and enum values with their own deprecation keep their reason
+	// Default: "fields"
+	TypeDeprecationMode TypeDeprecationMode `yaml:"type_deprecation_mode"`
+
+	// MapScalar is the scalar map fields render as when they are not rendered as pairs
+	// Nested maps and maps of lists resolve to the same scalar
+	// Default: "Map"
+	MapScalar string `yaml:"map_scalar"`
+
+	// AnyScalar is the scalar fields typed any, interface{} or an empty named interface render as
+	// Default: "JSON"
+	AnyScalar string `yaml:"any_scalar"`
+
+	// InterfacesAsAnyScalar renders fields typed as an interface with methods as AnyScalar too
+	// When false, such fields are skipped with a warning
+	// Default: false
+	InterfacesAsAnyScalar bool `yaml:"interfaces_as_any_scalar"`
+
+	// MapAsPairs renders map fields as lists of synthesized key-value pair types
+	// (e.g., map[string]*Product -> [StringProductPair!]!) instead of a single scalar.
+	// Can be overridden per field with the "pairs" / "pairs:false" gql tag options
+	MapAsPairs bool `yaml:"map_as_pairs"`
+
+	// MapPairTypeName is the name template for synthesized pair types
+	// {Key} and {Value} are replaced by the GraphQL key and value type names
+	// Inputs use the same name with an "Input" suffix
+	// Default: "{Key}{Value}Pair"
+	MapPairTypeName string `yaml:"map_pair_type_name"`
+
 	// Use json struct tag for field names if gql tag is not present
 	UseJsonTag bool `yaml:"use_json_tag"`
 
+	// Struct tags read for field names, highest priority first; the first tag naming a field wins
+	// Only gql carries options (ro, wo, include, type:...), other tags give the name or "-" to ignore
+	// json entries are skipped when use_json_tag is false
+	// Default: ["gql", "json"]
+	TagPriority []string `yaml:"tag_priority"`
+
 	// Generate gqlgen directives (@goModel, @goField, @goTag)
 	UseGqlGenDirectives bool `yaml:"use_gqlgen_directives"`
 
@@ -99,29 +263,101 @@ type Config struct {
 	// Default: "{model_name}.graphqls"
 	SchemaFileName string `yaml:"schema_file_name"`
 
+	// Render fields nullable unless they are tagged required (list elements keep their nullability)
+	// Default: false (fields are non-null unless tagged optional)
+	DefaultNullable bool `yaml:"default_nullable"`
+
+	// InlineStructTypes synthesizes a type (and input) for fields declared as an anonymous struct,
+	// named after the parent Go type and the field (User.Meta -> UserMeta, UserMetaInput)
+	// When false, such fields render as String like any other type without a GraphQL mapping
+	// Default: false
+	InlineStructTypes bool `yaml:"inline_struct_types"`
+
+	// OmitemptyNullable renders fields whose json tag has omitempty as nullable, like the optional flag
+	// Only applies with use_json_tag; fields tagged required or with a type: override are unchanged
+	// Default: false
+	OmitemptyNullable bool `yaml:"omitempty_nullable"`
+
 	// Include empty types (types with no fields)
 	IncludeEmptyTypes bool `yaml:"include_empty_types"`
 
 	// Skip existing files
 	SkipExisting bool `yaml:"skip_existing"`
 
-	// Generate inputs automatically
+	// DryRun reports the files that would be written (new files with their size,
+	// existing files as a unified diff) without writing anything
+	DryRun bool `yaml:"dry_run"`
+
+	// CheckOnly compares the generated files with the ones on disk without writing anything
+	// and fails listing the stale files (missing or different, ignoring trailing whitespace)
+	CheckOnly bool `yaml:"check_only"`
+
+	// Verbose logs to stderr the directives parsed for each type, why each field was included
+	// or excluded, and the file each item was written to
+	Verbose bool `yaml:"verbose"`
+
+	// Allow several Go types to generate the same GraphQL name (for intentional merging)
+	// By default generation fails when two definitions share a name
+	AllowDuplicateNames bool `yaml:"allow_duplicate_names"`
+
+	// Make the field closing each cycle of non-null input references nullable
+	// By default generation fails listing the cycles, since no input value could satisfy them
+	RelaxInputCycles bool `yaml:"relax_input_cycles"`
+
+	// Write the definitions of the gqlgen directives used in the schema (directive @goModel(...) on ...)
+	// for tools that do not provide them: prepended to the single output file, otherwise written to
+	// directives.graphqls in the output directory. Directives that are not used are not defined
+	EmitDirectiveDefinitions bool `yaml:"emit_directive_definitions"`
+
+	// Generate an input for every @gqlType struct without @gqlInput
 	GenInputs bool `yaml:"gen_inputs"`
 
 	// Generate empty structs
 	GenerateEmptyStructs bool `yaml:"generate_empty_structs"`
 
+	// DisableDocDescriptions stops struct and field doc comments from becoming GraphQL descriptions,
+	// so only description: parameters and tags are used
+	// Default: false
+	DisableDocDescriptions bool `yaml:"disable_doc_descriptions"`
+
+	// DisableHeader leaves out the generated-code header comment at the top of every generated file
+	// Default: false
+	DisableHeader bool `yaml:"disable_header"`
+
+	// HeaderText replaces the first header line; each line is written as a "#" comment
+	// Default: DefaultHeaderText
+	HeaderText string `yaml:"header_text"`
+
 	// GQLKeep preserved sections marker
 	KeepBeginMarker      string `yaml:"keep_begin_marker"`
 	KeepEndMarker        string `yaml:"keep_end_marker"`
 	KeepSectionPlacement string `yaml:"keep_section_placement"`
 
+	// MergeMode merges the output into the existing files instead of rewriting them
+	// Every definition is written between "# gqlschemagen:type=User" and "# gqlschemagen:end"
+	// markers; only those blocks are replaced (new ones are appended) and the rest of the
+	// file, hand-written definitions included, is left intact. Keep sections are not used.
+	MergeMode bool `yaml:"merge_mode"`
+
+	// PostProcess rewrites every generated file right before it is written (programmatic use only)
+	// It receives the final content, after the header and keep sections are merged in, and its
+	// result is also what check and dry runs compare. Output to stdout is passed with "-" as the path.
+	PostProcess func(filePath string, content string) (string, error) `yaml:"-"`
+
 	// Namespace separator for converting namespace to file paths
 	// Default: "/" (e.g., "user.auth" becomes "user/auth.graphqls")
 	NamespaceSeparator string `yaml:"namespace_separator"`
 
+	// ExcludeTypes lists Go types that are never turned into GraphQL types or fields
+	// Entries are written as "pkg.Type" (e.g., "sync.Mutex") or with the full import path
+	// (e.g., "sync/atomic.Value"). Embedded excluded types are skipped, and fields referencing
+	// them are handled according to auto_generate.out_of_scope_types.
+	// Default: common standard library infrastructure types (see DefaultExcludeTypes)
+	ExcludeTypes []string `yaml:"exclude_types"`
+
 	// Known GraphQL scalar types (built-in + custom scalars)
 	// These types are always considered "in scope" and won't trigger out-of-scope warnings
+	// Default: built-in and common custom scalars (see DefaultKnownScalars)
 	KnownScalars []string `yaml:"known_scalars"`
 
 	// Scalars maps GraphQL scalar names to Go package types
@@ -129,9 +365,22 @@ type Config struct {
 	// This allows mapping Go types like uuid.UUID to GraphQL scalars like ID
 	Scalars map[string]ScalarMapping `yaml:"scalars"`
 
+	// TypeMappings maps package-qualified Go types, written as in source ("time.Time"), to GraphQL scalars
+	// Types are looked up by selector text, then by bare name ("Time"); scalars takes precedence.
+	// Entries are merged over DefaultTypeMappings, an empty value disables a default.
+	// The mapped scalars are added to known_scalars
+	TypeMappings map[string]string `yaml:"type_mappings"`
+
 	// Auto-generation configuration
 	AutoGenerate AutoGenerateConfig `yaml:"auto_generate"`
 
+	// Federation enables the Apollo Federation directives: @key on types (@gqlKey),
+	// @external/@shareable on fields, and the extend form for @gqlExtend types
+	Federation bool `yaml:"federation"`
+
+	// Relay connection generation
+	Relay RelayConfig `yaml:"relay"`
+
 	// CLI watcher configuration
 	CLI CLIConfig `yaml:"cli"`
 
@@ -155,10 +404,11 @@ const (
 type OutOfScopeAction string
 
 const (
-	OutOfScopeWarn    OutOfScopeAction = "warn"    // Warn about out-of-scope types (default)
-	OutOfScopeFail    OutOfScopeAction = "fail"    // Fail generation if out-of-scope types found
-	OutOfScopeIgnore  OutOfScopeAction = "ignore"  // Silently ignore out-of-scope types
-	OutOfScopeExclude OutOfScopeAction = "exclude" // Exclude fields with out-of-scope types
+	OutOfScopeWarn        OutOfScopeAction = "warn"        // Warn about out-of-scope types (default)
+	OutOfScopeFail        OutOfScopeAction = "fail"        // Fail generation if out-of-scope types found
+	OutOfScopeIgnore      OutOfScopeAction = "ignore"      // Silently ignore out-of-scope types
+	OutOfScopeExclude     OutOfScopeAction = "exclude"     // Exclude fields with out-of-scope types
+	OutOfScopePlaceholder OutOfScopeAction = "placeholder" // Map out-of-scope types to a placeholder scalar with a TODO comment
 )
 
 // AutoGenerateConfig controls automatic type generation
@@ -171,8 +421,13 @@ type AutoGenerateConfig struct {
 
 	// Maximum depth for transitive type references (default: 1)
 	// 0 = unlimited, 1 = direct references only, 2 = references of references, etc.
+	// Inputs are not limited: every struct reachable from an input gets an input variant
 	MaxDepth int `yaml:"max_depth"`
 
+	// Maximum number of types (and inputs) added by the referenced strategy, 0 = unlimited
+	// Types are added breadth-first from the annotated types; the ones left out are listed in a warning
+	MaxTypes int `yaml:"max_types"`
+
 	// Patterns to include (glob-style patterns matching package/type)
 	// Example: "*/models/*Connection", "*/graph/model/*Input"
 	Patterns []string `yaml:"patterns"`
@@ -185,19 +440,26 @@ type AutoGenerateConfig struct {
 	OnlyReferencedByAnnotated bool `yaml:"only_referenced_by_annotated"`
 
 	// Auto-generate embedded struct types
+	// Structs embedded from packages that are not scanned are loaded on demand and inlined
 	IncludeEmbedded bool `yaml:"include_embedded"`
 
 	// Auto-generate field types
 	IncludeFieldTypes bool `yaml:"include_field_types"`
 
 	// Action to take for out-of-scope types (types referenced but not in scanned packages)
-	// Options: "warn" (default), "fail", "ignore", "exclude"
+	// Options: "warn" (default), "fail", "ignore", "exclude", "placeholder"
 	OutOfScopeTypes OutOfScopeAction `yaml:"out_of_scope_types"`
 
+	// PlaceholderScalar is the GraphQL type used in place of out-of-scope types
+	// when OutOfScopeTypes is "placeholder". The field is preceded by a
+	// "# TODO: define scalar X" comment so the gap stays visible in the schema.
+	// Default: "String"
+	PlaceholderScalar string `yaml:"placeholder_scalar"`
+
 	// UnresolvedGenericType specifies what type to use for unresolved generic type parameters
 	// Default: "" (keeps as-is, e.g., "T"), common values: "Any", "JSON", or custom scalar name
 	// When a generic type parameter cannot be resolved (e.g., T in Result[T] when used standalone),
-	// this config determines what GraphQL type to use instead
+	// this config determines what GraphQL type to use instead (rendered nullable)
 	UnresolvedGenericType string `yaml:"unresolved_generic_type"`
 
 	// SuppressGenericTypeWarnings suppresses out-of-scope warnings for common type parameters (T, K, V, etc.)
@@ -212,6 +474,20 @@ type ScalarMapping struct {
 	Model []string `yaml:"model"`
 }
 
+// RelayConfig controls the generation of Relay-style connections
+// When enabled, the Node interface and PageInfo type are generated, and every
+// @gqlType(relay:true) type implements Node and gets an edge and a connection type
+type RelayConfig struct {
+	// Enable Relay connection generation
+	Enabled bool `yaml:"enabled"`
+
+	// Suffix of the connection type name (default: "Connection", e.g. CommentConnection)
+	ConnectionSuffix string `yaml:"connection_suffix"`
+
+	// Suffix of the edge type name (default: "Edge", e.g. CommentEdge)
+	EdgeSuffix string `yaml:"edge_suffix"`
+}
+
 // CLIConfig contains CLI-specific configuration
 type CLIConfig struct {
 	Watcher WatcherConfig `yaml:"watcher"`
@@ -284,26 +560,126 @@ func parseGoModModulePath(goModPath string) string {
 	return ""
 }
 
+// DefaultTagPriority returns the struct tags field names are read from by default
+func DefaultTagPriority() []string {
+	return []string{"gql", "json"}
+}
+
+// fieldTags returns the struct tags to read field names from, in priority order
+func (c *Config) fieldTags() []string {
+	tags := c.TagPriority
+	if len(tags) == 0 {
+		tags = DefaultTagPriority()
+	}
+	if c.UseJsonTag {
+		return tags
+	}
+	return slices.DeleteFunc(slices.Clone(tags), func(tag string) bool { return tag == "json" })
+}
+
+// DefaultIndent is the indentation used in the generated schema when Config.Indent is not set
+const DefaultIndent = "  "
+
+// indent returns the configured indentation for the generated schema
+func (c *Config) indent() string {
+	if c.Indent == "" {
+		return DefaultIndent
+	}
+	return c.Indent
+}
+
+// DefaultSchemaExtensions returns the extensions recognized as schema files by default
+func DefaultSchemaExtensions() []string {
+	return []string{".graphqls", ".graphql", ".gql"}
+}
+
+// isSchemaFile reports whether path names a schema file rather than a directory
+func (c *Config) isSchemaFile(path string) bool {
+	extensions := c.SchemaExtensions
+	if len(extensions) == 0 {
+		extensions = DefaultSchemaExtensions()
+	}
+	path = strings.ToLower(path)
+	for _, ext := range extensions {
+		if strings.HasSuffix(path, strings.ToLower(ext)) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultTypeMappings returns the scalars common standard library and third-party types map to
+func DefaultTypeMappings() map[string]string {
+	return map[string]string{
+		"time.Time":       "DateTime",
+		"time.Duration":   "Duration",
+		"uuid.UUID":       "UUID",
+		"json.RawMessage": "JSON",
+		"decimal.Decimal": "Decimal",
+	}
+}
+
+// DefaultKnownScalars returns the GraphQL built-in scalars and common custom scalars
+// that are always considered in scope
+func DefaultKnownScalars() []string {
+	return []string{
+		// GraphQL built-in scalars
+		"Int", "Float", "String", "Boolean", "ID",
+		// Common custom scalars
+		"Time", "DateTime", "Date", "Timestamp",
+		"Upload", "Map", "UUID", "Any", "JSON",
+		"Byte", "Bytes", "Int64", "UInt", "UInt64",
+	}
+}
+
+// DefaultExcludeTypes returns the standard library types that are skipped by default
+// These are infrastructure types that have no meaningful GraphQL representation
+func DefaultExcludeTypes() []string {
+	return []string{
+		"sync.Mutex", "sync.RWMutex", "sync.WaitGroup", "sync.Once",
+		"sync.Map", "sync.Pool", "sync.Cond",
+		"sync/atomic.Value", "atomic.Value",
+		"embed.FS", "context.Context",
+		"io.Reader", "io.Writer", "io.Closer", "io.ReadCloser", "io.WriteCloser",
+		"http.Client", "http.Request", "http.ResponseWriter",
+		"sql.DB", "sql.Tx", "os.File",
+		"log.Logger", "slog.Logger",
+		"reflect.Type", "reflect.Value",
+	}
+}
+
+// DefaultHeaderText is the standard generated-code marker recognized by Go tooling
+const DefaultHeaderText = "Code generated by github.com/pablor21/gqlschemagen; DO NOT EDIT."
+
 // NewConfig creates a new Config with defaults
 func NewConfig() *Config {
 	return &Config{
+		HeaderText:          DefaultHeaderText,
 		FieldCase:           FieldCaseCamel,
+		FieldOrder:          FieldOrderSource,
+		TypeOrder:           TypeOrderTopological,
+		DuplicateFields:     DuplicateFieldsFirst,
+		TypeDeprecationMode: TypeDeprecationFields,
+		EnumValueSort:       EnumValueSortDeclaration,
+		TypeNameCase:        TypeNameCasePascal,
+		Format:              FormatGraphQL,
 		UseJsonTag:          true,
+		TagPriority:         DefaultTagPriority(),
 		UseGqlGenDirectives: false,
 		GenStrategy:         GenStrategyMultiple,
 		SchemaFileName:      "{model_name}.graphqls",
 		OutputFileName:      "gqlschemagen.graphqls",
 		OutputFileExtension: ".graphqls",
+		SchemaExtensions:    DefaultSchemaExtensions(),
+		Indent:              DefaultIndent,
 		IncludeEmptyTypes:   false,
 		NamespaceSeparator:  "/",
-		KnownScalars: []string{
-			// GraphQL built-in scalars
-			"Int", "Float", "String", "Boolean", "ID",
-			// Common custom scalars
-			"Time", "DateTime", "Date", "Timestamp",
-			"Upload", "Map", "UUID", "Any", "JSON",
-			"Byte", "Bytes", "Int64", "UInt", "UInt64",
-		},
+		ExcludeTypes:        DefaultExcludeTypes(),
+		MapPairTypeName:     "{Key}{Value}Pair",
+		MapScalar:           "Map",
+		AnyScalar:           "JSON",
+		KnownScalars:        DefaultKnownScalars(),
+		TypeMappings:        DefaultTypeMappings(),
 		AutoGenerate: AutoGenerateConfig{
 			Enabled:                     true,
 			Strategy:                    AutoGenReferenced,
@@ -314,9 +690,14 @@ func NewConfig() *Config {
 			IncludeEmbedded:             true,
 			IncludeFieldTypes:           true,
 			OutOfScopeTypes:             OutOfScopeWarn,
+			PlaceholderScalar:           "String",
 			UnresolvedGenericType:       "",    // Keep type parameters as-is by default
 			SuppressGenericTypeWarnings: false, // Show warnings by default
 		},
+		Relay: RelayConfig{
+			ConnectionSuffix: "Connection",
+			EdgeSuffix:       "Edge",
+		},
 		CLI: CLIConfig{
 			Watcher: WatcherConfig{
 				Enabled:         false,
@@ -370,6 +751,56 @@ func (c *Config) Normalize() {
 	if c.FieldCase == "" {
 		c.FieldCase = FieldCaseCamel
 	}
+	if c.FieldOrder == "" {
+		c.FieldOrder = FieldOrderSource
+	}
+	if c.TypeOrder == "" {
+		c.TypeOrder = TypeOrderTopological
+	}
+	if c.MapPairTypeName == "" {
+		c.MapPairTypeName = "{Key}{Value}Pair"
+	}
+	if c.Relay.ConnectionSuffix == "" {
+		c.Relay.ConnectionSuffix = "Connection"
+	}
+	if c.Relay.EdgeSuffix == "" {
+		c.Relay.EdgeSuffix = "Edge"
+	}
+	if c.MapScalar == "" {
+		c.MapScalar = "Map"
+	}
+	if c.AnyScalar == "" {
+		c.AnyScalar = "JSON"
+	}
+	// nil means "not configured"; an explicit list replaces the defaults
+	if c.KnownScalars == nil {
+		c.KnownScalars = DefaultKnownScalars()
+	}
+	// Map fields reference the map scalar, which must not be reported as out of scope
+	if !slices.Contains(c.KnownScalars, c.MapScalar) {
+		c.KnownScalars = append(c.KnownScalars, c.MapScalar)
+	}
+	// Same for the scalar any and interface fields reference
+	if !slices.Contains(c.KnownScalars, c.AnyScalar) {
+		c.KnownScalars = append(c.KnownScalars, c.AnyScalar)
+	}
+	if len(c.TagPriority) == 0 {
+		c.TagPriority = DefaultTagPriority()
+	}
+	// Configured type mappings extend and override the defaults, and their scalars are known
+	if c.TypeMappings == nil {
+		c.TypeMappings = make(map[string]string)
+	}
+	for goType, scalar := range DefaultTypeMappings() {
+		if _, exists := c.TypeMappings[goType]; !exists {
+			c.TypeMappings[goType] = scalar
+		}
+	}
+	for _, goType := range sortedKeys(c.TypeMappings) {
+		if scalar := c.TypeMappings[goType]; scalar != "" && !slices.Contains(c.KnownScalars, scalar) {
+			c.KnownScalars = append(c.KnownScalars, scalar)
+		}
+	}
 	if c.SchemaFileName == "" {
 		c.SchemaFileName = "{model_name}.graphqls"
 	}
@@ -379,6 +810,36 @@ func (c *Config) Normalize() {
 	if c.OutputFileExtension == "" {
 		c.OutputFileExtension = ".graphqls"
 	}
+	if c.Indent == "" {
+		c.Indent = DefaultIndent
+	}
+	if len(c.SchemaExtensions) == 0 {
+		c.SchemaExtensions = DefaultSchemaExtensions()
+	}
+	// A new slice, so the caller's extensions are left as they were set
+	extensions := make([]string, 0, len(c.SchemaExtensions))
+	for _, ext := range c.SchemaExtensions {
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		extensions = append(extensions, ext)
+	}
+	c.SchemaExtensions = extensions
+	if c.Format == "" {
+		c.Format = FormatGraphQL
+	}
+	if c.DuplicateFields == "" {
+		c.DuplicateFields = DuplicateFieldsFirst
+	}
+	if c.TypeDeprecationMode == "" {
+		c.TypeDeprecationMode = TypeDeprecationFields
+	}
+	if c.EnumValueSort == "" {
+		c.EnumValueSort = EnumValueSortDeclaration
+	}
+	if c.TypeNameCase == "" {
+		c.TypeNameCase = TypeNameCasePascal
+	}
 
 	if c.KeepBeginMarker == "" {
 		c.KeepBeginMarker = "# @gqlKeepBegin"
@@ -395,6 +856,15 @@ func (c *Config) Normalize() {
 		c.NamespaceSeparator = "/"
 	}
 
+	if c.AutoGenerate.PlaceholderScalar == "" {
+		c.AutoGenerate.PlaceholderScalar = "String"
+	}
+
+	// nil means "not configured"; an explicit empty list disables the defaults
+	if c.ExcludeTypes == nil {
+		c.ExcludeTypes = DefaultExcludeTypes()
+	}
+
 	// Note: normalizeOutputPath() removed - backward compatibility is now handled
 	// in generateSingleFile() by detecting file extensions in the Output path
 }
@@ -511,25 +981,162 @@ func LoadConfigFromFile(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
 	}
 
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
-	}
-
 	// Store the directory where config was found
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get absolute path for config: %w", err)
 	}
-	cfg.ConfigDir = filepath.Dir(absPath)
+
+	cfg, err := LoadConfigFromBytes(data, filepath.Dir(absPath))
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// LoadConfigFromBytes loads a config from YAML content, e.g. fetched from a remote source.
+// baseDir is stored in ConfigDir and relative paths in the config are resolved against it;
+// with an empty baseDir they are kept relative to the working directory.
+func LoadConfigFromBytes(data []byte, baseDir string) (*Config, error) {
+	var cfg Config
+	if err := decodeConfig(data, baseDir, &cfg); err != nil {
+		return nil, err
+	}
+
+	cfg.Normalize()
+	return &cfg, nil
+}
+
+// decodeConfig unmarshals data into cfg, sets ConfigDir to baseDir and resolves relative paths
+func decodeConfig(data []byte, baseDir string, cfg *Config) error {
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	// Environment variables are expanded before relative paths are resolved against baseDir
+	if err := cfg.expandEnvPaths(); err != nil {
+		return err
+	}
+
+	if baseDir != "" {
+		absDir, err := filepath.Abs(baseDir)
+		if err != nil {
+			return fmt.Errorf("failed to get absolute path for %s: %w", baseDir, err)
+		}
+		cfg.ConfigDir = absDir
+	}
 
 	// Resolve all relative paths in the config relative to ConfigDir
 	cfg.resolveRelativePaths()
+	return nil
+}
+
+// LoadConfigFromFiles loads several config files and merges them in order with MergeConfig,
+// so later files override earlier ones field by field. Relative paths in each file are
+// resolved against that file's directory. Defaults are applied once, after the merge,
+// so they never override a value set by an earlier file.
+func LoadConfigFromFiles(paths ...string) (*Config, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no config files given")
+	}
+
+	var cfg Config
+	if err := readConfigFile(paths[0], &cfg); err != nil {
+		return nil, err
+	}
+	for _, path := range paths[1:] {
+		var override Config
+		if err := readConfigFile(path, &override); err != nil {
+			return nil, err
+		}
+		MergeConfig(&cfg, &override)
+	}
 
 	cfg.Normalize()
 	return &cfg, nil
 }
 
+// readConfigFile decodes the file at path into cfg, resolving relative paths against its directory
+func readConfigFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for config: %w", err)
+	}
+
+	if err := decodeConfig(data, filepath.Dir(absPath), cfg); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// MergeConfig overrides dst with every non-zero field of src. Nested settings (auto_generate,
+// relay, cli) are merged field by field the same way, while slices and maps such as Packages,
+// KnownScalars or Scalars replace the value of dst as a whole instead of being appended to.
+// Zero values (false, 0, "") never override, so src can turn a boolean on but not off.
+func MergeConfig(dst, src *Config) {
+	mergeStruct(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem())
+}
+
+// mergeStruct copies the non-zero fields of src into dst, recursing into nested structs
+func mergeStruct(dst, src reflect.Value) {
+	for i := range src.NumField() {
+		field := dst.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		value := src.Field(i)
+		if value.Kind() == reflect.Struct {
+			mergeStruct(field, value)
+			continue
+		}
+		if !value.IsZero() {
+			field.Set(value)
+		}
+	}
+}
+
+// expandEnvPaths expands $VAR and ${VAR} in output, packages and model_path
+// Unset variables are reported instead of expanding to "", which would silently turn
+// ${SCHEMA_DIR}/graph into /graph or an empty value into the config directory
+func (c *Config) expandEnvPaths() error {
+	expand := func(key, value string) (string, error) {
+		var unset []string
+		expanded := os.Expand(value, func(name string) string {
+			v, ok := os.LookupEnv(name)
+			if !ok {
+				unset = append(unset, name)
+			}
+			return v
+		})
+		if len(unset) > 0 {
+			return "", fmt.Errorf("%s %q uses unset environment variable %s", key, value, strings.Join(unset, ", "))
+		}
+		if value != "" && strings.TrimSpace(expanded) == "" {
+			return "", fmt.Errorf("%s %q expands to an empty path", key, value)
+		}
+		return expanded, nil
+	}
+
+	var err error
+	if c.Output, err = expand("output", c.Output); err != nil {
+		return err
+	}
+	for i, pkg := range c.Packages {
+		if c.Packages[i], err = expand("packages", pkg); err != nil {
+			return err
+		}
+	}
+	if c.ModelPath, err = expand("model_path", c.ModelPath); err != nil {
+		return err
+	}
+	return nil
+}
+
 // resolveRelativePaths converts all relative paths in the config to be relative to ConfigDir.
 // This ensures that paths work correctly when the config is loaded from a different directory
 // than where the command is run (e.g., with //go:generate).
@@ -546,7 +1153,7 @@ func (c *Config) resolveRelativePaths() {
 	}
 
 	// Resolve output path
-	if c.Output != "" && !filepath.IsAbs(c.Output) {
+	if c.Output != "" && c.Output != OutputStdout && !filepath.IsAbs(c.Output) {
 		c.Output = filepath.Join(c.ConfigDir, c.Output)
 	}
 }
@@ -558,17 +1165,125 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("packages is required (at least one package path must be specified)")
 	}
 
+	// Validate output location (namespaces and the multiple/package strategies resolve files relative to it)
+	if c.Output == "" {
+		return fmt.Errorf("output is required (a schema file, an output directory, or '-' for stdout)")
+	}
+
+	if c.OutputFileExtension != "" && !strings.HasPrefix(c.OutputFileExtension, ".") {
+		return fmt.Errorf("invalid output_file_extension: %q (must start with a dot, e.g. '.graphqls')", c.OutputFileExtension)
+	}
+
+	// The default "/" maps namespaces to directories as-is, any other separator is replaced by one
+	if c.NamespaceSeparator != "" && c.NamespaceSeparator != "/" {
+		if utf8.RuneCountInString(c.NamespaceSeparator) != 1 {
+			return fmt.Errorf("invalid namespace_separator: %q (must be a single character, e.g. '.')", c.NamespaceSeparator)
+		}
+		if strings.ContainsAny(c.NamespaceSeparator, `/\`) {
+			return fmt.Errorf("invalid namespace_separator: %q (must not be a path separator, use '/' to map namespaces to directories)", c.NamespaceSeparator)
+		}
+	}
+
+	// Stripping and adding the same affix either does nothing or applies it twice
+	if err := checkAffixConflict("strip_prefix", c.StripPrefix, map[string]string{
+		"add_type_prefix":  c.AddTypePrefix,
+		"add_input_prefix": c.AddInputPrefix,
+	}); err != nil {
+		return err
+	}
+	if err := checkAffixConflict("strip_suffix", c.StripSuffix, map[string]string{
+		"add_type_suffix":  c.AddTypeSuffix,
+		"add_input_suffix": c.AddInputSuffix,
+	}); err != nil {
+		return err
+	}
+
 	// Validate strategy
 	if c.GenStrategy != "" && c.GenStrategy != GenStrategySingle && c.GenStrategy != GenStrategyMultiple && c.GenStrategy != GenStrategyPackage {
 		return fmt.Errorf("invalid strategy: %s (must be 'single', 'multiple', or 'package')", c.GenStrategy)
 	}
 
+	// The multiple and package strategies write several files into the output directory
+	if (c.GenStrategy == GenStrategyMultiple || c.GenStrategy == GenStrategyPackage) && c.isSchemaFile(c.Output) {
+		return fmt.Errorf("output %q is a schema file, but the '%s' strategy writes several files into a directory (set output to a directory such as %q, or use the 'single' strategy)", c.Output, c.GenStrategy, strings.TrimSuffix(c.Output, filepath.Ext(c.Output)))
+	}
+
+	if strings.Trim(c.Indent, " \t") != "" {
+		return fmt.Errorf("invalid indent: %q (only spaces and tabs are allowed)", c.Indent)
+	}
+
+	// Validate output format
+	switch c.Format {
+	case "", FormatGraphQL, FormatJSON:
+	default:
+		return fmt.Errorf("invalid format: %s (must be 'graphql' or 'json')", c.Format)
+	}
+
+	// There are no files on disk to check stdout output against
+	if c.CheckOnly && c.Output == OutputStdout {
+		return fmt.Errorf("check_only cannot be used with output '-' (stdout)")
+	}
+
+	// Stdout output produces a single schema (the JSON manifest is always a single document)
+	if c.Output == OutputStdout && c.Format != FormatJSON && c.GenStrategy != GenStrategySingle {
+		return fmt.Errorf("output '-' (stdout) requires the 'single' strategy, got '%s'", c.GenStrategy)
+	}
+
 	// Validate field case
 	if c.FieldCase != "" && c.FieldCase != FieldCaseCamel && c.FieldCase != FieldCaseSnake &&
 		c.FieldCase != FieldCasePascal && c.FieldCase != FieldCaseOriginal && c.FieldCase != FieldCaseNone {
 		return fmt.Errorf("invalid field-case: %s (must be 'camel', 'snake', 'pascal', 'original', or 'none')", c.FieldCase)
 	}
 
+	// Validate field order
+	switch c.FieldOrder {
+	case "", FieldOrderSource, FieldOrderAlpha, FieldOrderJSON, FieldOrderRequiredFirst:
+	default:
+		return fmt.Errorf("invalid field_order: %s (must be 'source', 'alpha', 'json', or 'required-first')", c.FieldOrder)
+	}
+
+	// Validate type order
+	switch c.TypeOrder {
+	case "", TypeOrderTopological, TypeOrderAlphabetical, TypeOrderSource:
+	default:
+		return fmt.Errorf("invalid type_order: %s (must be 'topological', 'alphabetical', or 'source')", c.TypeOrder)
+	}
+
+	// Validate type name case
+	switch c.TypeNameCase {
+	case "", TypeNameCasePascal, TypeNameCaseSnake, TypeNameCaseScreaming:
+	default:
+		return fmt.Errorf("invalid type_name_case: %s (must be 'pascal', 'snake', or 'screaming')", c.TypeNameCase)
+	}
+
+	// Validate duplicate field policy
+	switch c.DuplicateFields {
+	case "", DuplicateFieldsFirst, DuplicateFieldsLast:
+	default:
+		return fmt.Errorf("invalid duplicate_fields: %s (must be 'first' or 'last')", c.DuplicateFields)
+	}
+
+	// Validate enum value sort
+	switch c.EnumValueSort {
+	case "", EnumValueSortDeclaration, EnumValueSortAlphabetical:
+	default:
+		return fmt.Errorf("invalid enum_value_sort: %s (must be 'declaration' or 'alphabetical')", c.EnumValueSort)
+	}
+
+	// Validate type deprecation mode
+	switch c.TypeDeprecationMode {
+	case "", TypeDeprecationFields, TypeDeprecationDescription:
+	default:
+		return fmt.Errorf("invalid type_deprecation_mode: %s (must be 'fields' or 'description')", c.TypeDeprecationMode)
+	}
+
+	// Validate out-of-scope action
+	switch c.AutoGenerate.OutOfScopeTypes {
+	case "", OutOfScopeWarn, OutOfScopeFail, OutOfScopeIgnore, OutOfScopeExclude, OutOfScopePlaceholder:
+	default:
+		return fmt.Errorf("invalid out_of_scope_types: %s (must be 'warn', 'fail', 'ignore', 'exclude', or 'placeholder')", c.AutoGenerate.OutOfScopeTypes)
+	}
+
 	if c.KeepSectionPlacement != "start" && c.KeepSectionPlacement != "end" {
 		return fmt.Errorf("invalid keep_section_placement: %s (must be 'start' or 'end')", c.KeepSectionPlacement)
 	}
@@ -576,6 +1291,23 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// checkAffixConflict reports an entry of a comma-separated strip list that is also added back
+// by one of the add options (keyed by option name)
+func checkAffixConflict(stripOption, stripList string, addOptions map[string]string) error {
+	for _, affix := range strings.Split(stripList, ",") {
+		affix = strings.TrimSpace(affix)
+		if affix == "" {
+			continue
+		}
+		for _, addOption := range sortedKeys(addOptions) {
+			if addOptions[addOption] == affix {
+				return fmt.Errorf("%s and %s both use %q (names would be stripped and re-added, remove it from one of them)", stripOption, addOption, affix)
+			}
+		}
+	}
+	return nil
+}
+
 // GetScalarForGoType returns the GraphQL scalar name for a given Go type package path
 // Returns empty string if no mapping exists
 func (c *Config) GetScalarForGoType(goTypePath string) string {
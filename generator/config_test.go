@@ -3,6 +3,8 @@ package generator
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -153,4 +155,287 @@ use_gqlgen_directives: true
 	if len(cfg.Packages) != 2 {
 		t.Errorf("Expected 2 packages, got %d", len(cfg.Packages))
 	}
+
+	if cfg.DisableHeader {
+		t.Error("Expected the header to stay enabled when not set in the file")
+	}
+}
+
+func TestLoadConfigFromFileExpandsEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	schemaDir := t.TempDir()
+	t.Setenv("SCHEMA_DIR", schemaDir)
+	t.Setenv("MODELS_PKG", "models")
+	t.Setenv("MODULE", "example.com/app")
+
+	configContent := `packages:
+  - ./${MODELS_PKG}
+output: ${SCHEMA_DIR}/graph/schema
+model_path: $MODULE/models
+`
+	configPath := filepath.Join(tmpDir, "gqlschemagen.yml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfigFromFile(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfigFromFile failed: %v", err)
+	}
+
+	if want := filepath.Join(schemaDir, "graph", "schema"); cfg.Output != want {
+		t.Errorf("Expected output %s, got %s", want, cfg.Output)
+	}
+	// Relative paths are resolved after expansion
+	if want := filepath.Join(tmpDir, "models"); cfg.Packages[0] != want {
+		t.Errorf("Expected package %s, got %s", want, cfg.Packages[0])
+	}
+	if cfg.ModelPath != "example.com/app/models" {
+		t.Errorf("Expected model_path example.com/app/models, got %s", cfg.ModelPath)
+	}
+
+	// Unset variables are an error, not the config directory or a path from the filesystem root
+	for _, output := range []string{"${GQLSCHEMAGEN_UNSET_DIR}", "${GQLSCHEMAGEN_UNSET_DIR}/graph/schema", "$GQLSCHEMAGEN_UNSET_DIR/graph"} {
+		if err := os.WriteFile(configPath, []byte("packages:\n  - ./models\noutput: "+output+"\n"), 0644); err != nil {
+			t.Fatalf("Failed to write test config: %v", err)
+		}
+		_, err = LoadConfigFromFile(configPath)
+		if err == nil || !strings.Contains(err.Error(), "unset environment variable GQLSCHEMAGEN_UNSET_DIR") {
+			t.Errorf("Expected an unset variable error for %s, got %v", output, err)
+		}
+	}
+
+	// Variables set to an empty string are allowed, unless the whole value ends up empty
+	t.Setenv("GQLSCHEMAGEN_EMPTY_DIR", "")
+	if err := os.WriteFile(configPath, []byte("packages:\n  - ./models\noutput: ${GQLSCHEMAGEN_EMPTY_DIR}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	_, err = LoadConfigFromFile(configPath)
+	if err == nil || !strings.Contains(err.Error(), "expands to an empty path") {
+		t.Errorf("Expected an empty path error, got %v", err)
+	}
+}
+
+func TestLoadConfigFromBytes(t *testing.T) {
+	baseDir := t.TempDir()
+	data := []byte(`packages:
+  - ./models
+  - /abs/entities
+output: graph/schema
+field_case: snake
+`)
+
+	cfg, err := LoadConfigFromBytes(data, baseDir)
+	if err != nil {
+		t.Fatalf("LoadConfigFromBytes failed: %v", err)
+	}
+
+	if cfg.ConfigDir != baseDir {
+		t.Errorf("Expected ConfigDir %s, got %s", baseDir, cfg.ConfigDir)
+	}
+	if cfg.Packages[0] != filepath.Join(baseDir, "models") {
+		t.Errorf("Expected the relative package to resolve against baseDir, got %s", cfg.Packages[0])
+	}
+	if cfg.Packages[1] != "/abs/entities" {
+		t.Errorf("Expected the absolute package to be kept, got %s", cfg.Packages[1])
+	}
+	if cfg.Output != filepath.Join(baseDir, "graph", "schema") {
+		t.Errorf("Expected the output to resolve against baseDir, got %s", cfg.Output)
+	}
+	if cfg.FieldCase != FieldCaseSnake {
+		t.Errorf("Expected FieldCase 'snake', got %s", cfg.FieldCase)
+	}
+
+	// The config is normalized like one loaded from a file
+	if cfg.DisableHeader || cfg.OutputFileExtension != ".graphqls" {
+		t.Errorf("Expected normalized defaults, got DisableHeader=%v OutputFileExtension=%q", cfg.DisableHeader, cfg.OutputFileExtension)
+	}
+
+	// Without a base dir, paths stay relative to the working directory
+	cfg, err = LoadConfigFromBytes(data, "")
+	if err != nil {
+		t.Fatalf("LoadConfigFromBytes failed: %v", err)
+	}
+	if cfg.Packages[0] != "./models" || cfg.Output != "graph/schema" {
+		t.Errorf("Expected unresolved paths without a base dir, got %v and %s", cfg.Packages, cfg.Output)
+	}
+
+	if _, err := LoadConfigFromBytes([]byte("packages: [unclosed"), baseDir); err == nil {
+		t.Error("Expected an error for invalid YAML")
+	}
+}
+
+func TestLoadConfigFromFilesMerge(t *testing.T) {
+	tmpDir := t.TempDir()
+	serviceDir := filepath.Join(tmpDir, "service")
+	if err := os.MkdirAll(serviceDir, 0755); err != nil {
+		t.Fatalf("Failed to create service dir: %v", err)
+	}
+
+	base := `packages:
+  - ./models
+output: ./graph/schema
+field_case: snake
+disable_header: true
+known_scalars:
+  - Money
+  - Email
+auto_generate:
+  enabled: true
+  max_depth: 2
+`
+	service := `packages:
+  - ./entities
+strategy: multiple
+known_scalars:
+  - Upload
+auto_generate:
+  max_depth: 5
+`
+	basePath := filepath.Join(tmpDir, "base.yml")
+	servicePath := filepath.Join(serviceDir, "service.yml")
+	if err := os.WriteFile(basePath, []byte(base), 0644); err != nil {
+		t.Fatalf("Failed to write base config: %v", err)
+	}
+	if err := os.WriteFile(servicePath, []byte(service), 0644); err != nil {
+		t.Fatalf("Failed to write service config: %v", err)
+	}
+
+	cfg, err := LoadConfigFromFiles(basePath, servicePath)
+	if err != nil {
+		t.Fatalf("LoadConfigFromFiles failed: %v", err)
+	}
+
+	// Slices are replaced, each resolved against the directory of its own file
+	if len(cfg.Packages) != 1 || cfg.Packages[0] != filepath.Join(serviceDir, "entities") {
+		t.Errorf("Expected packages from service.yml, got %v", cfg.Packages)
+	}
+	if !slices.Contains(cfg.KnownScalars, "Upload") || slices.Contains(cfg.KnownScalars, "Money") {
+		t.Errorf("Expected known_scalars to be replaced, got %v", cfg.KnownScalars)
+	}
+	if cfg.Output != filepath.Join(tmpDir, "graph", "schema") {
+		t.Errorf("Expected output from base.yml, got %s", cfg.Output)
+	}
+
+	// Fields set only in the base are kept
+	if cfg.FieldCase != FieldCaseSnake {
+		t.Errorf("Expected FieldCase 'snake' from base.yml, got %s", cfg.FieldCase)
+	}
+	if !cfg.DisableHeader {
+		t.Error("Expected disable_header: true from base.yml to be kept")
+	}
+	if cfg.GenStrategy != GenStrategyMultiple {
+		t.Errorf("Expected GenStrategy 'multiple' from service.yml, got %s", cfg.GenStrategy)
+	}
+
+	// Nested settings are merged field by field
+	if !cfg.AutoGenerate.Enabled || cfg.AutoGenerate.MaxDepth != 5 {
+		t.Errorf("Expected auto_generate enabled with max_depth 5, got %+v", cfg.AutoGenerate)
+	}
+}
+
+func TestConfigValidateOutputOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "empty output",
+			modify:  func(cfg *Config) { cfg.Output = "" },
+			wantErr: "output is required",
+		},
+		{
+			name:    "extension without leading dot",
+			modify:  func(cfg *Config) { cfg.OutputFileExtension = "graphqls" },
+			wantErr: `invalid output_file_extension: "graphqls"`,
+		},
+		{
+			name:    "namespace separator with a path separator",
+			modify:  func(cfg *Config) { cfg.NamespaceSeparator = "./" },
+			wantErr: `invalid namespace_separator: "./"`,
+		},
+		{
+			name:    "backslash namespace separator",
+			modify:  func(cfg *Config) { cfg.NamespaceSeparator = `\` },
+			wantErr: "must not be a path separator",
+		},
+		{
+			name:    "stripped prefix added back to types",
+			modify:  func(cfg *Config) { cfg.StripPrefix = "DB, Gql"; cfg.AddTypePrefix = "Gql" },
+			wantErr: `strip_prefix and add_type_prefix both use "Gql"`,
+		},
+		{
+			name:    "stripped suffix added back to inputs",
+			modify:  func(cfg *Config) { cfg.StripSuffix = "Input"; cfg.AddInputSuffix = "Input" },
+			wantErr: `strip_suffix and add_input_suffix both use "Input"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewConfig()
+			cfg.Normalize()
+			cfg.Packages = []string{"."}
+			cfg.Output = "graph/schema"
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if err == nil {
+				t.Fatalf("Expected an error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// The default separator, a single safe character and unrelated affixes are valid
+	cfg := NewConfig()
+	cfg.Normalize()
+	cfg.Packages = []string{"."}
+	cfg.Output = "graph/schema"
+	cfg.NamespaceSeparator = "."
+	cfg.StripPrefix = "DB"
+	cfg.AddTypePrefix = "Gql"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected a valid config, got %v", err)
+	}
+}
+
+func TestConfigValidateOutputMatchesStrategy(t *testing.T) {
+	tests := []struct {
+		strategy GenStrategy
+		output   string
+		wantErr  string
+	}{
+		{GenStrategyMultiple, "graph/schema.graphqls", `output "graph/schema.graphqls" is a schema file, but the 'multiple' strategy`},
+		{GenStrategyPackage, "schema.graphql", `set output to a directory such as "schema"`},
+		{GenStrategyPackage, "graph/schema.gql", "'package' strategy writes several files into a directory"},
+		{GenStrategySingle, "graph/schema.graphqls", ""},
+		{GenStrategySingle, "graph/schema", ""},
+		{GenStrategyMultiple, "graph/schema", ""},
+		{GenStrategyPackage, "graph", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy)+" "+tt.output, func(t *testing.T) {
+			cfg := NewConfig()
+			cfg.Normalize()
+			cfg.Packages = []string{"."}
+			cfg.GenStrategy = tt.strategy
+			cfg.Output = tt.output
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected a valid config, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
 }
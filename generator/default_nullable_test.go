@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const defaultNullableSource = `package models
+
+// @gqlType
+// @gqlInput
+type Profile struct {
+	Name     string
+	Bio      *string
+	Handle   string   ` + "`gql:\"handle,required\"`" + `
+	Website  *string  ` + "`gql:\"website,required\"`" + `
+	Nickname string   ` + "`gql:\"nickname,optional\"`" + `
+	Tags     []string
+	Score    int      ` + "`gql:\"score,type:Float!\"`" + `
+}
+`
+
+func TestDefaultNullable(t *testing.T) {
+	tests := []struct {
+		name            string
+		defaultNullable bool
+		want            []string
+	}{
+		{
+			name:            "non-null by default",
+			defaultNullable: false,
+			want: []string{
+				"name: String!", "bio: String!", "handle: String!", "website: String!",
+				"nickname: String\n", "tags: [String!]!", "score: Float!",
+			},
+		},
+		{
+			name:            "nullable by default",
+			defaultNullable: true,
+			want: []string{
+				"name: String\n", "bio: String\n", "handle: String!", "website: String!",
+				"nickname: String\n", "tags: [String!]\n", "score: Float!",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(defaultNullableSource), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			p := NewParser()
+			if err := p.Walk(tmpDir); err != nil {
+				t.Fatalf("Walk() error = %v", err)
+			}
+
+			cfg := NewConfig()
+			cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+			cfg.GenStrategy = GenStrategySingle
+			cfg.DefaultNullable = tt.defaultNullable
+
+			files, err := NewGenerator(p, cfg).Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			schema := files[cfg.Output]
+
+			// Types and inputs follow the same default
+			for _, header := range []string{"type Profile", "input ProfileInput"} {
+				block := schemaBlock(schema, header)
+				for _, want := range tt.want {
+					if !strings.Contains(block, want) {
+						t.Errorf("Expected %q in %s, got:\n%s", want, header, block)
+					}
+				}
+			}
+		})
+	}
+}
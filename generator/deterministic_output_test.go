@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDeterministicOutput(t *testing.T) {
+	src := `package models
+
+// @gqlInput(name:"ZetaInput")
+type Zeta struct {
+	Name string
+}
+
+// @gqlInput(name:"AlphaInput")
+type Alpha struct {
+	Name string
+}
+
+// @gqlType
+// @gqlInput(name:"MiddleInput")
+type Middle struct {
+	Name string
+}
+
+// @gqlInput(name:"BetaInput")
+type Beta struct {
+	Name string
+}
+
+// @gqlInput(name:"OmegaInput")
+type Omega struct {
+	Name string
+}
+
+// @gqlType
+type User struct {
+	ID string ` + "`gql:\"type:ID\"`" + `
+}
+`
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	build := func() map[string]string {
+		p := NewParser()
+		if err := p.Walk(tmpDir); err != nil {
+			t.Fatalf("Walk() error = %v", err)
+		}
+
+		config := NewConfig()
+		config.Output = filepath.Join(tmpDir, "out")
+		config.GenStrategy = GenStrategyPackage
+
+		files, err := NewGenerator(p, config).Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		return files
+	}
+
+	first := build()
+	if len(first) == 0 {
+		t.Fatal("expected generated files")
+	}
+	for i := 0; i < 10; i++ {
+		next := build()
+		if len(next) != len(first) {
+			t.Fatalf("run %d produced %d files, want %d", i, len(next), len(first))
+		}
+		for file, content := range first {
+			if next[file] != content {
+				t.Fatalf("run %d produced different output for %s:\n%s\nwant:\n%s", i, file, next[file], content)
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const directiveDefinitionsSource = `package models
+
+// @gqlEnum
+type Role string
+
+const (
+	RoleAdmin Role = "admin"
+)
+
+// @gqlType
+type User struct {
+	ID   string
+	Role Role
+}
+`
+
+func buildDirectiveDefinitions(t *testing.T, strategy GenStrategy, gqlgen bool) (map[string]string, *Config) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(directiveDefinitionsSource), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	p.MatchEnumConstants()
+
+	cfg := NewConfig()
+	cfg.GenStrategy = strategy
+	cfg.Output = filepath.Join(tmpDir, "schema")
+	if strategy == GenStrategySingle {
+		cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	}
+	cfg.UseGqlGenDirectives = gqlgen
+	cfg.ModelPath = "example.com/app/models"
+	cfg.EmitDirectiveDefinitions = true
+
+	files, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return files, cfg
+}
+
+func TestEmitDirectiveDefinitionsSingleFile(t *testing.T) {
+	files, cfg := buildDirectiveDefinitions(t, GenStrategySingle, true)
+	schema := files[cfg.Output]
+
+	// The used directives are defined at the top of the schema
+	if !strings.HasPrefix(schema, "directive @goModel(model: String, models: [String!]) on OBJECT | INPUT_OBJECT | SCALAR | ENUM | INTERFACE | UNION\n\n") {
+		t.Errorf("Expected the @goModel definition first, got:\n%s", schema)
+	}
+	if !strings.Contains(schema, "directive @goEnum(value: String) on ENUM_VALUE\n") {
+		t.Errorf("Expected the @goEnum definition, got:\n%s", schema)
+	}
+
+	// @goField is never written, so it is not defined
+	if strings.Contains(schema, "directive @goField") {
+		t.Errorf("Expected no @goField definition, got:\n%s", schema)
+	}
+	if len(files) != 1 {
+		t.Errorf("Expected no separate directives file, got %d files", len(files))
+	}
+}
+
+func TestEmitDirectiveDefinitionsFile(t *testing.T) {
+	files, cfg := buildDirectiveDefinitions(t, GenStrategyMultiple, true)
+
+	directives, exists := files[filepath.Join(cfg.Output, "directives.graphqls")]
+	if !exists {
+		t.Fatalf("Expected a directives.graphqls file, got %v", sortedKeys(files))
+	}
+	if !strings.Contains(directives, "directive @goModel(") {
+		t.Errorf("Expected the @goModel definition, got:\n%s", directives)
+	}
+	for file, content := range files {
+		if !strings.HasSuffix(file, "directives.graphqls") && strings.Contains(content, "directive @") {
+			t.Errorf("Expected definitions only in directives.graphqls, %s has:\n%s", file, content)
+		}
+	}
+
+	// Without gqlgen directives there is nothing to define
+	files, _ = buildDirectiveDefinitions(t, GenStrategyMultiple, false)
+	for file, content := range files {
+		if strings.Contains(content, "directive @") {
+			t.Errorf("Expected no definitions without gqlgen directives, %s has:\n%s", file, content)
+		}
+	}
+}
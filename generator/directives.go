@@ -3,52 +3,125 @@ package generator
 import (
 	"go/ast"
 	"reflect"
+	"slices"
 	"strings"
 	"unicode"
 )
 
 // ExtraField represents a @gqlTypeExtraField or @gqlInputExtraField annotation
 type ExtraField struct {
-	Name         string
-	Type         string
-	OverrideTags string
-	Description  string
-	ForType      bool     // true if this is a @gqlTypeExtraField
-	ForInput     bool     // true if this is a @gqlInputExtraField
-	On           []string // list of type/input names this applies to, empty or ["*"] means all
+	Name             string
+	Type             string
+	OverrideTags     string
+	Description      string
+	ForType          bool       // true if this is a @gqlTypeExtraField
+	ForInput         bool       // true if this is a @gqlInputExtraField
+	On               []string   // list of type/input names this applies to, empty or ["*"] means all
+	Args             []FieldArg // field arguments (type fields only), from args:"..." or @gqlArg
+	Deprecated       bool       // deprecated:"reason" or deprecated:true, skipped on required input fields
+	DeprecatedReason string
+}
+
+// newExtraField builds an ExtraField from the parameters shared by all extra-field directives
+func newExtraField(name string, params map[string]string, forType, forInput bool) ExtraField {
+	ef := ExtraField{Name: name, ForType: forType, ForInput: forInput, On: []string{"*"}}
+	ef.Type = params["type"]
+	ef.Description = params["description"]
+	ef.OverrideTags = params["overridetags"]
+	if on, ok := params["on"]; ok {
+		ef.On = parseListValue(on)
+	}
+	if reason, ok := params["deprecated"]; ok {
+		ef.Deprecated = true
+		if reason != "true" {
+			ef.DeprecatedReason = reason
+		}
+	}
+	return ef
+}
+
+// FieldArg represents an argument of an extra field, e.g. first: Int = 10
+type FieldArg struct {
+	Name    string
+	Type    string
+	Default string // default value literal, empty when the argument has no default
 }
 
 // TypeDefinition represents a single @gqlType annotation
 type TypeDefinition struct {
-	Name        string // Custom type name
-	Description string // Type description
-	IgnoreAll   bool   // ignoreAll property
-	Namespace   string // Custom namespace override
+	Name        string   // Custom type name
+	Description string   // Type description
+	DocComment  bool     // Description was taken from the doc comment, not the description property
+	IgnoreAll   bool     // ignoreAll property
+	Namespace   string   // Custom namespace override
+	FieldOrder  []string // Pinned field order, "*" = remaining fields (fieldOrder property)
+	From        string   // Source struct to take the fields from (from property, projection types)
+	Fields      []string // Whitelist of GraphQL field names to keep (fields property)
+	Implements  []string // GraphQL interfaces the type implements (implements property)
+	Relay       bool     // Generate Relay edge/connection types and implement Node (relay property)
+	Keys        []string // Federation entity keys (@gqlKey, repeatable)
+	Extend      bool     // Emit "extend type" for a type defined elsewhere (extend property or @gqlExtend)
+	OutputFile  string   // File the type is written to, relative to the output dir (@gqlFile)
+	Deprecated  bool     // @gqlDeprecated, rendered according to Config.TypeDeprecationMode
+	RawName     bool     // Use the Go type name verbatim, skipping the strip/add and case transforms (rawName property)
+	// Reason of @gqlDeprecated(reason:"..."), empty for a plain @gqlDeprecated
+	DeprecatedReason string
+	Root             string // Root operation the fields are merged into: query, mutation or subscription (root property)
 }
 
 // InputDefinition represents a single @gqlInput annotation
 type InputDefinition struct {
-	Name        string // Custom input name
-	Description string // Input description
-	IgnoreAll   bool   // ignoreAll property
-	Namespace   string // Custom namespace override
+	Name        string   // Custom input name
+	Description string   // Input description
+	DocComment  bool     // Description was taken from the doc comment, not the description property
+	IgnoreAll   bool     // ignoreAll property
+	Namespace   string   // Custom namespace override
+	OneOf       bool     // oneOf property: emit the @oneOf directive (tagged-union input)
+	Extend      bool     // extend property: emit "extend input" for an input defined elsewhere
+	OutputFile  string   // File the input is written to, relative to the output dir (@gqlFile)
+	EmbedInputs []string // Inputs whose fields are inlined, as Go struct names or GraphQL input names (embedInput property)
+	FromType    string   // Go struct the input takes its fields from instead of the annotated one (from property)
+}
+
+// InterfaceDefinition represents a single @gqlInterface annotation
+type InterfaceDefinition struct {
+	Name        string   // Custom interface name
+	Description string   // Interface description
+	DocComment  bool     // Description was taken from the doc comment, not the description property
+	IgnoreAll   bool     // ignoreAll property
+	Namespace   string   // Custom namespace override
+	Fields      []string // Whitelist of GraphQL field names to keep (fields property)
+}
+
+// UnionDefinition represents a single @gqlUnion annotation
+type UnionDefinition struct {
+	Name        string   // Custom union name
+	Description string   // Union description
+	DocComment  bool     // Description was taken from the doc comment, not the description property
+	Namespace   string   // Custom namespace override
+	Members     []string // Member types, as Go struct names or GraphQL type names (members property)
 }
 
 // StructDirectives holds parsed values from surrounding comments for a type
 type StructDirectives struct {
-	GQLName             string            // Default struct name
-	Types               []TypeDefinition  // All @gqlType annotations
-	Inputs              []InputDefinition // All @gqlInput annotations
-	IgnoreAll           bool              // @gqlIgnoreAll
-	UseModelDirective   bool              // @gqlUseModelDirective
-	SkipType            bool              // @gqlskip
-	GenInput            bool              // Generate input type
-	HasTypeDirective    bool              // Has @gqlType directive
-	HasInputDirective   bool              // Has @gqlInput directive
-	HasIncludeDirective bool              // Has @gqlInclude directive
-	Partial             bool              // @partial
-	TypeExtraFields     []ExtraField      // @gqlTypeExtraField (repeatable)
-	InputExtraFields    []ExtraField      // @gqlInputExtraField (repeatable)
+	GQLName               string                // Default struct name
+	Types                 []TypeDefinition      // All @gqlType annotations
+	Inputs                []InputDefinition     // All @gqlInput annotations
+	Interfaces            []InterfaceDefinition // All @gqlInterface annotations
+	Unions                []UnionDefinition     // All @gqlUnion annotations
+	IgnoreAll             bool                  // @gqlIgnoreAll
+	UseModelDirective     bool                  // @gqlUseModelDirective
+	SkipType              bool                  // @gqlskip
+	GenInput              bool                  // Generate input type
+	HasTypeDirective      bool                  // Has @gqlType directive
+	HasInputDirective     bool                  // Has @gqlInput directive
+	HasInterfaceDirective bool                  // Has @gqlInterface directive
+	HasUnionDirective     bool                  // Has @gqlUnion directive
+	HasIncludeDirective   bool                  // Has @gqlInclude directive
+	Partial               bool                  // @partial
+	OutputFile            string                // @gqlFile(name:"auth.graphqls")
+	TypeExtraFields       []ExtraField          // @gqlTypeExtraField (repeatable)
+	InputExtraFields      []ExtraField          // @gqlInputExtraField (repeatable)
 }
 
 // ParseDirectives collects directives from GenDecl.Doc, TypeSpec.Doc and TypeSpec.Comment
@@ -65,6 +138,11 @@ func ParseDirectives(typeSpec *ast.TypeSpec, genDecl *ast.GenDecl) StructDirecti
 		comments = append(comments, typeSpec.Comment)
 	}
 
+	var pendingArgs []pendingFieldArg
+	var federationKeys []string
+	var extendTypes bool
+	var deprecated bool
+	var deprecatedReason string
 	for _, cg := range comments {
 		for _, c := range cg.List {
 			text := strings.TrimSpace(c.Text)
@@ -83,7 +161,7 @@ func ParseDirectives(typeSpec *ast.TypeSpec, genDecl *ast.GenDecl) StructDirecti
 				line = strings.TrimPrefix(line, "*")
 				line = strings.TrimSpace(line)
 
-				// @gqlType(name:"TypeName",description:"desc",ignoreAll:true,namespace:"api/v1")
+				// @gqlType(name:"TypeName",description:"desc",ignoreAll:true,namespace:"api/v1",fieldOrder:"id,name,*",from:"User",fields:"id,name",implements:"Node,Timestamped",relay:true,extend:true,root:"query",rawName:true)
 				if hasDirectivePrefix(line, "Type(") || hasDirectiveName(line, "Type") {
 					res.HasTypeDirective = true
 					line = normalizeDirective(line)
@@ -99,21 +177,92 @@ func ParseDirectives(typeSpec *ast.TypeSpec, genDecl *ast.GenDecl) StructDirecti
 					if desc, ok := params["description"]; ok {
 						typeDef.Description = desc
 					}
-					if ignoreAll, ok := params["ignoreAll"]; ok && (ignoreAll == "true" || ignoreAll == "1") {
+					if ignoreAll, ok := params["ignoreall"]; ok && (ignoreAll == "true" || ignoreAll == "1") {
 						typeDef.IgnoreAll = true
 					}
 					if namespace, ok := params["namespace"]; ok {
 						typeDef.Namespace = namespace
 					}
+					if fieldOrder, ok := params["fieldorder"]; ok {
+						typeDef.FieldOrder = parseListValue(fieldOrder)
+					}
+					if from, ok := params["from"]; ok {
+						typeDef.From = from
+					}
+					if fields, ok := params["fields"]; ok {
+						typeDef.Fields = parseListValue(fields)
+					}
+					if implements, ok := params["implements"]; ok {
+						typeDef.Implements = parseListValue(implements)
+					}
+					if relay, ok := params["relay"]; ok && (relay == "true" || relay == "1") {
+						typeDef.Relay = true
+					}
+					if extend, ok := params["extend"]; ok && (extend == "true" || extend == "1") {
+						typeDef.Extend = true
+					}
+					if root, ok := params["root"]; ok {
+						typeDef.Root = root
+					}
+					if rawName, ok := params["rawname"]; ok && (rawName == "true" || rawName == "1") {
+						typeDef.RawName = true
+					}
 					res.Types = append(res.Types, typeDef)
 				}
 
+				// @gqlInterface(name:"Node",description:"desc",ignoreAll:true,namespace:"api/v1",fields:"id,createdAt")
+				if hasDirectivePrefix(line, "Interface(") || hasDirectiveName(line, "Interface") {
+					res.HasInterfaceDirective = true
+					line = normalizeDirective(line)
+					params := parseDirectiveParams(line, "@gqlInterface")
+
+					interfaceDef := InterfaceDefinition{}
+					if name, ok := params["name"]; ok && name != "" {
+						interfaceDef.Name = name
+					}
+					if desc, ok := params["description"]; ok {
+						interfaceDef.Description = desc
+					}
+					if ignoreAll, ok := params["ignoreall"]; ok && (ignoreAll == "true" || ignoreAll == "1") {
+						interfaceDef.IgnoreAll = true
+					}
+					if namespace, ok := params["namespace"]; ok {
+						interfaceDef.Namespace = namespace
+					}
+					if fields, ok := params["fields"]; ok {
+						interfaceDef.Fields = parseListValue(fields)
+					}
+					res.Interfaces = append(res.Interfaces, interfaceDef)
+				}
+
+				// @gqlUnion(name:"SearchResult",description:"desc",namespace:"api/v1",members:"User,Post,Comment")
+				if hasDirectivePrefix(line, "Union(") || hasDirectiveName(line, "Union") {
+					res.HasUnionDirective = true
+					line = normalizeDirective(line)
+					params := parseDirectiveParams(line, "@gqlUnion")
+
+					unionDef := UnionDefinition{}
+					if name, ok := params["name"]; ok && name != "" {
+						unionDef.Name = name
+					}
+					if desc, ok := params["description"]; ok {
+						unionDef.Description = desc
+					}
+					if namespace, ok := params["namespace"]; ok {
+						unionDef.Namespace = namespace
+					}
+					if members, ok := params["members"]; ok {
+						unionDef.Members = parseListValue(members)
+					}
+					res.Unions = append(res.Unions, unionDef)
+				}
+
 				// @gqlInclude or @GqlInclude - marks type as included without explicit type/input directives
 				if hasDirectivePrefix(line, "Include") || hasDirectiveName(line, "Include") {
 					res.HasIncludeDirective = true
 				}
 
-				// @gqlInput(name:"InputName",description:"desc",ignoreAll:true,namespace:"api/v1")
+				// @gqlInput(name:"InputName",description:"desc",ignoreAll:true,namespace:"api/v1",oneOf:true,extend:true,embedInput:"BaseInput",from:"CreateUserRequest")
 				if hasDirectivePrefix(line, "Input(") || hasDirectiveName(line, "Input") {
 					res.HasInputDirective = true
 					res.GenInput = true // Enable input generation
@@ -127,12 +276,24 @@ func ParseDirectives(typeSpec *ast.TypeSpec, genDecl *ast.GenDecl) StructDirecti
 					if desc, ok := params["description"]; ok {
 						inputDef.Description = desc
 					}
-					if ignoreAll, ok := params["ignoreAll"]; ok && (ignoreAll == "true" || ignoreAll == "1") {
+					if ignoreAll, ok := params["ignoreall"]; ok && (ignoreAll == "true" || ignoreAll == "1") {
 						inputDef.IgnoreAll = true
 					}
 					if namespace, ok := params["namespace"]; ok {
 						inputDef.Namespace = namespace
 					}
+					if oneOf, ok := params["oneof"]; ok && (oneOf == "true" || oneOf == "1") {
+						inputDef.OneOf = true
+					}
+					if extend, ok := params["extend"]; ok && (extend == "true" || extend == "1") {
+						inputDef.Extend = true
+					}
+					if embedInput, ok := params["embedinput"]; ok && embedInput != "" {
+						inputDef.EmbedInputs = parseListValue(embedInput)
+					}
+					if from, ok := params["from"]; ok && from != "" {
+						inputDef.FromType = from
+					}
 					res.Inputs = append(res.Inputs, inputDef)
 				}
 
@@ -146,28 +307,44 @@ func ParseDirectives(typeSpec *ast.TypeSpec, genDecl *ast.GenDecl) StructDirecti
 					res.UseModelDirective = true
 				}
 
+				// @gqlKey(fields:"id") - federation entity key, repeatable for several keys
+				if hasDirectivePrefix(line, "Key(") {
+					line = normalizeDirective(line)
+					params := parseDirectiveParams(line, "@gqlKey")
+					if fields, ok := params["fields"]; ok && fields != "" {
+						federationKeys = append(federationKeys, fields)
+					}
+				}
+
+				// @gqlFile(name:"auth.graphqls") - write the types and inputs to their own file
+				if hasDirectivePrefix(line, "File(") {
+					line = normalizeDirective(line)
+					params := parseDirectiveParams(line, "@gqlFile")
+					res.OutputFile = params["name"]
+				}
+
+				// @gqlDeprecated(reason:"Use Account instead") - sunset all the types of the struct
+				if ok, reason := parseDeprecatedDirective(line); ok {
+					deprecated, deprecatedReason = true, reason
+				}
+
+				// @gqlExtend - extend the types instead of defining them (extend type X)
+				if hasDirectiveName(line, "Extend") {
+					extendTypes = true
+				}
+
 				// @gqlExtraField(name:"fieldName",type:"FieldType",description:"desc",on:"Input1,Input2")
 				if hasDirectivePrefix(line, "ExtraField") {
 					line = normalizeDirective(line)
 					params := parseDirectiveParams(line, "@gqlExtraField")
 					if name, ok := params["name"]; ok && name != "" {
-						ef := ExtraField{Name: name, ForType: true, ForInput: true}
-						if typ, ok := params["type"]; ok {
-							ef.Type = typ
-						}
-						if desc, ok := params["description"]; ok {
-							ef.Description = desc
-						}
-						if tags, ok := params["overrideTags"]; ok {
-							ef.OverrideTags = tags
-						}
-						if on, ok := params["on"]; ok {
-							ef.On = parseListValue(on)
-						} else {
-							ef.On = []string{"*"}
-						}
+						ef := newExtraField(name, params, true, true)
 						if ef.Type != "" {
+							// Arguments only apply to type fields, inputs cannot declare them
 							res.InputExtraFields = append(res.InputExtraFields, ef)
+							if args, ok := params["args"]; ok {
+								ef.Args = parseFieldArgs(args)
+							}
 							res.TypeExtraFields = append(res.TypeExtraFields, ef)
 						}
 					}
@@ -178,20 +355,9 @@ func ParseDirectives(typeSpec *ast.TypeSpec, genDecl *ast.GenDecl) StructDirecti
 					line = normalizeDirective(line)
 					params := parseDirectiveParams(line, "@gqlTypeExtraField")
 					if name, ok := params["name"]; ok && name != "" {
-						ef := ExtraField{Name: name, ForType: true, ForInput: false}
-						if typ, ok := params["type"]; ok {
-							ef.Type = typ
-						}
-						if desc, ok := params["description"]; ok {
-							ef.Description = desc
-						}
-						if tags, ok := params["overrideTags"]; ok {
-							ef.OverrideTags = tags
-						}
-						if on, ok := params["on"]; ok {
-							ef.On = parseListValue(on)
-						} else {
-							ef.On = []string{"*"}
+						ef := newExtraField(name, params, true, false)
+						if args, ok := params["args"]; ok {
+							ef.Args = parseFieldArgs(args)
 						}
 						if ef.Type != "" {
 							res.TypeExtraFields = append(res.TypeExtraFields, ef)
@@ -199,26 +365,26 @@ func ParseDirectives(typeSpec *ast.TypeSpec, genDecl *ast.GenDecl) StructDirecti
 					}
 				}
 
+				// @gqlArg(field:"fieldName",name:"argName",type:"ArgType",default:"10")
+				// Adds an argument to the type extra field with the given name
+				if hasDirectivePrefix(line, "Arg(") {
+					line = normalizeDirective(line)
+					params := parseDirectiveParams(line, "@gqlArg")
+					field, name, typ := params["field"], params["name"], params["type"]
+					if field != "" && name != "" && typ != "" {
+						pendingArgs = append(pendingArgs, pendingFieldArg{
+							Field: field,
+							Arg:   FieldArg{Name: name, Type: typ, Default: params["default"]},
+						})
+					}
+				}
+
 				// @gqlInputExtraField(name:"fieldName",type:"FieldType",description:"desc",on:"Input1,Input2")
 				if hasDirectivePrefix(line, "InputExtraField") {
 					line = normalizeDirective(line)
 					params := parseDirectiveParams(line, "@gqlInputExtraField")
 					if name, ok := params["name"]; ok && name != "" {
-						ef := ExtraField{Name: name, ForType: false, ForInput: true}
-						if typ, ok := params["type"]; ok {
-							ef.Type = typ
-						}
-						if desc, ok := params["description"]; ok {
-							ef.Description = desc
-						}
-						if tags, ok := params["overrideTags"]; ok {
-							ef.OverrideTags = tags
-						}
-						if on, ok := params["on"]; ok {
-							ef.On = parseListValue(on)
-						} else {
-							ef.On = []string{"*"}
-						}
+						ef := newExtraField(name, params, false, true)
 						if ef.Type != "" {
 							res.InputExtraFields = append(res.InputExtraFields, ef)
 						}
@@ -228,6 +394,53 @@ func ParseDirectives(typeSpec *ast.TypeSpec, genDecl *ast.GenDecl) StructDirecti
 		}
 	}
 
+	// Attach @gqlArg arguments to their extra fields (the extra field may be declared after the argument)
+	for _, pending := range pendingArgs {
+		for i := range res.TypeExtraFields {
+			if res.TypeExtraFields[i].Name == pending.Field {
+				res.TypeExtraFields[i].Args = append(res.TypeExtraFields[i].Args, pending.Arg)
+			}
+		}
+	}
+
+	// Federation keys, @gqlExtend, @gqlDeprecated and @gqlFile apply to every type of the struct
+	for i := range res.Types {
+		res.Types[i].Keys = federationKeys
+		if extendTypes {
+			res.Types[i].Extend = true
+		}
+		res.Types[i].Deprecated = deprecated
+		res.Types[i].DeprecatedReason = deprecatedReason
+		res.Types[i].OutputFile = res.OutputFile
+	}
+	for i := range res.Inputs {
+		res.Inputs[i].OutputFile = res.OutputFile
+	}
+
+	// Plain doc comments describe the definitions without an explicit description:
+	if doc := extractDescription(comments...); doc != "" {
+		for i := range res.Types {
+			if res.Types[i].Description == "" {
+				res.Types[i].Description, res.Types[i].DocComment = doc, true
+			}
+		}
+		for i := range res.Inputs {
+			if res.Inputs[i].Description == "" {
+				res.Inputs[i].Description, res.Inputs[i].DocComment = doc, true
+			}
+		}
+		for i := range res.Interfaces {
+			if res.Interfaces[i].Description == "" {
+				res.Interfaces[i].Description, res.Interfaces[i].DocComment = doc, true
+			}
+		}
+		for i := range res.Unions {
+			if res.Unions[i].Description == "" {
+				res.Unions[i].Description, res.Unions[i].DocComment = doc, true
+			}
+		}
+	}
+
 	// Check for @gqlskip or @gqlIgnore (prevent auto-generation)
 	for _, cg := range comments {
 		for _, c := range cg.List {
@@ -256,6 +469,40 @@ func ParseDirectives(typeSpec *ast.TypeSpec, genDecl *ast.GenDecl) StructDirecti
 	return res
 }
 
+// pendingFieldArg is a @gqlArg waiting to be attached to its extra field
+type pendingFieldArg struct {
+	Field string
+	Arg   FieldArg
+}
+
+// parseFieldArgs parses an args value like "first:Int=10,after:String,ids:[ID!]"
+// Each argument is name:Type with an optional =default, the list may be wrapped in brackets
+func parseFieldArgs(value string) []FieldArg {
+	value = strings.TrimSpace(value)
+	// Argument lists start with a name, so a leading bracket can only wrap the whole list
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		value = strings.TrimSpace(value[1 : len(value)-1])
+	}
+
+	var args []FieldArg
+	for _, part := range splitParams(value) {
+		nameAndType := strings.SplitN(part, ":", 2)
+		if len(nameAndType) != 2 {
+			continue
+		}
+		arg := FieldArg{Name: strings.TrimSpace(nameAndType[0])}
+		typeAndDefault := strings.SplitN(nameAndType[1], "=", 2)
+		arg.Type = strings.TrimSpace(typeAndDefault[0])
+		if len(typeAndDefault) == 2 {
+			arg.Default = strings.TrimSpace(typeAndDefault[1])
+		}
+		if arg.Name != "" && arg.Type != "" {
+			args = append(args, arg)
+		}
+	}
+	return args
+}
+
 // hasDirectivePrefix checks if line starts with @gql or @Gql followed by the given suffix
 func hasDirectivePrefix(line, suffix string) bool {
 	return strings.HasPrefix(line, "@gql"+suffix) || strings.HasPrefix(line, "@Gql"+suffix)
@@ -266,6 +513,17 @@ func hasDirectiveName(line, name string) bool {
 	return line == "@gql"+name || line == "@Gql"+name
 }
 
+// parseDeprecatedDirective parses a @gqlDeprecated or @gqlDeprecated(reason:"...") line
+func parseDeprecatedDirective(line string) (bool, string) {
+	if hasDirectiveName(line, "Deprecated") {
+		return true, ""
+	}
+	if hasDirectivePrefix(line, "Deprecated(") {
+		return true, parseDirectiveParams(normalizeDirective(line), "@gqlDeprecated")["reason"]
+	}
+	return false, ""
+}
+
 // normalizeDirective converts @Gql to @gql for consistent parsing
 func normalizeDirective(line string) string {
 	if strings.HasPrefix(line, "@Gql") {
@@ -310,7 +568,8 @@ func parseDirectiveParams(line, prefix string) map[string]string {
 		// Remove quotes from value
 		value = strings.Trim(value, `"`)
 
-		result[key] = value
+		// Keys are case-insensitive (like directive names), so Name: and name: are equivalent
+		result[strings.ToLower(key)] = value
 	}
 
 	return result
@@ -451,35 +710,81 @@ type FieldOptions struct {
 	Description      string
 	Deprecated       bool   // Field is deprecated (flag only)
 	DeprecatedReason string // Deprecation reason (if provided)
+	MapAsPairs       *bool  // Render map as key-value pair list (nil = use Config.MapAsPairs)
+	Default          string // Default value for input fields (default:20), ignored on type fields
+	External         bool   // Federation @external (external:true or @gqlExternal), type fields only
+	Shareable        bool   // Federation @shareable (shareable:true or @gqlShareable), type fields only
 }
 
-// ParseFieldOptions parses `gql:"name,omit|include,optional|required,type:GqlType,forceResolver,description:\"desc\",deprecated,deprecated:\"reason\""`
+// ParseFieldOptions parses `gql:"name,omit|include,optional|required,type:GqlType,forceResolver,description:\"desc\",deprecated,deprecated:\"reason\",default:20,external:true,shareable:true"`
+// With Config.OmitemptyNullable, json fields tagged omitempty are optional unless tagged required or typed
 func ParseFieldOptions(field *ast.Field, config *Config) FieldOptions {
-	res := FieldOptions{}
+	res := parseFieldTags(field, config)
+	if config.OmitemptyNullable && config.UseJsonTag && !res.Required && res.Type == "" && hasOmitempty(field) {
+		res.Optional = true
+	}
+	return res
+}
+
+// hasOmitempty reports whether the field's json tag has the omitempty option
+func hasOmitempty(field *ast.Field) bool {
+	if field.Tag == nil {
+		return false
+	}
+	value := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Get("json")
+	for _, option := range strings.Split(value, ",")[1:] {
+		if strings.TrimSpace(option) == "omitempty" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFieldTags reads the field options from the doc directives and the configured struct tags
+func parseFieldTags(field *ast.Field, config *Config) FieldOptions {
+	// The field's doc comment is the description unless the tag sets description:
+	// or Config.DisableDocDescriptions is set
+	// A Go "Deprecated:" paragraph deprecates the field unless the tag says otherwise
+	doc, reason, deprecated := splitGoDeprecation(field.Doc)
+	if !deprecated {
+		_, reason, deprecated = splitGoDeprecation(field.Comment)
+	}
+	res := FieldOptions{Deprecated: deprecated, DeprecatedReason: reason}
+	if !config.DisableDocDescriptions {
+		res.Description = extractDescription(doc)
+	}
+	parseFieldDocDirectives(field.Doc, &res)
 	if field.Tag == nil {
 		return res
 	}
 	tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
-	g := tag.Get("gql")
-
-	// If no gql tag, try json tag if enabled
-	if g == "" && config.UseJsonTag {
-		jsonTag := tag.Get("json")
-		if jsonTag != "" {
-			jsonParts := strings.Split(jsonTag, ",")
-			// Check for json:"-" which means ignore the field
-			if len(jsonParts) > 0 && jsonParts[0] == "-" {
-				res.Ignore = true
-				return res
-			}
-			if len(jsonParts) > 0 && jsonParts[0] != "" {
-				res.Name = jsonParts[0]
-			}
+	tags := config.fieldTags()
+
+	// Tags ranked above gql (e.g., graphql, json) only give the name, or "-" to ignore the field
+	// The first tag present decides, so a gql tag keeps json:"-" from ignoring the field
+	otherName := ""
+	for _, tagName := range tags {
+		value := tag.Get(tagName)
+		if value == "" {
+			continue
 		}
-		return res
+		if tagName == "gql" {
+			break
+		}
+		otherName = strings.TrimSpace(strings.Split(value, ",")[0])
+		if otherName == "-" {
+			res.Ignore = true
+			return res
+		}
+		break
 	}
 
+	g := ""
+	if slices.Contains(tags, "gql") {
+		g = tag.Get("gql")
+	}
 	if g == "" {
+		res.Name = otherName
 		return res
 	}
 
@@ -510,6 +815,9 @@ func ParseFieldOptions(field *ast.Field, config *Config) FieldOptions {
 			value := strings.TrimSpace(kv[1])
 
 			switch key {
+			case "gqlName":
+				// gqlName:displayName - GraphQL field name, takes precedence over the positional name
+				res.Name = strings.Trim(value, "\"'")
 			case "type":
 				res.Type = value
 			case "description":
@@ -528,12 +836,25 @@ func ParseFieldOptions(field *ast.Field, config *Config) FieldOptions {
 			case "rw":
 				// rw:TypeA,TypeB or rw:* or rw (read-write, both types and inputs)
 				res.ReadWrite = parseTypeList(value)
-			case "ro":
-				// ro:TypeA,TypeB or ro:* or ro (read-only, types only)
+			case "ro", "typeOnly":
+				// ro:TypeA,TypeB or ro:* or ro (read-only, types only), typeOnly is an alias
 				res.ReadOnly = parseTypeList(value)
-			case "wo":
-				// wo:TypeA,TypeB or wo:* or wo (write-only, inputs only)
+			case "wo", "inputOnly":
+				// wo:TypeA,TypeB or wo:* or wo (write-only, inputs only), inputOnly is an alias
 				res.WriteOnly = parseTypeList(value)
+			case "pairs":
+				// pairs:true|false - override Config.MapAsPairs for this field
+				asPairs := value != "false"
+				res.MapAsPairs = &asPairs
+			case "default":
+				// default:20 or default:'text' - default value for input fields (quoted on render when needed)
+				res.Default = strings.Trim(value, "\"'")
+			case "external":
+				// external:true - federation @external; not a bare flag, so gql:"external" still names a field
+				res.External = value == "true"
+			case "shareable":
+				// shareable:true - federation @shareable, same as external
+				res.Shareable = value == "true"
 			}
 			continue
 		}
@@ -552,10 +873,10 @@ func ParseFieldOptions(field *ast.Field, config *Config) FieldOptions {
 		case "rw":
 			// Read-write: include in both types and inputs (all)
 			res.ReadWrite = []string{"*"}
-		case "ro":
+		case "ro", "typeOnly":
 			// Read-only: include only in types, not in inputs (all)
 			res.ReadOnly = []string{"*"}
-		case "wo":
+		case "wo", "inputOnly":
 			// Write-only: include only in inputs, not in types (all)
 			res.WriteOnly = []string{"*"}
 		case "optional":
@@ -568,12 +889,71 @@ func ParseFieldOptions(field *ast.Field, config *Config) FieldOptions {
 		case "deprecated":
 			// deprecated - mark as deprecated without reason
 			res.Deprecated = true
+			res.DeprecatedReason = ""
+		case "pairs":
+			// Render map as key-value pair list
+			asPairs := true
+			res.MapAsPairs = &asPairs
 		}
 	}
 
+	// A higher-ranked tag names the field, gql still sets its options
+	if otherName != "" {
+		res.Name = otherName
+	}
+
 	return res
 }
 
+// parseFieldDocDirectives applies the field-level directives of a field doc comment
+// (@gqlExternal, @gqlShareable)
+func parseFieldDocDirectives(doc *ast.CommentGroup, res *FieldOptions) {
+	if doc == nil {
+		return
+	}
+	for _, c := range doc.List {
+		line := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		switch {
+		case hasDirectiveName(line, "External"):
+			res.External = true
+		case hasDirectiveName(line, "Shareable"):
+			res.Shareable = true
+		case hasDirectiveName(line, "TypeOnly"):
+			// @gqlTypeOnly is the ro flag
+			res.ReadOnly = []string{"*"}
+		case hasDirectiveName(line, "InputOnly"):
+			// @gqlInputOnly is the wo flag
+			res.WriteOnly = []string{"*"}
+		}
+	}
+}
+
+// splitGoDeprecation finds a Go "// Deprecated: reason" paragraph in a comment group and returns the
+// group without it, the reason (continuation lines up to the next blank line included) and whether it was found
+func splitGoDeprecation(doc *ast.CommentGroup) (*ast.CommentGroup, string, bool) {
+	if doc == nil {
+		return nil, "", false
+	}
+	rest := &ast.CommentGroup{}
+	var reason []string
+	found, inParagraph := false, false
+	for _, c := range doc.List {
+		line := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if !found && strings.HasPrefix(line, "Deprecated:") {
+			found, inParagraph = true, true
+			reason = append(reason, strings.TrimSpace(strings.TrimPrefix(line, "Deprecated:")))
+			continue
+		}
+		if inParagraph && line != "" && !strings.HasPrefix(line, "@") {
+			reason = append(reason, line)
+			continue
+		}
+		inParagraph = false
+		rest.List = append(rest.List, c)
+	}
+	return rest, strings.TrimSpace(strings.Join(reason, " ")), found
+}
+
 // splitParamsWithLists splits parameters by comma, respecting quoted strings and brackets
 // Comma-separated type lists can use: include:'TypeA,TypeB', include:"TypeA,TypeB", or include:[TypeA,TypeB]
 func splitParamsWithLists(s string) []string {
@@ -644,35 +1024,30 @@ func splitParamsWithLists(s string) []string {
 // }
 
 // ResolveFieldName resolves field name based on config and tags
-// Priority: gql tag name > json tag > struct field name (case transformation only applies to struct field)
+// Priority: tags in TagPriority order (by default gql gqlName: > gql positional name > json tag) > struct field name
+// (case transformation only applies to struct field)
 func ResolveFieldName(field *ast.Field, config *Config) string {
-	// 1. Check gql tag name (highest priority, always used if present)
+	// 1. The first tag in TagPriority that names the field (gql, then json by default)
 	if field.Tag != nil {
 		tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
-		g := tag.Get("gql")
-		if g != "" {
-			parts := strings.Split(g, ",")
-			if len(parts) > 0 {
-				firstPart := strings.TrimSpace(parts[0])
-				// If first part is not empty and not a flag/key:value, it's the name
-				if firstPart != "" && !strings.Contains(firstPart, ":") && !isKnownFlag(firstPart) {
-					return firstPart
-				}
+		for _, tagName := range config.fieldTags() {
+			value := tag.Get(tagName)
+			if value == "" {
+				continue
 			}
-		}
-
-		// 2. Try json tag (second priority)
-		if config.UseJsonTag {
-			if j := tag.Get("json"); j != "" {
-				jsonName := strings.Split(j, ",")[0]
-				if jsonName != "" && jsonName != "-" {
-					return jsonName
+			if tagName == "gql" {
+				if name := gqlTagName(value); name != "" {
+					return name
 				}
+				continue
+			}
+			if name := strings.TrimSpace(strings.Split(value, ",")[0]); name != "" && name != "-" {
+				return name
 			}
 		}
 	}
 
-	// 3. Use struct field name with case transformation (lowest priority)
+	// 2. Use struct field name with case transformation (lowest priority)
 	if len(field.Names) > 0 {
 		name := field.Names[0].Name
 		return TransformFieldName(name, config.FieldCase)
@@ -680,6 +1055,39 @@ func ResolveFieldName(field *ast.Field, config *Config) string {
 	return ""
 }
 
+// gqlTagName returns the field name set by a gql tag, or "" if the tag only has options
+func gqlTagName(g string) string {
+	parts := splitParamsWithLists(g)
+	// An explicit gqlName: key wins over the positional name
+	for _, p := range parts {
+		if name, ok := strings.CutPrefix(strings.TrimSpace(p), "gqlName:"); ok && name != "" {
+			return strings.Trim(strings.TrimSpace(name), "\"'")
+		}
+	}
+	if len(parts) > 0 {
+		firstPart := strings.TrimSpace(parts[0])
+		// If first part is not empty and not a flag/key:value, it's the name
+		if firstPart != "" && !strings.Contains(firstPart, ":") && !isKnownFlag(firstPart) {
+			return firstPart
+		}
+	}
+	return ""
+}
+
+// JSONTagName returns the name from a field's json struct tag, or empty string if
+// the field has no json tag, the name is omitted, or the field is ignored with json:"-"
+func JSONTagName(field *ast.Field) string {
+	if field.Tag == nil {
+		return ""
+	}
+	tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+	jsonName := strings.Split(tag.Get("json"), ",")[0]
+	if jsonName == "-" {
+		return ""
+	}
+	return jsonName
+}
+
 // TransformFieldName transforms a field name based on the case setting
 func TransformFieldName(name string, fieldCase FieldCase) string {
 	switch fieldCase {
@@ -720,7 +1128,7 @@ func isAllUpper(s string) bool {
 // isKnownFlag checks if a string is a known gql tag flag
 func isKnownFlag(s string) bool {
 	switch s {
-	case "ignore", "omit", "include", "optional", "required", "forceResolver", "force_resolver", "deprecated", "rw", "ro", "wo":
+	case "ignore", "omit", "include", "optional", "required", "forceResolver", "force_resolver", "deprecated", "rw", "ro", "wo", "typeOnly", "inputOnly", "pairs":
 		return true
 	}
 	return false
@@ -794,6 +1202,18 @@ func StripPrefixSuffix(name, prefixList, suffixList string) string {
 	return name
 }
 
+// TransformTypeName transforms a type or input name based on the case setting
+func TransformTypeName(name string, typeNameCase TypeNameCase) string {
+	switch typeNameCase {
+	case TypeNameCaseSnake:
+		return ToSnakeCase(name)
+	case TypeNameCaseScreaming:
+		return toScreamingSnakeCase(name)
+	default:
+		return name // Keep as-is (PascalCase)
+	}
+}
+
 // ToSnakeCase converts PascalCase to snake_case
 func ToSnakeCase(s string) string {
 	var result strings.Builder
@@ -806,53 +1226,62 @@ func ToSnakeCase(s string) string {
 	return strings.ToLower(result.String())
 }
 
-// // extractDescription extracts description from comment group
-// func extractDescription(commentGroup *ast.CommentGroup) string {
-// 	if commentGroup == nil {
-// 		return ""
-// 	}
+// extractDescription joins the plain (non-directive) lines of the comment groups with spaces
+// It is used as the GraphQL description when no description: parameter is given
+func extractDescription(commentGroups ...*ast.CommentGroup) string {
+	var description []string
+	for _, commentGroup := range commentGroups {
+		if commentGroup == nil {
+			continue
+		}
+		for _, comment := range commentGroup.List {
+			text := comment.Text
+			// Normalize block comments
+			text = strings.TrimPrefix(text, "/**")
+			text = strings.TrimPrefix(text, "/*")
+			text = strings.TrimSuffix(text, "*/")
 
-// 	var description []string
-// 	for _, comment := range commentGroup.List {
-// 		text := comment.Text
-// 		// Normalize block comments
-// 		text = strings.TrimPrefix(text, "/*")
-// 		text = strings.TrimPrefix(text, "/**")
-// 		text = strings.TrimSuffix(text, "*/")
-
-// 		// Process each line
-// 		for _, line := range strings.Split(text, "\n") {
-// 			line = strings.TrimSpace(line)
-// 			line = strings.TrimPrefix(line, "//")
-// 			line = strings.TrimPrefix(line, "*")
-// 			line = strings.TrimSpace(line)
-
-// 			// Skip directive lines
-// 			if strings.HasPrefix(line, "@") {
-// 				continue
-// 			}
-
-// 			if line != "" {
-// 				description = append(description, line)
-// 			}
-// 		}
-// 	}
+			// Process each line
+			for _, line := range strings.Split(text, "\n") {
+				line = strings.TrimSpace(line)
+				line = strings.TrimPrefix(line, "//")
+				line = strings.TrimPrefix(line, "*")
+				line = strings.TrimSpace(line)
 
-// 	return strings.Join(description, " ")
-// }
+				// Skip directive lines and tool pragmas (//go:generate, //nolint, ...)
+				if strings.HasPrefix(line, "@") || isPragmaComment(line) {
+					continue
+				}
+
+				if line != "" {
+					description = append(description, line)
+				}
+			}
+		}
+	}
+
+	return strings.Join(description, " ")
+}
+
+// isPragmaComment reports whether a comment line is a tool directive rather than documentation
+func isPragmaComment(line string) bool {
+	for _, prefix := range []string{"go:", "nolint", "lint:", "+build"} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
 
 // extractDirectiveParam extracts a parameter value from a directive comment
 // e.g., @gqlEnumValue(name:"CUSTOM") -> extractDirectiveParam(text, "name") returns "CUSTOM"
 func extractDirectiveParam(text, paramName string) string {
-	// Look for pattern: paramName:"value" or paramName:'value'
-	pattern := paramName + `:`
-	idx := strings.Index(text, pattern)
+	// Look for pattern: paramName:"value" or paramName:'value' (key is case-insensitive)
+	idx := findDirectiveParamKey(text, paramName)
 	if idx == -1 {
 		return ""
 	}
-
-	// Skip past the parameter name and colon
-	text = text[idx+len(pattern):]
+	text = text[idx:]
 	text = strings.TrimSpace(text)
 
 	// Extract quoted value
@@ -872,3 +1301,40 @@ func extractDirectiveParam(text, paramName string) string {
 
 	return ""
 }
+
+// findDirectiveParamKey returns the index just past the colon of the paramName key in text, or -1.
+// Only whole keys outside quoted values match, so name: is not found in typeName: or description:"name: x"
+func findDirectiveParamKey(text, paramName string) int {
+	var quote byte
+	lastNonSpace := byte(0)
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		// Quotes open a value only after a colon, apostrophes in plain comment text are not values
+		if (c == '"' || c == '\'') && lastNonSpace == ':' {
+			quote = c
+			continue
+		}
+		if c != ' ' && c != '\t' {
+			lastNonSpace = c
+		}
+
+		// The key starts the text or follows a separator
+		if i+len(paramName) > len(text) || !strings.EqualFold(text[i:i+len(paramName)], paramName) {
+			continue
+		}
+		if i > 0 && !strings.ContainsRune(" \t,(", rune(text[i-1])) {
+			continue
+		}
+		rest := strings.TrimLeft(text[i+len(paramName):], " \t")
+		if strings.HasPrefix(rest, ":") {
+			return len(text) - len(rest) + 1
+		}
+	}
+	return -1
+}
@@ -4,6 +4,9 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -362,3 +365,306 @@ func TestArraySyntaxEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestDirectiveParamKeysCaseInsensitive(t *testing.T) {
+	sourceCode := `package test
+
+/**
+ * @gqlType(Name:"X", Description:"Y", IgnoreAll:true)
+ * @GqlInput(NAME:"XInput", Namespace:"api")
+ * @gqlTypeExtraField(Name:"posts",Type:"[Post!]!",On:["X"])
+ */
+type Thing struct {
+	ID string
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", sourceCode, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	var typeSpec *ast.TypeSpec
+	var genDecl *ast.GenDecl
+	ast.Inspect(file, func(n ast.Node) bool {
+		if gd, ok := n.(*ast.GenDecl); ok {
+			genDecl = gd
+			if len(gd.Specs) > 0 {
+				if ts, ok := gd.Specs[0].(*ast.TypeSpec); ok {
+					typeSpec = ts
+					return false
+				}
+			}
+		}
+		return true
+	})
+
+	if typeSpec == nil {
+		t.Fatalf("Failed to find type spec")
+	}
+
+	directives := ParseDirectives(typeSpec, genDecl)
+
+	if len(directives.Types) != 1 {
+		t.Fatalf("Expected 1 type definition, got %d", len(directives.Types))
+	}
+	typeDef := directives.Types[0]
+	if typeDef.Name != "X" {
+		t.Errorf("Expected type name 'X', got %q", typeDef.Name)
+	}
+	if typeDef.Description != "Y" {
+		t.Errorf("Expected description 'Y', got %q", typeDef.Description)
+	}
+	if !typeDef.IgnoreAll {
+		t.Errorf("Expected IgnoreAll to be true")
+	}
+
+	if len(directives.Inputs) != 1 || directives.Inputs[0].Name != "XInput" {
+		t.Errorf("Expected input named 'XInput', got %+v", directives.Inputs)
+	}
+
+	if len(directives.TypeExtraFields) != 1 {
+		t.Fatalf("Expected 1 TypeExtraField, got %d", len(directives.TypeExtraFields))
+	}
+	ef := directives.TypeExtraFields[0]
+	if ef.Name != "posts" || ef.Type != "[Post!]!" || len(ef.On) != 1 || ef.On[0] != "X" {
+		t.Errorf("Unexpected extra field: %+v", ef)
+	}
+}
+
+func TestInputOneOf(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+/**
+ * @gqlInput(name:"ProcessInput", oneOf:true)
+ */
+type Process struct {
+	ByID   *string ` + "`gql:\"byId,optional\"`" + `
+	ByName *string ` + "`gql:\"byName,optional\"`" + `
+}
+
+/**
+ * @gqlInput
+ */
+type Plain struct {
+	ID string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "process.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+
+	gen := NewGenerator(p, cfg)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	schema := string(content)
+
+	if !strings.Contains(schema, "input ProcessInput @oneOf {\n  byId: String\n  byName: String\n}") {
+		t.Errorf("Expected @oneOf input, got:\n%s", schema)
+	}
+	if !strings.Contains(schema, "input PlainInput {") {
+		t.Errorf("Expected plain input without @oneOf, got:\n%s", schema)
+	}
+}
+
+func TestInputOneOfForcesNullable(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlInput(name:"FilterInput", oneOf:true)
+// @gqlInputExtraField(name:"byTag",type:"String!",description:"Tag to match")
+type Filter struct {
+	// Exact identifier
+	ByID    *string
+	ByName  string
+	ByEmail *string ` + "`gql:\"byEmail,required\"`" + `
+	ByIDs   []string
+	ByCode  string ` + "`gql:\"byCode,type:ID!\"`" + `
+	Scope
+}
+
+type Scope struct {
+	ByOwner string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "filter.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+
+	gen := NewGenerator(p, cfg)
+	files, err := gen.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	// Every field is nullable: required, type overrides, embedded and extra fields included
+	want := "input FilterInput @oneOf {\n" +
+		"  \"Exact identifier\"\n  byID: String\n" +
+		"  byName: String\n" +
+		"  byEmail: String\n" +
+		"  byIDs: [String!]\n" +
+		"  byCode: ID\n" +
+		"  byOwner: String\n" +
+		"  \"Tag to match\"\n  byTag: String\n" +
+		"}"
+	if schema := files[cfg.Output]; !strings.Contains(schema, want) {
+		t.Errorf("Expected all-nullable @oneOf input:\n%s\ngot:\n%s", want, schema)
+	}
+
+	// The manifest records the relaxed types too
+	for _, item := range gen.GeneratedItems {
+		if item.GQLName != "FilterInput" {
+			continue
+		}
+		for _, field := range item.Fields {
+			if strings.HasSuffix(field.Type, "!") {
+				t.Errorf("Expected %s to be nullable, got %s", field.Name, field.Type)
+			}
+		}
+	}
+}
+
+func TestTypeProjectionFrom(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlType
+type User struct {
+	ID       string
+	Name     string
+	Avatar   string
+	Email    string
+	Password string
+}
+
+// @gqlType(name:"PublicProfile", from:"User", fields:"id,name,avatar")
+type PublicProfile struct{}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "user.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+
+	gen := NewGenerator(p, cfg)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	schema := string(content)
+
+	want := "type PublicProfile {\n  id: String!\n  name: String!\n  avatar: String!\n}"
+	if !strings.Contains(schema, want) {
+		t.Errorf("Expected projection type:\n%s\ngot:\n%s", want, schema)
+	}
+	if !strings.Contains(schema, "type User {") || !strings.Contains(schema, "password: String!") {
+		t.Errorf("Expected full User type to be generated, got:\n%s", schema)
+	}
+}
+
+func TestTypeExtraFieldArgs(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlType
+// @gqlTypeExtraField(name:"posts",type:"PostConnection!",args:"first:Int=10,after:String,ids:[ID!]",on:"User")
+// @gqlArg(field:"avatar",name:"size",type:"Int",default:"64")
+// @gqlTypeExtraField(name:"avatar",type:"String")
+type User struct {
+	ID string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "user.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+
+	gen := NewGenerator(p, cfg)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	schema := string(content)
+
+	for _, want := range []string{
+		"  posts(first: Int = 10, after: String, ids: [ID!]): PostConnection!",
+		"  avatar(size: Int = 64): String",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain %q, got:\n%s", want, schema)
+		}
+	}
+}
+
+func TestExtractDirectiveParamMatchesWholeKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		param string
+		want  string
+	}{
+		{"key ending in the name", `@gqlEnum(typeName:"Kind", name:"Status")`, "name", "Status"},
+		{"name inside a quoted value", `@gqlEnumValue(description:"old name: LEGACY", name:"CURRENT")`, "name", "CURRENT"},
+		{"spaces before the colon", `@gqlEnum(name : "Status")`, "name", "Status"},
+		{"case-insensitive key", `@gqlEnum(Name:"Status")`, "name", "Status"},
+		{"apostrophe in comment text", `// Customer's pick @gqlEnumValue(name:"PICK")`, "name", "PICK"},
+		{"only a longer key", `@gqlEnum(typeName:"Kind")`, "name", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractDirectiveParam(tt.text, tt.param); got != tt.want {
+				t.Errorf("extractDirectiveParam(%q, %q) = %q, want %q", tt.text, tt.param, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDocCommentsAsDescriptions(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// User is a registered account.
+// It can sign in with an email.
+//
+//go:generate echo user
+// @gqlType
+// @gqlInput
+type User struct {
+	// Unique identifier
+	ID string
+	// Display name, shown publicly
+	Name  string ` + "`gql:\"name,description:\\\"Public name\\\"\"`" + `
+	Email string
+}
+
+// Post is ignored in favour of the explicit description
+// @gqlType(description:"A blog post")
+type Post struct {
+	Title string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+
+	gen := NewGenerator(p, cfg)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	schema := string(content)
+
+	for _, want := range []string{
+		"\"User is a registered account. It can sign in with an email.\"\ntype User {",
+		"\"User is a registered account. It can sign in with an email.\"\ninput UserInput {",
+		"  \"Unique identifier\"\n  id: String!",
+		"  \"Public name\"\n  name: String!",
+		"\"A blog post\"\ntype Post {",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain:\n%s\ngot:\n%s", want, schema)
+		}
+	}
+
+	for _, unwanted := range []string{"go:generate", "@gqlType", "Display name", "Post is ignored"} {
+		if strings.Contains(schema, unwanted) {
+			t.Errorf("Did not expect %q in schema, got:\n%s", unwanted, schema)
+		}
+	}
+	if !strings.Contains(schema, "name: String!\n  email: String!") {
+		t.Errorf("Did not expect a description for the undocumented email field, got:\n%s", schema)
+	}
+}
+
+func TestDisableDocDescriptions(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// User is a registered account.
+// @gqlType
+// @gqlInput
+type User struct {
+	// Unique identifier
+	ID   string
+	Name string ` + "`gql:\"name,description:\\\"Public name\\\"\"`" + `
+}
+
+// Post is ignored in favour of the explicit description
+// @gqlType(description:"A blog post")
+type Post struct {
+	Title string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.DisableDocDescriptions = true
+
+	files, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[cfg.Output]
+
+	// Explicit descriptions are kept
+	for _, want := range []string{
+		"\"A blog post\"\ntype Post {",
+		"  \"Public name\"\n  name: String!",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain:\n%s\ngot:\n%s", want, schema)
+		}
+	}
+
+	for _, unwanted := range []string{"registered account", "Unique identifier", "Post is ignored"} {
+		if strings.Contains(schema, unwanted) {
+			t.Errorf("Did not expect %q in schema, got:\n%s", unwanted, schema)
+		}
+	}
+}
+
+func TestEnumDocCommentsAsDescriptions(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// Status is the lifecycle state of an order.
+// Orders start pending and end delivered
+// or cancelled.
+// @gqlEnum
+type Status string
+
+const (
+	// Waiting for payment
+	StatusPending Status = "pending"
+	// Paid and on its way,
+	// tracked by the carrier
+	StatusShipped   Status = "shipped"
+	StatusDelivered Status = "delivered" // Handed to the customer
+	// Ignored in favour of the explicit description
+	StatusCancelled Status = "cancelled" // @gqlEnumValue(description:"Cancelled by anyone")
+)
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+	p.MatchEnumConstants()
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+
+	files, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[cfg.Output]
+
+	for _, want := range []string{
+		"\"Status is the lifecycle state of an order. Orders start pending and end delivered or cancelled.\"\nenum Status {",
+		"  \"Waiting for payment\"\n  PENDING",
+		"  \"Paid and on its way, tracked by the carrier\"\n  SHIPPED",
+		"  \"Handed to the customer\"\n  DELIVERED",
+		"  \"Cancelled by anyone\"\n  CANCELLED",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain:\n%s\ngot:\n%s", want, schema)
+		}
+	}
+	if strings.Contains(schema, "Ignored in favour") || strings.Contains(schema, "@gqlEnum") {
+		t.Errorf("Did not expect directives or overridden comments in schema, got:\n%s", schema)
+	}
+}
+
+func TestFormatDescription(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		indent string
+		want   string
+	}{
+		{
+			name: "single line is inline",
+			text: "A registered account",
+			want: "\"A registered account\"\n",
+		},
+		{
+			name:   "single line keeps the indent",
+			text:   "Unique identifier",
+			indent: "  ",
+			want:   "  \"Unique identifier\"\n",
+		},
+		{
+			name: "quotes are escaped inline",
+			text: `Use "id" or \ slug`,
+			want: "\"Use \\\"id\\\" or \\\\ slug\"\n",
+		},
+		{
+			name:   "multi-line is a block",
+			text:   "First line\n\nThird line",
+			indent: "  ",
+			want:   "  \"\"\"\n  First line\n\n  Third line\n  \"\"\"\n",
+		},
+		{
+			name: "triple quotes are escaped in blocks",
+			text: "Wraps \"\"\"\nraw",
+			want: "\"\"\"\nWraps \\\"\"\"\nraw\n\"\"\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatDescription(tt.text, tt.indent); got != tt.want {
+				t.Errorf("formatDescription(%q, %q) = %q, want %q", tt.text, tt.indent, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// captureStdout runs fn and returns what it printed to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	fn()
+	os.Stdout = stdout
+	_ = w.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read stdout: %v", err)
+	}
+	return string(out)
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	oldContent := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	newContent := "a\nb\nc\nd\nE\nf\ng\nh\ni\nj\nk\n"
+
+	expected := "--- old\n+++ new\n" +
+		"@@ -2,9 +2,10 @@\n b\n c\n d\n-e\n+E\n f\n g\n h\n i\n j\n+k\n"
+	if diff := UnifiedDiff("old", "new", oldContent, newContent); diff != expected {
+		t.Errorf("Unexpected diff:\n%s\nexpected:\n%s", diff, expected)
+	}
+
+	if diff := UnifiedDiff("old", "new", oldContent, oldContent); diff != "" {
+		t.Errorf("Expected no diff for equal content, got:\n%s", diff)
+	}
+}
+
+func TestUnifiedDiffTooLarge(t *testing.T) {
+	var oldLines, newLines []string
+	for i := range 3000 {
+		oldLines = append(oldLines, "old "+strconv.Itoa(i))
+		newLines = append(newLines, "new "+strconv.Itoa(i))
+	}
+
+	// Changes past diffMaxCells are reported without allocating the LCS table
+	diff := UnifiedDiff("old", "new", strings.Join(oldLines, "\n"), strings.Join(newLines, "\n"))
+	if expected := "Files old and new differ\n"; diff != expected {
+		t.Errorf("Expected %q, got %q", expected, diff)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	srcFile := filepath.Join(tmpDir, "user.go")
+	writeSource := func(src string) {
+		if err := os.WriteFile(srcFile, []byte(src), 0644); err != nil {
+			t.Fatalf("Failed to write source: %v", err)
+		}
+	}
+	newConfig := func(dryRun bool) *Config {
+		cfg := NewConfig()
+		cfg.Packages = []string{tmpDir}
+		cfg.GenStrategy = GenStrategySingle
+		cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+		cfg.DryRun = dryRun
+		return cfg
+	}
+
+	writeSource("package models\n\n// @gqlType\ntype User struct {\n\tID string\n}\n")
+	output := newConfig(true).Output
+
+	// New file: reported with its size and not written
+	var err error
+	out := captureStdout(t, func() { err = Generate(newConfig(true)) })
+	if !errors.Is(err, ErrSchemaChanged) {
+		t.Errorf("Expected ErrSchemaChanged for a new file, got %v", err)
+	}
+	if !strings.Contains(out, "would create "+output+" (") {
+		t.Errorf("Expected new file to be reported, got:\n%s", out)
+	}
+	if FileExists(output) {
+		t.Fatal("Dry run must not write files")
+	}
+
+	// Up to date: no error
+	if err := Generate(newConfig(false)); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	out = captureStdout(t, func() { err = Generate(newConfig(true)) })
+	if err != nil {
+		t.Errorf("Expected no error when the schema is up to date, got %v", err)
+	}
+	if !strings.Contains(out, "unchanged "+output) {
+		t.Errorf("Expected file to be reported unchanged, got:\n%s", out)
+	}
+
+	// Changed: unified diff against the existing file, which is left untouched
+	before, _ := os.ReadFile(output)
+	writeSource("package models\n\n// @gqlType\ntype User struct {\n\tID   string\n\tName string\n}\n")
+	out = captureStdout(t, func() { err = Generate(newConfig(true)) })
+	if !errors.Is(err, ErrSchemaChanged) {
+		t.Errorf("Expected ErrSchemaChanged for a changed file, got %v", err)
+	}
+	if !strings.Contains(out, "would update "+output) || !strings.Contains(out, "+  name: String!") {
+		t.Errorf("Expected a diff adding the name field, got:\n%s", out)
+	}
+	after, _ := os.ReadFile(output)
+	if string(before) != string(after) {
+		t.Error("Dry run must not modify existing files")
+	}
+}
+
+func TestCheckOnly(t *testing.T) {
+	tmpDir := t.TempDir()
+	srcFile := filepath.Join(tmpDir, "user.go")
+	writeSource := func(src string) {
+		if err := os.WriteFile(srcFile, []byte(src), 0644); err != nil {
+			t.Fatalf("Failed to write source: %v", err)
+		}
+	}
+	newConfig := func(strategy GenStrategy, check bool) *Config {
+		cfg := NewConfig()
+		cfg.Packages = []string{tmpDir}
+		cfg.GenStrategy = strategy
+		cfg.Output = filepath.Join(tmpDir, "schema")
+		cfg.CheckOnly = check
+		return cfg
+	}
+
+	writeSource("package models\n\n// @gqlType\ntype User struct {\n\tID string\n}\n")
+
+	// Missing file: stale and not written
+	var err error
+	out := captureStdout(t, func() { err = Generate(newConfig(GenStrategyPackage, true)) })
+	if !errors.Is(err, ErrSchemaChanged) {
+		t.Errorf("Expected ErrSchemaChanged for a missing file, got %v", err)
+	}
+	output := filepath.Join(tmpDir, "schema", "models.graphqls")
+	if !strings.Contains(out, "  "+output+"\n") {
+		t.Errorf("Expected missing file to be listed as stale, got:\n%s", out)
+	}
+	if FileExists(output) {
+		t.Fatal("Check must not write files")
+	}
+
+	// Current file: no error, even with CRLF line endings and trailing whitespace
+	if err := Generate(newConfig(GenStrategyPackage, false)); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	written, _ := os.ReadFile(output)
+	crlf := strings.ReplaceAll(string(written), "\n", "  \r\n")
+	if err := os.WriteFile(output, []byte(crlf), 0644); err != nil {
+		t.Fatalf("Failed to rewrite output: %v", err)
+	}
+	out = captureStdout(t, func() { err = Generate(newConfig(GenStrategyPackage, true)) })
+	if err != nil {
+		t.Errorf("Expected no error when the schema is current, got %v\n%s", err, out)
+	}
+
+	// Changed source: stale, and the existing file is left untouched
+	writeSource("package models\n\n// @gqlType\ntype User struct {\n\tID   string\n\tName string\n}\n")
+	out = captureStdout(t, func() { err = Generate(newConfig(GenStrategyPackage, true)) })
+	if !errors.Is(err, ErrSchemaChanged) {
+		t.Errorf("Expected ErrSchemaChanged for a changed file, got %v", err)
+	}
+	if !strings.Contains(out, "  "+output+"\n") {
+		t.Errorf("Expected changed file to be listed as stale, got:\n%s", out)
+	}
+	after, _ := os.ReadFile(output)
+	if string(after) != crlf {
+		t.Error("Check must not modify existing files")
+	}
+}
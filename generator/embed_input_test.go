@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestComposedInputs(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlInput
+type BaseInput struct {
+	Name     string  ` + "`gql:\"name\"`" + `
+	Nickname string  ` + "`gql:\"nickname,optional\"`" + `
+	Bio      string  ` + "`gql:\"bio,required\"`" + `
+	Slug     string  ` + "`gql:\"slug,ro\"`" + `
+}
+
+// @gqlInput(name:"AuditInput")
+// @gqlIgnoreAll
+type Audit struct {
+	Reason   string ` + "`gql:\"reason,include:*\"`" + `
+	Internal string
+}
+
+// @gqlInput(name:"UpdateUserInput")
+type UpdateUser struct {
+	BaseInput
+	Audit
+	ID string ` + "`gql:\"id,type:ID!\"`" + `
+}
+
+// @gqlInput(name:"CreatePostInput", embedInput:"BaseInput,AuditInput")
+type CreatePost struct {
+	Title string ` + "`gql:\"title\"`" + `
+	Name  int    ` + "`gql:\"name\"`" + `
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+
+	files, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[cfg.Output]
+
+	input := func(name string) string {
+		start := strings.Index(schema, "input "+name+" {")
+		if start == -1 {
+			t.Fatalf("Expected input %s, got:\n%s", name, schema)
+		}
+		return schema[start : start+strings.Index(schema[start:], "}")]
+	}
+
+	updateUser := input("UpdateUserInput")
+	for _, want := range []string{"  name: String!", "  nickname: String\n", "  bio: String!", "  reason: String!", "  id: ID!"} {
+		if !strings.Contains(updateUser, want) {
+			t.Errorf("Expected %q in UpdateUserInput, got:\n%s", want, updateUser)
+		}
+	}
+	// Read-only fields and the ignoreAll of the embedded input still apply
+	for _, unwanted := range []string{"slug", "internal"} {
+		if strings.Contains(updateUser, unwanted) {
+			t.Errorf("Did not expect %q in UpdateUserInput, got:\n%s", unwanted, updateUser)
+		}
+	}
+
+	createPost := input("CreatePostInput")
+	want := "  title: String!\n  name: Int!\n  nickname: String\n  bio: String!\n  reason: String!\n"
+	if !strings.HasSuffix(createPost, want) {
+		t.Errorf("Expected CreatePostInput to inline BaseInput and AuditInput after its own fields, got:\n%s", createPost)
+	}
+}
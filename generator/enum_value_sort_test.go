@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnumValueSortAlphabetical(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlEnum
+type Priority int
+
+const (
+	PriorityMedium Priority = iota
+	PriorityLow
+	PriorityUrgent // @gqlEnumValue(name:"CRITICAL")
+	PriorityHigh
+)
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	build := func(sort EnumValueSort) string {
+		p := NewParser()
+		if err := p.Walk(tmpDir); err != nil {
+			t.Fatalf("Walk() error = %v", err)
+		}
+		p.MatchEnumConstants()
+
+		cfg := NewConfig()
+		cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+		cfg.GenStrategy = GenStrategySingle
+		cfg.UseGqlGenDirectives = true
+		cfg.ModelPath = "example.com/app/models"
+		cfg.EnumValueSort = sort
+
+		files, err := NewGenerator(p, cfg).Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		return schemaBlock(files[cfg.Output], "enum Priority")
+	}
+
+	valueOrder := func(block string) []string {
+		var names []string
+		for _, line := range strings.Split(block, "\n")[1:] {
+			if fields := strings.Fields(line); len(fields) > 0 && fields[0] != "}" {
+				names = append(names, fields[0])
+			}
+		}
+		return names
+	}
+
+	if got := strings.Join(valueOrder(build(EnumValueSortDeclaration)), ","); got != "MEDIUM,LOW,CRITICAL,HIGH" {
+		t.Errorf("Expected declaration order, got %s", got)
+	}
+
+	sorted := build(EnumValueSortAlphabetical)
+	if got := strings.Join(valueOrder(sorted), ","); got != "CRITICAL,HIGH,LOW,MEDIUM" {
+		t.Errorf("Expected alphabetical order, got %s:\n%s", got, sorted)
+	}
+
+	// Every value still maps to its own Go const
+	for _, want := range []string{
+		`CRITICAL @goEnum(value: "example.com/app/models.PriorityUrgent")`,
+		`HIGH @goEnum(value: "example.com/app/models.PriorityHigh")`,
+		`MEDIUM @goEnum(value: "example.com/app/models.PriorityMedium")`,
+	} {
+		if !strings.Contains(sorted, want) {
+			t.Errorf("Expected %q, got:\n%s", want, sorted)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExcludePathsSkipFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"models.go": `package models
+
+// @gqlType
+type User struct {
+	ID     string
+	Status Status
+}
+
+// @gqlEnum
+type Status string
+
+const (
+	StatusActive Status = "active"
+)
+`,
+		"status_gen.go": `package models
+
+const (
+	StatusGenerated Status = "generated"
+)
+`,
+		"mocks/mock_user.go": `package mocks
+
+// @gqlType
+type MockUser struct {
+	ID string
+}
+`,
+		// Never parsed, so its syntax error must not fail the walk
+		"internal/testdata/broken.go": `package testdata
+
+type Broken struct {
+`,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	p := NewParser()
+	p.ExcludePaths = []string{"**/mock_*.go", "*_gen.go", "internal/testdata/**"}
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	p.MatchEnumConstants()
+
+	if _, ok := p.StructTypes["User"]; !ok {
+		t.Error("Expected User to be scanned")
+	}
+	if _, ok := p.StructTypes["MockUser"]; ok {
+		t.Error("Expected mocks/mock_user.go to be excluded")
+	}
+
+	// Excluded files contribute no enum constants
+	status := p.EnumTypes["Status"]
+	if status == nil {
+		t.Fatal("Expected Status enum to be scanned")
+	}
+	if len(status.Values) != 1 || status.Values[0].GoName != "StatusActive" {
+		t.Errorf("Expected only StatusActive, got %+v", status.Values)
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"**/mock_*.go", "mock_user.go", true},
+		{"**/mock_*.go", "a/b/mock_user.go", true},
+		{"internal/testdata/**", "internal/testdata", true},
+		{"internal/testdata/**", "internal/testdata/x/y.go", true},
+		{"internal/testdata/**", "internal/other/y.go", false},
+		{"*_gen.go", "models/user_gen.go", false},
+		{"models/*.go", "models/user.go", true},
+		{"models/*.go", "models/sub/user.go", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExcludeTypesSkipsStdlibTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+import "sync"
+
+// @gqlType
+type Counter struct {
+	sync.Mutex
+	Count int
+	Lock  sync.RWMutex
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "counter.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.AutoGenerate.OutOfScopeTypes = OutOfScopeExclude
+
+	gen := NewGenerator(p, cfg)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	schema := string(content)
+
+	if !strings.Contains(schema, "count: Int!") {
+		t.Errorf("Expected count field, got:\n%s", schema)
+	}
+	for _, unexpected := range []string{"Mutex", "RWMutex", "lock:", "state:"} {
+		if strings.Contains(schema, unexpected) {
+			t.Errorf("Schema should not contain %q, got:\n%s", unexpected, schema)
+		}
+	}
+}
+
+func TestExcludeTypesCustomList(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlType
+type Internal struct {
+	Secret string
+}
+
+// @gqlType
+type User struct {
+	Name     string
+	Internal *Internal
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "user.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.ExcludeTypes = []string{"Internal"}
+	cfg.AutoGenerate.OutOfScopeTypes = OutOfScopeExclude
+
+	gen := NewGenerator(p, cfg)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	schema := string(content)
+
+	if strings.Contains(schema, "internal:") {
+		t.Errorf("Field referencing excluded type should be dropped, got:\n%s", schema)
+	}
+	if !strings.Contains(schema, "name: String!") {
+		t.Errorf("Expected name field, got:\n%s", schema)
+	}
+}
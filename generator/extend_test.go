@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const extendSource = `package models
+
+// @gqlType(name:"Query")
+type Query struct {
+	Version string
+}
+
+// QueryExtension adds the user queries
+// @gqlType(name:"Query", extend:true)
+// @gqlTypeExtraField(name:"users",type:"[String!]!")
+type QueryExtension struct {
+	Me string
+}
+
+// @gqlInput(name:"UserFilter")
+type UserFilter struct {
+	Name string
+}
+
+// UserFilterExtension adds filtering by email
+// @gqlInput(name:"UserFilter", extend:true)
+type UserFilterExtension struct {
+	Email string
+}
+`
+
+func TestExtendTypeAndInput(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(extendSource), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.UseGqlGenDirectives = true
+
+	// Extensions share their name with the base definition without being a collision
+	fileContents, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	var b strings.Builder
+	for _, content := range fileContents {
+		b.WriteString(content)
+	}
+	schema := b.String()
+
+	for _, want := range []string{
+		"type Query @goModel(",
+		"extend type Query {\n  me: String!\n",
+		"users: [String!]!",
+		"input UserFilter @goModel(",
+		"extend input UserFilter {\n  email: String!\n",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain:\n%s\ngot:\n%s", want, schema)
+		}
+	}
+
+	// Extensions take @goModel from the base definition and cannot carry a description
+	for _, unwanted := range []string{"QueryExtension", "UserFilterExtension", "adds the user queries", "filtering by email"} {
+		if strings.Contains(schema, unwanted) {
+			t.Errorf("Did not expect schema to contain %q, got:\n%s", unwanted, schema)
+		}
+	}
+}
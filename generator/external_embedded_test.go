@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeExternalEmbeddedModule writes a module whose models package embeds a struct from a
+// sibling package (base) that is not in the scan set
+func writeExternalEmbeddedModule(t *testing.T) string {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.24\n",
+		"base/model.go": `package base
+
+type Model struct {
+	ID        string
+	CreatedBy Author
+	Audit
+}
+
+type Audit struct {
+	Version int
+}
+
+type Author struct {
+	Name string
+}
+`,
+		"models/post.go": `package models
+
+import "example.com/app/base"
+
+// @gqlType
+// @gqlInput
+type Post struct {
+	base.Model
+	Title string
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return tmpDir
+}
+
+func TestEmbeddedExternalStruct(t *testing.T) {
+	tmpDir := writeExternalEmbeddedModule(t)
+
+	p := NewParser()
+	if err := p.Walk(filepath.Join(tmpDir, "models")); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.AutoGenerate.Enabled = true
+	cfg.AutoGenerate.Strategy = AutoGenReferenced
+	cfg.AutoGenerate.OutOfScopeTypes = OutOfScopeFail
+
+	files, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[cfg.Output]
+
+	post := schemaBlock(schema, "type Post")
+	for _, want := range []string{"id: String!", "createdBy: Author!", "version: Int!", "title: String!"} {
+		if !strings.Contains(post, want) {
+			t.Errorf("Expected %q in Post, got:\n%s", want, post)
+		}
+	}
+	postInput := schemaBlock(schema, "input PostInput")
+	for _, want := range []string{"id: String!", "version: Int!", "title: String!"} {
+		if !strings.Contains(postInput, want) {
+			t.Errorf("Expected %q in PostInput, got:\n%s", want, postInput)
+		}
+	}
+
+	// Types the embedded struct references are auto-generated, the embedded struct is only inlined
+	if !strings.Contains(schema, "type Author {") {
+		t.Errorf("Expected Author to be auto-generated, got:\n%s", schema)
+	}
+	if strings.Contains(schema, "type Model {") {
+		t.Errorf("Expected Model to be inlined only, got:\n%s", schema)
+	}
+}
+
+func TestEmbeddedExternalStructDisabled(t *testing.T) {
+	tmpDir := writeExternalEmbeddedModule(t)
+
+	p := NewParser()
+	if err := p.Walk(filepath.Join(tmpDir, "models")); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.AutoGenerate.IncludeEmbedded = false
+	cfg.AutoGenerate.OutOfScopeTypes = OutOfScopeFail
+
+	// Without include_embedded the package is not loaded and the embedded fields are dropped
+	_, err := NewGenerator(p, cfg).Build()
+	if err == nil {
+		t.Fatal("Expected an error for the dropped embedded type")
+	}
+	if !strings.Contains(err.Error(), "Post (embedded, its fields were dropped)") {
+		t.Errorf("Expected the embedded Model to be reported, got:\n%s", err.Error())
+	}
+}
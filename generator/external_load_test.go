@@ -0,0 +1,184 @@
+package generator
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// countPackageLoads counts the packages.Load calls made until the test ends
+func countPackageLoads(tb testing.TB) *int {
+	tb.Helper()
+	original := loadPackages
+	count := 0
+	loadPackages = func(cfg *packages.Config, patterns ...string) ([]*packages.Package, error) {
+		count++
+		return original(cfg, patterns...)
+	}
+	tb.Cleanup(func() { loadPackages = original })
+	return &count
+}
+
+// writeExternalTypesModule writes a module whose scanned models package references several
+// types of a package (ext) that is not in the scan set
+func writeExternalTypesModule(tb testing.TB) string {
+	tb.Helper()
+	tmpDir := tb.TempDir()
+
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.24\n",
+		"ext/types.go": `package ext
+
+// @gqlType
+type Author struct {
+	Name string
+}
+
+// @gqlType
+type Tag struct {
+	Label string
+}
+
+// @gqlType
+type Category struct {
+	Title string
+}
+
+// @gqlEnum
+type Level string
+
+const (
+	LevelLow  Level = "low"
+	LevelHigh Level = "high"
+)
+
+type Plain struct {
+	Value string
+}
+`,
+		"models/post.go": `package models
+
+import "example.com/app/ext"
+
+// @gqlType
+type Post struct {
+	Author   ext.Author
+	Tags     []ext.Tag
+	Category *ext.Category
+	Level    ext.Level
+	Plain    ext.Plain
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			tb.Fatalf("Failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			tb.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return tmpDir
+}
+
+// checkPostFields calls HasGQLAnnotations for every field of Post and returns the results by field name
+func checkPostFields(tb testing.TB, p *Parser) map[string]bool {
+	tb.Helper()
+	post, ok := p.StructTypes["Post"]
+	if !ok {
+		tb.Fatal("Post was not parsed")
+	}
+	results := make(map[string]bool)
+	for _, field := range post.Type.(*ast.StructType).Fields.List {
+		results[field.Names[0].Name] = p.HasGQLAnnotations(field.Type, "Post")
+	}
+	return results
+}
+
+func TestExternalPackageLoadedOnce(t *testing.T) {
+	tmpDir := writeExternalTypesModule(t)
+
+	p := NewParser()
+	if err := p.Walk(filepath.Join(tmpDir, "models")); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	loads := countPackageLoads(t)
+	results := checkPostFields(t, p)
+
+	// All five fields reference ext, which is loaded and registered a single time
+	if *loads != 1 {
+		t.Errorf("Expected 1 packages.Load call for the external package, got %d", *loads)
+	}
+	want := map[string]bool{"Author": true, "Tags": true, "Category": true, "Level": true, "Plain": false}
+	for field, annotated := range want {
+		if results[field] != annotated {
+			t.Errorf("HasGQLAnnotations(%s) = %v, want %v", field, results[field], annotated)
+		}
+	}
+
+	// Registering the package once keeps its const blocks from being collected again
+	if len(p.constBlocks) != 1 {
+		t.Errorf("Expected the const block of ext to be collected once, got %d blocks", len(p.constBlocks))
+	}
+	p.MatchEnumConstants()
+	if level := p.EnumTypes["Level"]; level == nil || len(level.Values) != 2 {
+		t.Errorf("Expected Level with 2 values, got %+v", level)
+	}
+}
+
+func TestExternalPackageExcludePaths(t *testing.T) {
+	tmpDir := writeExternalTypesModule(t)
+	mock := `package ext
+
+// @gqlType
+type MockTag struct {
+	Label string
+}
+
+const (
+	LevelMock Level = "mock"
+)
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "ext", "mock_tag.go"), []byte(mock), 0644); err != nil {
+		t.Fatalf("Failed to write mock_tag.go: %v", err)
+	}
+
+	p := NewParser()
+	p.ExcludePaths = []string{"**/mock_*.go"}
+	if err := p.Walk(filepath.Join(tmpDir, "models")); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	if results := checkPostFields(t, p); !results["Author"] {
+		t.Error("Expected the other files of ext to be registered")
+	}
+
+	// Files of on-demand loaded packages are excluded like scanned ones, consts included
+	if _, ok := p.StructTypes["MockTag"]; ok {
+		t.Error("Expected ext/mock_tag.go to be excluded")
+	}
+	p.MatchEnumConstants()
+	if level := p.EnumTypes["Level"]; level == nil || len(level.Values) != 2 {
+		t.Errorf("Expected Level without the excluded const, got %+v", level)
+	}
+}
+
+func BenchmarkExternalTypeLookups(b *testing.B) {
+	tmpDir := writeExternalTypesModule(b)
+	loads := countPackageLoads(b)
+
+	b.ResetTimer()
+	for range b.N {
+		p := NewParser()
+		if err := p.Walk(filepath.Join(tmpDir, "models")); err != nil {
+			b.Fatalf("Walk() error = %v", err)
+		}
+		checkPostFields(b, p)
+	}
+	// One load resolves the models import path, one loads ext for all of its types
+	b.ReportMetric(float64(*loads)/float64(b.N), "loads/op")
+}
@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func buildExtraFieldSchema(t *testing.T, src string) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.UseGqlGenDirectives = true
+
+	files, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return files[cfg.Output]
+}
+
+func TestInputExtraFieldDescriptionAndDeprecation(t *testing.T) {
+	schema := buildExtraFieldSchema(t, `package models
+
+// @gqlType
+// @gqlInput
+// @gqlInputExtraField(name:"password",type:"String!",description:"Plain text password")
+// @gqlInputExtraField(name:"legacyToken",type:"String",deprecated:"Use password instead")
+// @gqlTypeExtraField(name:"fullName",type:"String!",description:"Computed name",deprecated:true)
+type User struct {
+	Name string
+}
+`)
+
+	input := schemaBlock(schema, "input UserInput")
+	for _, want := range []string{
+		"  \"Plain text password\"\n  password: String! @goField(forceResolver: true)\n",
+		`legacyToken: String @goField(forceResolver: true) @deprecated(reason: "Use password instead")`,
+	} {
+		if !strings.Contains(input, want) {
+			t.Errorf("Expected %q in UserInput, got:\n%s", want, input)
+		}
+	}
+
+	// Type and input extra fields are emitted the same way
+	user := schemaBlock(schema, "type User")
+	want := "  \"Computed name\"\n  fullName: String! @goField(forceResolver: true) @deprecated\n"
+	if !strings.Contains(user, want) {
+		t.Errorf("Expected %q in User, got:\n%s", want, user)
+	}
+}
+
+func TestRequiredInputFieldNotDeprecated(t *testing.T) {
+	schema := buildExtraFieldSchema(t, `package models
+
+// @gqlInput
+// @gqlInputExtraField(name:"token",type:"String!",deprecated:"Use session")
+type Login struct {
+	Email string `+"`gql:\"email,deprecated:\\\"Use username\\\"\"`"+`
+	Retry int    `+"`gql:\"retry,default:3,deprecated\"`"+`
+	Hint  string `+"`gql:\"hint,optional,deprecated\"`"+`
+}
+`)
+
+	// Required input fields cannot be deprecated, the directive is skipped with a warning
+	input := schemaBlock(schema, "input LoginInput")
+	for _, line := range []string{"email: String!", "token: String!"} {
+		if !strings.Contains(input, line) {
+			t.Errorf("Expected %q in LoginInput, got:\n%s", line, input)
+		}
+	}
+	if strings.Contains(input, "Use username") || strings.Contains(input, "Use session") {
+		t.Errorf("Expected no @deprecated on required input fields, got:\n%s", input)
+	}
+
+	// Nullable fields and fields with a default value can still be deprecated
+	for _, want := range []string{"retry: Int! = 3 @deprecated", "hint: String @deprecated"} {
+		if !strings.Contains(input, want) {
+			t.Errorf("Expected %q in LoginInput, got:\n%s", want, input)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const federationSource = `package models
+
+// @gqlType
+// @gqlInput
+// @gqlKey(fields:"id")
+// @gqlKey(fields:"sku variant { id }")
+type Product struct {
+	ID   string ` + "`gql:\"id,type:ID!\"`" + `
+	SKU    string
+	Name   string ` + "`gql:\"name,shareable:true\"`" + `
+	Source string ` + "`gql:\"external\"`" + `
+}
+
+// User is owned by the accounts subgraph
+// @gqlType
+// @gqlExtend
+// @gqlKey(fields:"id")
+type User struct {
+	// @gqlExternal
+	ID      string ` + "`gql:\"id,type:ID!\"`" + `
+	Reviews []string
+}
+`
+
+func generateFederationSchema(t *testing.T, federation bool) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(federationSource), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.Federation = federation
+
+	fileContents, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	var schema strings.Builder
+	for _, content := range fileContents {
+		schema.WriteString(content)
+	}
+	return schema.String()
+}
+
+func TestFederationDirectives(t *testing.T) {
+	schema := generateFederationSchema(t, true)
+
+	for _, want := range []string{
+		"type Product @key(fields: \"id\") @key(fields: \"sku variant { id }\") {",
+		"  name: String! @shareable\n",
+		// A field named external is not the federation flag
+		"  external: String!\n",
+		"extend type User @key(fields: \"id\") {\n  id: ID! @external\n",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain:\n%s\ngot:\n%s", want, schema)
+		}
+	}
+
+	// Inputs never carry federation directives; extensions have no description
+	if strings.Contains(schema, "input ProductInput @key") || strings.Count(schema, "@shareable") != 1 {
+		t.Errorf("Did not expect federation directives on inputs, got:\n%s", schema)
+	}
+	if strings.Contains(schema, "accounts subgraph") {
+		t.Errorf("Did not expect a description on the type extension, got:\n%s", schema)
+	}
+}
+
+func TestFederationDisabled(t *testing.T) {
+	schema := generateFederationSchema(t, false)
+
+	for _, unwanted := range []string{"@key", "@external", "@shareable"} {
+		if strings.Contains(schema, unwanted) {
+			t.Errorf("Did not expect %q without federation enabled, got:\n%s", unwanted, schema)
+		}
+	}
+}
@@ -686,3 +686,137 @@ type Person struct {
 		}
 	}
 }
+
+func TestFieldWriteOnlyWithInputList(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFile := filepath.Join(tmpDir, "test.go")
+	testContent := `package test
+
+// @gqlInput(name:"CreateUserInput")
+// @gqlInput(name:"UpdateUserInput")
+type User struct {
+	Name     string
+	Password string ` + "`gql:\"password,wo:CreateUserInput\"`" + `
+}
+
+type Payload[T any] struct {
+	Data   T
+	Secret string ` + "`gql:\"secret,wo:CreatePayloadInput\"`" + `
+}
+
+// @gqlInput(name:"CreatePayloadInput")
+// @gqlInput(name:"UpdatePayloadInput")
+type StringPayload = Payload[string]
+`
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	parser := NewParser()
+	if err := parser.Walk(tmpDir); err != nil {
+		t.Fatalf("Parser walk failed: %v", err)
+	}
+
+	outFile := filepath.Join(tmpDir, "schema.graphqls")
+	cfg := &Config{
+		Packages:    []string{tmpDir},
+		Output:      outFile,
+		GenStrategy: GenStrategySingle,
+	}
+
+	files, err := NewGenerator(parser, cfg).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[outFile]
+
+	inputBody := func(name string) string {
+		start := strings.Index(schema, "input "+name+" ")
+		if start == -1 {
+			t.Fatalf("%s not found in schema:\n%s", name, schema)
+		}
+		end := strings.Index(schema[start:], "}")
+		return schema[start : start+end]
+	}
+
+	// Each input variant filters fields by its own name, not the first input's
+	tests := []struct {
+		input string
+		field string
+		want  bool
+	}{
+		{input: "CreateUserInput", field: "password", want: true},
+		{input: "UpdateUserInput", field: "password", want: false},
+		{input: "CreatePayloadInput", field: "secret", want: true},
+		{input: "UpdatePayloadInput", field: "secret", want: false},
+	}
+	for _, tt := range tests {
+		if got := strings.Contains(inputBody(tt.input), tt.field+":"); got != tt.want {
+			t.Errorf("%s contains %s = %v, want %v\n%s", tt.input, tt.field, got, tt.want, schema)
+		}
+	}
+}
+
+func TestFieldTypeOnlyInputOnlyAliases(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package test
+
+// @gqlType(name:"Admin")
+// @gqlType(name:"User")
+// @gqlInput(name:"UserInput")
+type Person struct {
+	ID        string ` + "`gql:\"id,typeOnly\"`" + `
+	Name      string
+	Password  string ` + "`gql:\"password,inputOnly\"`" + `
+	SecretKey string ` + "`gql:\"secretKey,typeOnly:Admin\"`" + `
+
+	// @gqlTypeOnly
+	CreatedAt string
+
+	// @gqlInputOnly
+	Token string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	parser := NewParser()
+	if err := parser.Walk(tmpDir); err != nil {
+		t.Fatalf("Parser walk failed: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+
+	files, err := NewGenerator(parser, cfg).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[cfg.Output]
+
+	tests := []struct {
+		header  string
+		want    []string
+		notWant []string
+	}{
+		{"type Admin {", []string{"id:", "name:", "secretKey:", "createdAt:"}, []string{"password:", "token:"}},
+		{"type User {", []string{"id:", "name:", "createdAt:"}, []string{"password:", "token:", "secretKey:"}},
+		{"input UserInput {", []string{"name:", "password:", "token:"}, []string{"id:", "secretKey:", "createdAt:"}},
+	}
+	for _, tt := range tests {
+		block := schemaBlock(schema, tt.header)
+		for _, field := range tt.want {
+			if !strings.Contains(block, field) {
+				t.Errorf("Expected %s in %s, got:\n%s", field, tt.header, block)
+			}
+		}
+		for _, field := range tt.notWant {
+			if strings.Contains(block, field) {
+				t.Errorf("Expected no %s in %s, got:\n%s", field, tt.header, block)
+			}
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFieldOrder(t *testing.T) {
+	src := `package models
+
+// @gqlType
+type User struct {
+	Name  string ` + "`json:\"c_name\" gql:\"name\"`" + `
+	Email string ` + "`json:\"a_email\" gql:\"email\"`" + `
+	Age   int    ` + "`json:\"d_age\" gql:\"age\"`" + `
+	Bio   string ` + "`json:\"b_bio\" gql:\"bio,optional\"`" + `
+	Base
+}
+
+type Base struct {
+	ID string ` + "`json:\"e_id\" gql:\"id\"`" + `
+}
+`
+
+	// The json tags sort differently from the GraphQL names, so every mode has its own order
+	tests := []struct {
+		name  string
+		order FieldOrder
+		want  []string
+	}{
+		{name: "source order", order: FieldOrderSource, want: []string{"name", "email", "age", "bio", "id"}},
+		{name: "alpha order", order: FieldOrderAlpha, want: []string{"age", "bio", "email", "id", "name"}},
+		{name: "json order", order: FieldOrderJSON, want: []string{"email", "bio", "name", "age", "id"}},
+		{name: "required first", order: FieldOrderRequiredFirst, want: []string{"age", "email", "id", "name", "bio"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "user.go"), []byte(src), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			p := NewParser()
+			if err := p.Walk(tmpDir); err != nil {
+				t.Fatalf("Walk() error = %v", err)
+			}
+
+			config := NewConfig()
+			config.Output = filepath.Join(tmpDir, "schema.graphqls")
+			config.GenStrategy = GenStrategySingle
+			config.FieldOrder = tt.order
+
+			gen := NewGenerator(p, config)
+			if err := gen.Run(); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			generated, err := os.ReadFile(config.Output)
+			if err != nil {
+				t.Fatalf("Failed to read generated file: %v", err)
+			}
+			content := string(generated)
+
+			last := -1
+			for _, field := range tt.want {
+				idx := strings.Index(content, "  "+field+":")
+				if idx == -1 {
+					t.Fatalf("Field %q not found in output:\n%s", field, content)
+				}
+				if idx < last {
+					t.Errorf("Field %q out of order, want %v:\n%s", field, tt.want, content)
+				}
+				last = idx
+			}
+		})
+	}
+}
+
+func TestFieldOrderJSONFallsBackToFieldName(t *testing.T) {
+	fields := []renderedField{
+		{Name: "zeta", JSONName: "zeta"},
+		{Name: "alpha", JSONName: "m_alpha"},
+		{Name: "beta", JSONName: "beta"},
+	}
+	sortRenderedFields(fields, FieldOrderJSON)
+
+	got := []string{fields[0].Name, fields[1].Name, fields[2].Name}
+	want := []string{"beta", "alpha", "zeta"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("sortRenderedFields(json) = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestTypeFieldOrderPinsFields(t *testing.T) {
+	src := `package models
+
+// @gqlType(name:"User", fieldOrder:"id,name,*")
+type User struct {
+	Email string
+	Name  string
+	Age   int
+	ID    string
+}
+`
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "user.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+
+	gen := NewGenerator(p, config)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	generated, err := os.ReadFile(config.Output)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	content := string(generated)
+
+	want := "type User {\n  id: String!\n  name: String!\n  email: String!\n  age: Int!\n}"
+	if !strings.Contains(content, want) {
+		t.Errorf("Expected pinned field order:\n%s\ngot:\n%s", want, content)
+	}
+}
+
+func TestApplyPinnedFieldOrder(t *testing.T) {
+	fields := []renderedField{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}}
+
+	tests := []struct {
+		name  string
+		order []string
+		want  []string
+	}{
+		{name: "no order", order: nil, want: []string{"a", "b", "c", "d"}},
+		{name: "pin first", order: []string{"c", "*"}, want: []string{"c", "a", "b", "d"}},
+		{name: "implicit rest", order: []string{"d", "b"}, want: []string{"d", "b", "a", "c"}},
+		{name: "pin last", order: []string{"*", "a"}, want: []string{"b", "c", "d", "a"}},
+		{name: "unknown names ignored", order: []string{"x", "b", "*"}, want: []string{"b", "a", "c", "d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := applyPinnedFieldOrder(append([]renderedField(nil), fields...), tt.order)
+			got := make([]string, len(result))
+			for i, f := range result {
+				got[i] = f.Name
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("applyPinnedFieldOrder(%v) = %v, want %v", tt.order, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuplicateEmbeddedFields(t *testing.T) {
+	src := `package models
+
+type Audit struct {
+	ID        string ` + "`gql:\"id,type:ID!\"`" + `
+	CreatedBy string
+}
+
+type Entity struct {
+	ID      int
+	Version int
+}
+
+// @gqlType
+type Order struct {
+	Audit
+	Total int
+	Entity
+	Note string
+}
+`
+
+	tests := []struct {
+		name   string
+		policy DuplicateFieldPolicy
+		want   string
+	}{
+		{
+			name:   "first writer",
+			policy: DuplicateFieldsFirst,
+			want:   "type Order {\n  id: ID!\n  createdBy: String!\n  total: Int!\n  version: Int!\n  note: String!\n}",
+		},
+		{
+			name:   "last writer",
+			policy: DuplicateFieldsLast,
+			want:   "type Order {\n  id: Int!\n  createdBy: String!\n  total: Int!\n  version: Int!\n  note: String!\n}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "order.go"), []byte(src), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			p := NewParser()
+			if err := p.Walk(tmpDir); err != nil {
+				t.Fatalf("Walk() error = %v", err)
+			}
+
+			config := NewConfig()
+			config.Output = filepath.Join(tmpDir, "schema.graphqls")
+			config.GenStrategy = GenStrategySingle
+			config.DuplicateFields = tt.policy
+
+			files, err := NewGenerator(p, config).Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			// Embedded fields keep the position of their embed, and id is emitted once
+			if content := files[config.Output]; !strings.Contains(content, tt.want) {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.want, content)
+			}
+		})
+	}
+}
+
+func TestDuplicateFieldsPreferOwnFields(t *testing.T) {
+	src := `package models
+
+type Base struct {
+	ID   int
+	Name string
+}
+
+// @gqlType
+type Account struct {
+	Base
+	Email string
+	ID    string ` + "`gql:\"id,type:ID!\"`" + `
+}
+`
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "account.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+
+	files, err := NewGenerator(p, config).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	// The own ID shadows the embedded one although it is declared after the embed
+	want := "type Account {\n  id: ID!\n  name: String!\n  email: String!\n}"
+	if content := files[config.Output]; !strings.Contains(content, want) {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, content)
+	}
+}
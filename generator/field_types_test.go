@@ -0,0 +1,191 @@
+package generator
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestResolveFieldTypeMatchesRenderer checks that the dependency graph and the field renderer
+// resolve nested field types to the same GraphQL type, and that every object type the rendered
+// field uses is a reference auto-generation follows
+func TestResolveFieldTypeMatchesRenderer(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+type Tag struct {
+	Name string
+}
+
+type Point struct {
+	X float64
+}
+
+type Money struct {
+	Cents int64
+}
+
+type Meta struct {
+	Key string
+}
+
+type Comment struct {
+	Body string
+}
+
+type Edge[T any] struct {
+	Node   T
+	Cursor string
+}
+
+type Holder struct {
+	PtrSlice       *[]Tag
+	PtrSlicePtrs   *[]*Tag
+	Matrix         [][]Point
+	PtrMatrix      *[][]*Point
+	SliceOfPtrList []*[]Point
+	Fixed          [2][]*Point
+	Strings        *[]*[]string
+	Edges          []*Edge[*Comment]
+	Price          *[]Money
+	Labels         map[string]Meta
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	config := NewConfig()
+	config.Normalize()
+	config.Scalars = map[string]ScalarMapping{"Money": {Model: []string{p.PackagePaths["Money"] + ".Money"}}}
+	gen := NewGenerator(p, config)
+
+	tests := []struct {
+		field    string
+		wantType string
+		wantRefs []string
+	}{
+		{field: "PtrSlice", wantType: "[Tag!]", wantRefs: []string{"Tag"}},
+		{field: "PtrSlicePtrs", wantType: "[Tag!]", wantRefs: []string{"Tag"}},
+		{field: "Matrix", wantType: "[[Point!]!]!", wantRefs: []string{"Point"}},
+		{field: "PtrMatrix", wantType: "[[Point!]!]", wantRefs: []string{"Point"}},
+		{field: "SliceOfPtrList", wantType: "[[Point!]]!", wantRefs: []string{"Point"}},
+		{field: "Fixed", wantType: "[[Point!]!]!", wantRefs: []string{"Point"}},
+		{field: "Strings", wantType: "[[String!]]", wantRefs: nil},
+		{field: "Edges", wantType: "[CommentEdge!]!", wantRefs: []string{"Edge", "Comment"}},
+		{field: "Price", wantType: "[Money!]", wantRefs: nil},
+		{field: "Labels", wantType: "Map!", wantRefs: nil},
+	}
+
+	fields := map[string]int{}
+	for i, f := range p.StructTypes["Holder"].Type.(*ast.StructType).Fields.List {
+		fields[f.Names[0].Name] = i
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			field := p.StructTypes["Holder"].Type.(*ast.StructType).Fields.List[fields[tt.field]]
+
+			gotType, gotRefs := gen.resolveFieldType(field.Type)
+			if gotType != tt.wantType {
+				t.Errorf("resolveFieldType() type = %q, want %q", gotType, tt.wantType)
+			}
+			if !slices.Equal(gotRefs, tt.wantRefs) {
+				t.Errorf("resolveFieldType() refs = %v, want %v", gotRefs, tt.wantRefs)
+			}
+			if graphRefs := gen.fieldReferences(field); !slices.Equal(graphRefs, tt.wantRefs) {
+				t.Errorf("fieldReferences() = %v, want %v", graphRefs, tt.wantRefs)
+			}
+
+			// The renderer resolves to the same type
+			if rendered := ExprToGraphQLTypeWithContext(field.Type, config, nil, gen); rendered != gotType {
+				t.Errorf("ExprToGraphQLTypeWithContext() = %q, resolveFieldType() = %q", rendered, gotType)
+			}
+		})
+	}
+
+	// Resolving for the dependency graph never tracks generic instantiations
+	if len(gen.GenericInstantiations) != 1 {
+		t.Errorf("Expected only the rendered CommentEdge instantiation, got %v", gen.GenericInstantiations)
+	}
+}
+
+// TestAutoGenerateNestedFieldTypes checks that auto-generation generates exactly the types nested
+// field types render with: no field references a missing type and no unused type is generated
+func TestAutoGenerateNestedFieldTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+type Tag struct {
+	Name string
+}
+
+type Point struct {
+	X float64
+}
+
+type Meta struct {
+	Key string
+}
+
+type Price struct {
+	Amount float64
+}
+
+// @gqlType
+type Holder struct {
+	Tags    *[]*Tag
+	Matrix  *[][]*Point
+	Labels  map[string]Meta
+	Prices  map[string]*Price ` + "`gql:\"prices,pairs\"`" + `
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+	config.AutoGenerate.Enabled = true
+	config.AutoGenerate.Strategy = AutoGenReferenced
+	config.AutoGenerate.OutOfScopeTypes = OutOfScopeFail
+
+	files, err := NewGenerator(p, config).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[config.Output]
+
+	for _, want := range []string{
+		"  tags: [Tag!]\n",
+		"  matrix: [[Point!]!]\n",
+		"  labels: Map!\n",
+		"  prices: [StringPricePair!]!\n",
+		"type Tag {",
+		"type Point {",
+		"type Price {",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected %q in schema, got:\n%s", want, schema)
+		}
+	}
+
+	// The map value is only used as a pair, a plain map renders as the Map scalar
+	if strings.Contains(schema, "type Meta") {
+		t.Errorf("Expected Meta not to be generated for a map scalar field, got:\n%s", schema)
+	}
+}
@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileDirective(t *testing.T) {
+	src := `package models
+
+// @gqlType
+// @gqlInput(name:"LoginInput")
+// @gqlFile(name:"auth.graphqls")
+type Session struct {
+	Token string ` + "`gql:\"token\"`" + `
+}
+
+// @gqlType(namespace:"accounts")
+// @gqlFile(name:"auth")
+type Credential struct {
+	Key string ` + "`gql:\"key\"`" + `
+}
+
+// @gqlType
+type User struct {
+	Name string ` + "`gql:\"name\"`" + `
+}
+`
+	// A file-level namespace switches single and package to the namespace strategies
+	namespaced := strings.Replace(src, "package models\n", "package models\n\n// @gqlNamespace(name:\"api\")\n", 1)
+
+	tests := []struct {
+		name     string
+		src      string
+		strategy GenStrategy
+	}{
+		{"single", src, GenStrategySingle},
+		{"multiple", src, GenStrategyMultiple},
+		{"package", src, GenStrategyPackage},
+		{"namespace", namespaced, GenStrategySingle},
+		{"namespace and package", namespaced, GenStrategyPackage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(tt.src), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			p := NewParser()
+			if err := p.Walk(tmpDir); err != nil {
+				t.Fatalf("Walk() error = %v", err)
+			}
+
+			config := NewConfig()
+			config.Output = filepath.Join(tmpDir, "schema")
+			config.GenStrategy = tt.strategy
+
+			files, err := NewGenerator(p, config).Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			authFile := filepath.Join(config.Output, "auth.graphqls")
+			auth, ok := files[authFile]
+			if !ok {
+				t.Fatalf("Expected %s, got files %v", authFile, sortedKeys(files))
+			}
+			// @gqlFile takes precedence over the type's namespace
+			for _, want := range []string{"type Session {", "input LoginInput {", "type Credential {"} {
+				if strings.Count(auth, want) != 1 {
+					t.Errorf("Expected %q once in auth.graphqls, got:\n%s", want, auth)
+				}
+			}
+
+			userFound := false
+			for outFile, content := range files {
+				if outFile == authFile {
+					continue
+				}
+				if strings.Contains(content, "Session") || strings.Contains(content, "LoginInput") || strings.Contains(content, "Credential") {
+					t.Errorf("Expected routed definitions only in auth.graphqls, found in %s:\n%s", outFile, content)
+				}
+				userFound = userFound || strings.Contains(content, "type User {")
+			}
+			if !userFound {
+				t.Errorf("Expected User in the strategy's file, got files %v", sortedKeys(files))
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFixedSizeArrays(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+type Point struct {
+	X float64 ` + "`gql:\"x\"`" + `
+	Y float64 ` + "`gql:\"y\"`" + `
+}
+
+// @gqlType
+// @gqlInput
+type Shape struct {
+	Labels  [3]string     ` + "`gql:\"labels\"`" + `
+	Corners [2]*Point     ` + "`gql:\"corners\"`" + `
+	Vector  [3]float64    ` + "`gql:\"vector\"`" + `
+	Matrix  [2][2]float64 ` + "`gql:\"matrix\"`" + `
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "shape.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+	config.AutoGenerate.OutOfScopeTypes = OutOfScopeFail
+
+	files, err := NewGenerator(p, config).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[config.Output]
+
+	for _, want := range []string{
+		"  labels: [String!]!\n",
+		"  vector: [Float!]!\n",
+		"  matrix: [[Float!]!]!\n",
+	} {
+		if strings.Count(schema, want) != 2 {
+			t.Errorf("Expected %q in both the type and the input, got:\n%s", want, schema)
+		}
+	}
+	for _, want := range []string{"  corners: [Point!]!\n", "  corners: [PointInput!]!\n"} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected %q, got:\n%s", want, schema)
+		}
+	}
+
+	// The element type of a fixed array is auto-generated like a slice element
+	for _, want := range []string{"type Point {", "input PointInput {"} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected %q to be auto-generated, got:\n%s", want, schema)
+		}
+	}
+}
+
+func TestExprToGoTypeFixedArray(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := "package models\n\ntype Shape struct {\n\tCorners [2]*Point\n}\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "shape.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	st := p.StructTypes["Shape"].Type.(*ast.StructType)
+	if got := ExprToGoType(st.Fields.List[0].Type); got != "[2]*Point" {
+		t.Errorf("ExprToGoType() = %q, want %q", got, "[2]*Point")
+	}
+}
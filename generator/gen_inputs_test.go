@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenInputs(t *testing.T) {
+	src := `package models
+
+type Address struct {
+	City string ` + "`gql:\"city\"`" + `
+}
+
+// @gqlType
+type User struct {
+	ID      string  ` + "`gql:\"id,type:ID,ro\"`" + `
+	Name    string  ` + "`gql:\"name\"`" + `
+	Address Address ` + "`gql:\"address\"`" + `
+}
+
+// @gqlType
+// @gqlInput(name:"NewPost")
+type Post struct {
+	Title string ` + "`gql:\"title\"`" + `
+}
+
+// @gqlType(name:"Query", extend:true)
+type UserQueries struct {
+	Me User ` + "`gql:\"me\"`" + `
+}
+`
+
+	generate := func(t *testing.T, genInputs bool) string {
+		t.Helper()
+		tmpDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+
+		p := NewParser()
+		if err := p.Walk(tmpDir); err != nil {
+			t.Fatalf("Walk() error = %v", err)
+		}
+
+		config := NewConfig()
+		config.Output = filepath.Join(tmpDir, "schema.graphqls")
+		config.GenStrategy = GenStrategySingle
+		config.GenInputs = genInputs
+		config.AddInputPrefix = "Gql"
+
+		files, err := NewGenerator(p, config).Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		return files[config.Output]
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		schema := generate(t, true)
+
+		if !strings.Contains(schema, "type User {") {
+			t.Errorf("Expected type User, got:\n%s", schema)
+		}
+		start := strings.Index(schema, "input GqlUserInput {")
+		if start == -1 {
+			t.Fatalf("Expected input GqlUserInput, got:\n%s", schema)
+		}
+		input := schema[start : start+strings.Index(schema[start:], "}")]
+		if strings.Contains(input, "id:") {
+			t.Errorf("Expected read-only id to be excluded from the input, got:\n%s", input)
+		}
+		for _, want := range []string{"  name: String!", "  address: GqlAddressInput!"} {
+			if !strings.Contains(input, want) {
+				t.Errorf("Expected %q in the input, got:\n%s", want, input)
+			}
+		}
+		// Referenced types get an input too when auto-generation is on
+		if !strings.Contains(schema, "input GqlAddressInput {") {
+			t.Errorf("Expected input GqlAddressInput, got:\n%s", schema)
+		}
+
+		// Explicit @gqlInput wins and extensions get no input
+		if strings.Contains(schema, "GqlPostInput") || !strings.Contains(schema, "input NewPost {") {
+			t.Errorf("Expected only the explicit NewPost input for Post, got:\n%s", schema)
+		}
+		if strings.Contains(schema, "UserQueriesInput") || strings.Contains(schema, "QueryInput") {
+			t.Errorf("Expected no input for the Query extension, got:\n%s", schema)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		schema := generate(t, false)
+
+		if strings.Contains(schema, "GqlUserInput") {
+			t.Errorf("Expected no input for User without gen_inputs, got:\n%s", schema)
+		}
+	})
+}
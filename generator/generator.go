@@ -37,12 +37,18 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
+	"go/token"
+	"io"
 	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -62,6 +68,10 @@ type Generator struct {
 	// Key: type name, Value: slice of field references with detailed info
 	OutOfScopeTypes map[string][]OutOfScopeReference
 
+	// MissingInputFields tracks required input fields whose object type has no input
+	// Any entry fails generation, since gqlgen rejects inputs that reference object types
+	MissingInputFields []MissingInputReference
+
 	// GenericInstantiations tracks generic types that need concrete instantiations
 	// Key: concrete type name (e.g., "CommentEdge"), Value: instantiation info
 	GenericInstantiations map[string]*GenericInstantiation
@@ -70,8 +80,47 @@ type Generator struct {
 	// Key: concrete type name, Value: GraphQL schema content
 	ConcreteTypeContents map[string]string
 
+	// MapPairTypes tracks synthesized key-value pair types for maps rendered as lists
+	// Key: pair type or input name, Value: true once its definition has been emitted
+	MapPairTypes map[string]bool
+
+	// InlineStructTypes tracks the types and inputs synthesized for inline anonymous struct fields
+	// Key: type or input name, Value: true once its definition has been emitted
+	InlineStructTypes map[string]bool
+
+	// pendingPairTypes holds pair and inline struct definitions to be written after the type being generated
+	pendingPairTypes []string
+
+	// syntheticTypes holds the Relay helper types generated without a Go model
+	// They get no @goModel directive, so gqlgen generates the models
+	syntheticTypes map[string]bool
+
+	// relayBaseEmitted is set once the Node interface and PageInfo type have been generated
+	relayBaseEmitted bool
+
+	// usedDirectives holds the gqlgen directives written to the schema (goModel, goField, goEnum)
+	usedDirectives map[string]bool
+
+	// expandingTypes holds the Go types whose fields are currently being collected
+	// Used to break cycles through embedded self-references (e.g., type Node struct { *Node })
+	expandingTypes map[string]bool
+
+	// referenceNames maps the GraphQL names field references were renamed to back to their Go types
+	// (user_profile -> UserProfile), so scope checks on rendered field types find the Go type
+	referenceNames map[string]string
+
 	// GeneratedItems tracks all generated GraphQL schema items
 	GeneratedItems []GQLSchemaItem
+
+	// routedFiles holds the types and inputs sent to their own file with @gqlFile, by output file
+	routedFiles map[string]*strings.Builder
+	// rootTypes collects the fields of @gqlType(root:...) structs by root type name (Query, Mutation, Subscription)
+	rootTypes map[string]*rootType
+
+	// verboseLog logs generation decisions when Config.Verbose is set, created on first use
+	verboseLog *slog.Logger
+	// verboseOutput receives verbose output, os.Stderr when nil
+	verboseOutput io.Writer
 }
 
 // GenericInstantiation represents a concrete instantiation of a generic type
@@ -94,6 +143,16 @@ type OutOfScopeReference struct {
 	FieldName      string // GraphQL field name (e.g., "test")
 	GoFieldType    string // Go field type (e.g., "outofscope.AnotherOutOfScope")
 	ReferencedType string // Referenced GraphQL type name (e.g., "AnotherOutOfScope")
+	Embedded       bool   // True for an embedded type whose fields could not be expanded
+}
+
+// MissingInputReference tracks a required input field whose object type has no input
+type MissingInputReference struct {
+	InputName      string // GraphQL name of the input containing the field
+	GoTypeName     string // Go type the input is generated from
+	GoFieldName    string // Go field name (e.g., "Address")
+	FieldName      string // GraphQL field name (e.g., "address")
+	ReferencedType string // Go type of the object without an input (e.g., "Address")
 }
 
 // GQLSchemaItem represents a generated GraphQL schema item
@@ -116,6 +175,20 @@ type GQLSchemaItem struct {
 	AutoGenerated bool
 	// Namespace is the namespace if applicable
 	Namespace string
+	// Extension is true for "extend type" / "extend input" blocks of a definition made elsewhere
+	Extension bool
+	// Fields lists the generated fields of types, inputs and interfaces
+	Fields []GQLSchemaField
+	// Values lists the generated values of enums
+	Values []string
+}
+
+// GQLSchemaField is a generated field of a GraphQL schema item
+type GQLSchemaField struct {
+	// Name is the GraphQL field name
+	Name string `json:"name"`
+	// Type is the GraphQL field type (e.g., "[String!]!")
+	Type string `json:"type"`
 }
 
 // GenerationContext holds context for generating a specific GraphQL item
@@ -142,11 +215,234 @@ func NewGenerator(p *Parser, config *Config) *Generator {
 		OutOfScopeTypes:       make(map[string][]OutOfScopeReference),
 		GenericInstantiations: make(map[string]*GenericInstantiation),
 		ConcreteTypeContents:  make(map[string]string),
+		MapPairTypes:          make(map[string]bool),
+		InlineStructTypes:     make(map[string]bool),
+		syntheticTypes:        make(map[string]bool),
+		usedDirectives:        make(map[string]bool),
+		expandingTypes:        make(map[string]bool),
+		referenceNames:        make(map[string]string),
+		routedFiles:           make(map[string]*strings.Builder),
+		rootTypes:             make(map[string]*rootType),
 		GeneratedItems:        []GQLSchemaItem{},
 	}
 }
 
 func (g *Generator) Run() error {
+	fileContents, err := g.Build()
+	if err != nil {
+		return err
+	}
+
+	if g.Config.Format == FormatJSON {
+		if fileContents, err = g.manifestFiles(); err != nil {
+			return err
+		}
+	}
+
+	if g.Config.Output == OutputStdout {
+		return g.writeStdout(fileContents)
+	}
+
+	// Check and dry runs compare the exact content a write would produce
+	rendered, err := g.renderFiles(fileContents)
+	if err != nil {
+		return err
+	}
+
+	if g.Config.CheckOnly {
+		return g.checkFiles(rendered)
+	}
+
+	if g.Config.DryRun {
+		return g.dryRun(rendered)
+	}
+
+	// Ensure output directory exists (the manifest output can be a file path, its directory is created below)
+	if g.Config.Format != FormatJSON {
+		if err := EnsureDir(g.outputDir()); err != nil {
+			return err
+		}
+	}
+
+	// All validations passed - now write the files
+	for _, outFile := range sortedKeys(rendered) {
+		// Ensure directory exists
+		if err := EnsureDir(filepath.Dir(outFile)); err != nil {
+			return err
+		}
+		if err := os.WriteFile(outFile, []byte(rendered[outFile]), 0o644); err != nil {
+			return err
+		}
+	}
+
+	// Log generation summary
+	g.logGenerationSummary()
+
+	return nil
+}
+
+// renderFiles returns the final content of every non-empty output file: schema files get the
+// header and keep sections (see RenderFileContent), the JSON manifest only the PostProcess hook
+func (g *Generator) renderFiles(fileContents map[string]string) (map[string]string, error) {
+	rendered := make(map[string]string, len(fileContents))
+	for outFile, content := range fileContents {
+		if len(content) == 0 {
+			continue
+		}
+		var err error
+		if g.Config.Format == FormatJSON {
+			content, err = postProcess(outFile, normalizeNewlines(content), g.Config)
+		} else {
+			content, err = RenderFileContent(outFile, content, g.Config)
+		}
+		if err != nil {
+			return nil, err
+		}
+		rendered[outFile] = content
+	}
+	return rendered, nil
+}
+
+// writeStdout writes the single-file schema to stdout, passed through the PostProcess hook with "-" as its path
+// The generated-code header and keep sections are left out: there is no file to keep sections from,
+// and the output is meant to be piped into other tools
+func (g *Generator) writeStdout(fileContents map[string]string) error {
+	// Namespaces split the output even with the single strategy
+	if len(fileContents) > 1 {
+		return fmt.Errorf("output '-' (stdout) produced %d files (namespaces are not supported)", len(fileContents))
+	}
+	for _, content := range fileContents {
+		content, err := postProcess(OutputStdout, normalizeNewlines(content), g.Config)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(os.Stdout, content); err != nil {
+			return err
+		}
+	}
+
+	g.logGenerationSummary()
+
+	return nil
+}
+
+// ErrSchemaChanged is returned by a dry run when at least one file would be written or changed
+var ErrSchemaChanged = errors.New("schema is out of date")
+
+// dryRun prints every file that would be written (new files with their size, existing
+// files as a unified diff) and returns ErrSchemaChanged if any file would change
+func (g *Generator) dryRun(rendered map[string]string) error {
+	changed := 0
+	for _, outFile := range sortedKeys(rendered) {
+		content := rendered[outFile]
+		if !FileExists(outFile) {
+			changed++
+			fmt.Printf("would create %s (%d bytes)\n", outFile, len(content))
+			continue
+		}
+
+		existing, err := os.ReadFile(outFile)
+		if err != nil {
+			return err
+		}
+		if diff := UnifiedDiff(outFile, outFile, string(existing), content); diff != "" {
+			changed++
+			fmt.Printf("would update %s\n%s", outFile, diff)
+		} else {
+			fmt.Printf("unchanged %s\n", outFile)
+		}
+	}
+
+	if changed > 0 {
+		return fmt.Errorf("%w: %d file(s) would change", ErrSchemaChanged, changed)
+	}
+	return nil
+}
+
+// checkFiles compares every file that would be written with the one on disk and returns
+// ErrSchemaChanged listing the stale files (missing or different) without writing anything
+func (g *Generator) checkFiles(rendered map[string]string) error {
+	var stale []string
+	for _, outFile := range sortedKeys(rendered) {
+		current, err := isFileCurrent(outFile, rendered[outFile])
+		if err != nil {
+			return err
+		}
+		if !current {
+			stale = append(stale, outFile)
+		}
+	}
+
+	return reportStaleFiles(stale)
+}
+
+// isFileCurrent reports whether path exists with the given content, ignoring trailing
+// whitespace and line endings so files checked out on another platform still match
+func isFileCurrent(path, content string) (bool, error) {
+	if !FileExists(path) {
+		return false, nil
+	}
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	return normalizeForCheck(string(existing)) == normalizeForCheck(content), nil
+}
+
+// normalizeForCheck strips trailing whitespace (including \r) from every line and trailing blank lines
+func normalizeForCheck(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// reportStaleFiles prints the stale files and returns ErrSchemaChanged if there are any
+func reportStaleFiles(stale []string) error {
+	if len(stale) == 0 {
+		return nil
+	}
+	fmt.Println("stale files:")
+	for _, outFile := range stale {
+		fmt.Printf("  %s\n", outFile)
+	}
+	return fmt.Errorf("%w: %d file(s) are stale, run generate to update them", ErrSchemaChanged, len(stale))
+}
+
+// Build generates the schema in memory without writing any files
+// It returns the generated content keyed by output file path (without the generated-code header
+// and keep sections that WriteFile adds). Out-of-scope types are reported the same way as in Run.
+func (g *Generator) Build() (map[string]string, error) {
+	fileContents, err := g.render()
+	if err != nil {
+		return nil, err
+	}
+
+	// The first problem that would make the schema invalid stops the build
+	if errs := g.checkSchema(fileContents); len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	// Report out-of-scope types if any were found (BEFORE writing files)
+	if len(g.OutOfScopeTypes) > 0 {
+		outOfScopeErr := g.reportOutOfScopeTypes()
+		if outOfScopeErr != nil {
+			return nil, outOfScopeErr
+		}
+	}
+
+	return fileContents, nil
+}
+
+// render runs auto-generation and renders every schema file, without checking the result
+func (g *Generator) render() (map[string]string, error) {
+	// Make @gqlScalar types known so fields using them resolve to the scalar
+	g.registerScalarTypes()
+
+	// Structs embedded from packages outside the scan list have their fields inlined too
+	g.loadExternalEmbeddedStructs()
+
 	// Build dependency graph and mark types for auto-generation
 	if g.Config.AutoGenerate.Enabled {
 		depGraph := g.BuildDependencyGraph()
@@ -185,29 +481,29 @@ func (g *Generator) Run() error {
 		depGraph := g.BuildDependencyGraph()
 		depGraph.MarkTypesForGeneration(g.Config)
 		g.applyAutoGeneration(depGraph)
-	}
-
-	// Check if we have any namespaces defined
-	hasNamespaces := len(g.P.TypeNamespaces) > 0 || len(g.P.EnumNamespaces) > 0
 
-	// Ensure output directory exists
-	outputDir := g.Config.Output
-	if g.Config.GenStrategy == GenStrategySingle && !hasNamespaces {
-		// For single strategy without namespaces, check if Output is a file path or directory
-		// If it ends with an extension, it's a file path - extract the directory
-		if strings.HasSuffix(g.Config.Output, ".graphqls") || strings.HasSuffix(g.Config.Output, ".graphql") || strings.HasSuffix(g.Config.Output, ".gql") {
-			outputDir = filepath.Dir(g.Config.Output)
-		} else {
-			outputDir = g.Config.Output
+		// The final graph has every discovered type, report the cut once from it
+		if len(depGraph.Truncated) > 0 {
+			slog.Warn("Auto-generation reached max_types, referenced types were left out and the schema is truncated",
+				"maxTypes", g.Config.AutoGenerate.MaxTypes, "skipped", strings.Join(depGraph.Truncated, ", "))
 		}
-	} else if g.Config.GenStrategy == GenStrategySingle && hasNamespaces {
-		// When using namespaces with single strategy, output path should be treated as directory
-		outputDir = g.Config.Output
 	}
-	if err := EnsureDir(outputDir); err != nil {
-		return err
+
+	// gen_inputs gives every @gqlType struct an input, as if it had @gqlInput
+	if g.Config.GenInputs {
+		g.applyGenInputs()
+	}
+
+	g.logTypeDirectives()
+
+	// Inputs built from another struct (@gqlInput(from:...)) need that struct
+	if err := g.checkInputSources(); err != nil {
+		return nil, err
 	}
 
+	// Check if we have any namespaces defined
+	hasNamespaces := g.hasNamespaces()
+
 	// Build topological order (AFTER auto-generation adds types to TypeNames)
 	orders := g.buildDependencyOrder()
 
@@ -233,39 +529,244 @@ func (g *Generator) Run() error {
 	}
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Report out-of-scope types if any were found (BEFORE writing files)
-	if len(g.OutOfScopeTypes) > 0 {
-		outOfScopeErr := g.reportOutOfScopeTypes()
-		if outOfScopeErr != nil {
-			return outOfScopeErr
+	// Types and inputs with @gqlFile were rendered into their own files
+	for _, outFile := range sortedKeys(g.routedFiles) {
+		if content := g.routedFiles[outFile].String(); content != "" {
+			fileContents[outFile] += content
 		}
 	}
 
-	// All validations passed - now write the files
-	for outFile, content := range fileContents {
-		if len(content) > 0 {
-			// Ensure directory exists
-			if err := EnsureDir(filepath.Dir(outFile)); err != nil {
-				return err
-			}
-			if err := WriteFile(outFile, content, g.Config); err != nil {
-				return err
-			}
+	g.writeRootTypes(fileContents)
+	g.writeDirectiveDefinitions(fileContents)
+
+	return fileContents, nil
+}
+
+// checkSchema runs the checks for problems that would make the rendered schema invalid
+// and returns every failure, in the order Build reports them
+func (g *Generator) checkSchema(fileContents map[string]string) []error {
+	var errs []error
+
+	// Required input fields referencing object types would produce an invalid schema
+	if len(g.MissingInputFields) > 0 {
+		errs = append(errs, g.missingInputFieldsError())
+	}
+
+	// Enum values that are not legal GraphQL names would produce an invalid schema
+	if err := g.checkEnumValues(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// @goEnum directives naming a package that cannot be resolved would not compile with gqlgen
+	if err := g.checkEnumGoValues(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Inputs referencing each other through non-null fields would produce an unusable schema
+	if err := g.checkInputCycles(fileContents); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Two definitions with the same GraphQL name would produce an invalid schema
+	if !g.Config.AllowDuplicateNames {
+		if err := g.checkNameCollisions(); err != nil {
+			errs = append(errs, err)
 		}
 	}
 
-	// Log generation summary
-	g.logGenerationSummary()
+	return errs
+}
 
-	return nil
+// hasNamespaces reports whether any type or enum declares a namespace
+func (g *Generator) hasNamespaces() bool {
+	return len(g.P.TypeNamespaces) > 0 || len(g.P.EnumNamespaces) > 0 || len(g.P.ScalarNamespaces) > 0
+}
+
+// singleOutputFile returns the file the single strategy writes to
+// If Output ends with a schema extension (old style), it is used directly
+// If Output is a directory (new style), it is joined with OutputFileName
+func (g *Generator) singleOutputFile() string {
+	if g.Config.Output == OutputStdout {
+		return OutputStdout
+	}
+	if g.Config.isSchemaFile(g.Config.Output) {
+		return g.Config.Output
+	}
+	return filepath.Join(g.Config.Output, g.Config.OutputFileName)
+}
+
+// outputDir returns the directory that receives the generated files
+func (g *Generator) outputDir() string {
+	if g.Config.GenStrategy == GenStrategySingle && !g.hasNamespaces() {
+		// For single strategy without namespaces, check if Output is a file path or directory
+		// If it ends with an extension, it's a file path - extract the directory
+		if g.Config.isSchemaFile(g.Config.Output) {
+			return filepath.Dir(g.Config.Output)
+		}
+	}
+	// When using namespaces with single strategy, output path should be treated as directory
+	return g.Config.Output
+}
+
+// fileOverrideContext returns the context for a definition sent to its own file with @gqlFile,
+// or nil when it stays in the file of ctx. @gqlFile takes precedence over namespaces and the
+// strategy; stdout output keeps everything in one document
+func (g *Generator) fileOverrideContext(outputFile string, ctx *GenerationContext) *GenerationContext {
+	if outputFile == "" || ctx == nil || g.Config.Output == OutputStdout {
+		return nil
+	}
+	if filepath.Ext(outputFile) == "" {
+		outputFile += g.Config.OutputFileExtension
+	}
+
+	target := filepath.Join(g.outputDir(), outputFile)
+	if ctx.OutputFile == target {
+		return nil
+	}
+
+	routed := *ctx
+	routed.OutputFile = target
+	routed.Namespace = ""
+	return &routed
+}
+
+// writeRoutedContent appends content rendered for a @gqlFile file
+func (g *Generator) writeRoutedContent(outFile, content string) {
+	if content == "" || (g.Config.SkipExisting && FileExists(outFile)) {
+		return
+	}
+	buf, exists := g.routedFiles[outFile]
+	if !exists {
+		buf = &strings.Builder{}
+		g.routedFiles[outFile] = buf
+	}
+	buf.WriteString(content)
+}
+
+// rootType is a root operation type assembled from the fields of @gqlType(root:...) structs
+type rootType struct {
+	OutputFile string          // File of the first contributing struct
+	Strategy   string          // Strategy of the first contributing struct
+	Namespace  string          // Namespace of the first contributing struct
+	Content    strings.Builder // Rendered fields, in contribution order
+	Fields     []GQLSchemaField
+	Sources    map[string]string // GraphQL field name -> contributing type
+}
+
+// rootTypeNames are the root operation types, in the order they are emitted
+var rootTypeNames = []string{"Query", "Mutation", "Subscription"}
+
+// rootTypeName returns the root operation type for a @gqlType root value, or "" when it is unknown
+func rootTypeName(root string) string {
+	for _, name := range rootTypeNames {
+		if strings.EqualFold(root, name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// addRootFields adds the fields of the root struct rendered as typeName to the root type
+// A field already contributed by another struct is skipped with a warning
+func (g *Generator) addRootFields(rootName, typeName string, fields []renderedField, extraFields []ExtraField, ctx *GenerationContext) {
+	root, exists := g.rootTypes[rootName]
+	if !exists {
+		root = &rootType{OutputFile: ctx.OutputFile, Strategy: ctx.Strategy, Namespace: ctx.Namespace, Sources: make(map[string]string)}
+		g.rootTypes[rootName] = root
+	}
+
+	add := func(fieldName, fieldType, content string) {
+		if source, taken := root.Sources[fieldName]; taken {
+			slog.Warn("Root field is defined by several structs, keeping the first one",
+				"root", rootName, "field", fieldName, "kept", source, "skipped", typeName)
+			return
+		}
+		root.Sources[fieldName] = typeName
+		root.Fields = append(root.Fields, GQLSchemaField{Name: fieldName, Type: fieldType})
+		root.Content.WriteString(content)
+	}
+	for _, field := range fields {
+		add(field.Name, field.Type, field.Content)
+	}
+	for _, ef := range extraFields {
+		buf := strings.Builder{}
+		g.writeExtraField(&buf, ef, rootName, false)
+		add(ef.Name, ef.Type, buf.String())
+	}
+	g.verbose("root fields added", "root", rootName, "type", typeName, "fields", len(fields)+len(extraFields))
+}
+
+// writeRootTypes appends the assembled root types to the file of their first contributing struct
+func (g *Generator) writeRootTypes(fileContents map[string]string) {
+	for _, rootName := range rootTypeNames {
+		root, exists := g.rootTypes[rootName]
+		if !exists || len(root.Fields) == 0 {
+			continue
+		}
+		fileContents[root.OutputFile] += fmt.Sprintf("type %s {\n%s}\n\n", rootName, root.Content.String())
+
+		g.registerGeneratedItem(GQLSchemaItem{
+			OutputFile: root.OutputFile,
+			GQLName:    rootName,
+			GQLKind:    "type",
+			Strategy:   root.Strategy,
+			Namespace:  root.Namespace,
+			Fields:     root.Fields,
+		})
+	}
+}
+
+// gqlgenDirectiveDefinitions are the definitions of the gqlgen directives, in the order they are emitted
+var gqlgenDirectiveDefinitions = []struct{ Name, Definition string }{
+	{"goModel", "directive @goModel(model: String, models: [String!]) on OBJECT | INPUT_OBJECT | SCALAR | ENUM | INTERFACE | UNION"},
+	{"goField", "directive @goField(forceResolver: Boolean, name: String, omittable: Boolean) on INPUT_FIELD_DEFINITION | FIELD_DEFINITION"},
+	{"goEnum", "directive @goEnum(value: String) on ENUM_VALUE"},
+}
+
+// DirectivesFileName is the file the gqlgen directive definitions are written to (emit_directive_definitions)
+const DirectivesFileName = "directives"
+
+// writeDirectiveDefinitions adds the definitions of the gqlgen directives used in the schema
+// (emit_directive_definitions): prepended to the single output file, or in their own
+// directives file when the schema is split over several files
+func (g *Generator) writeDirectiveDefinitions(fileContents map[string]string) {
+	if !g.Config.EmitDirectiveDefinitions {
+		return
+	}
+
+	var buf strings.Builder
+	for _, directive := range gqlgenDirectiveDefinitions {
+		if g.usedDirectives[directive.Name] {
+			buf.WriteString(directive.Definition + "\n\n")
+		}
+	}
+	if buf.Len() == 0 {
+		return
+	}
+
+	if g.Config.GenStrategy == GenStrategySingle && !g.hasNamespaces() {
+		outFile := g.singleOutputFile()
+		if _, exists := fileContents[outFile]; exists {
+			fileContents[outFile] = buf.String() + fileContents[outFile]
+		}
+		return
+	}
+
+	outFile := filepath.Join(g.outputDir(), DirectivesFileName+g.Config.OutputFileExtension)
+	if g.Config.SkipExisting && FileExists(outFile) {
+		return
+	}
+	fileContents[outFile] = buf.String()
 }
 
 // registerGeneratedItem records a generated GraphQL schema item
 func (g *Generator) registerGeneratedItem(item GQLSchemaItem) {
 	g.GeneratedItems = append(g.GeneratedItems, item)
+	g.verbose("generated", "kind", item.GQLKind, "name", item.GQLName, "goType", item.GoTypeName,
+		"autoGenerated", item.AutoGenerated, "file", item.OutputFile)
 }
 
 // logGenerationSummary logs a summary of all generated items
@@ -309,6 +810,7 @@ func (g *Generator) logGenerationSummary() {
 	}
 }
 
+// buildDependencyOrder returns the types to generate in the configured type order (see Config.TypeOrder)
 func (g *Generator) buildDependencyOrder() []string {
 	// Include types from TypeNames (scanned types) and types marked for auto-generation
 	nameSet := make(map[string]bool)
@@ -338,49 +840,99 @@ func (g *Generator) buildDependencyOrder() []string {
 	}
 	sort.Strings(names)
 
-	// Topological sort
-	orders := []string{}
-	visited := map[string]bool{}
-	var dfs func(string)
-	dfs = func(n string) {
-		if visited[n] {
+	// Collect the structs and generic instantiation aliases to generate, with the structs they reference
+	deps := make(map[string][]string)
+	var collect func(string)
+	collect = func(n string) {
+		if _, seen := deps[n]; seen {
 			return
 		}
-		visited[n] = true
 		typeSpec := g.P.StructTypes[n]
-
-		// Handle type aliases to generic instantiations (IndexListExpr, IndexExpr)
-		// These should be included in orders but don't have dependencies to traverse
-		if _, ok := typeSpec.Type.(*ast.IndexListExpr); ok {
-			orders = append(orders, n)
-			return
-		}
-		if _, ok := typeSpec.Type.(*ast.IndexExpr); ok {
-			orders = append(orders, n)
+		if typeSpec == nil {
 			return
 		}
 
-		st, ok := typeSpec.Type.(*ast.StructType)
-		if !ok {
-			return
-		}
-		for _, f := range st.Fields.List {
-			ft := FieldTypeName(f.Type)
-			if ts, ok := g.P.StructTypes[ft]; ok {
-				if _, ok := ts.Type.(*ast.StructType); !ok {
-					continue
+		switch t := typeSpec.Type.(type) {
+		case *ast.IndexListExpr, *ast.IndexExpr:
+			// Aliases to generic instantiations have no dependencies to traverse
+			deps[n] = nil
+		case *ast.StructType:
+			// Register before recursing so reference cycles terminate
+			deps[n] = []string{}
+			var refs []string
+			for _, f := range t.Fields.List {
+				ft := FieldTypeName(f.Type)
+				if ts, ok := g.P.StructTypes[ft]; ok {
+					if _, ok := ts.Type.(*ast.StructType); !ok {
+						continue
+					}
+					refs = append(refs, ft)
+					collect(ft)
 				}
-				dfs(ft)
 			}
+			deps[n] = refs
 		}
-		orders = append(orders, n)
 	}
 	for _, n := range names {
-		dfs(n)
+		collect(n)
+	}
+
+	orders := make([]string, 0, len(deps))
+	for n := range deps {
+		orders = append(orders, n)
+	}
+
+	switch g.Config.TypeOrder {
+	case TypeOrderAlphabetical:
+		sort.Strings(orders)
+	case TypeOrderSource:
+		sort.Slice(orders, func(i, j int) bool {
+			fi, fj := g.P.SourceFiles[orders[i]], g.P.SourceFiles[orders[j]]
+			if fi != fj {
+				return fi < fj
+			}
+			pi, pj := g.P.StructTypes[orders[i]].Pos(), g.P.StructTypes[orders[j]].Pos()
+			if pi != pj {
+				return pi < pj
+			}
+			return orders[i] < orders[j]
+		})
+	default: // TypeOrderTopological
+		// A type's layer is one more than the deepest layer it references, leaf types are layer 0
+		// Edges back into a type that is still being measured close a cycle and add no layer
+		layers := make(map[string]int, len(deps))
+		measuring := make(map[string]bool)
+		var layerOf func(string) int
+		layerOf = func(n string) int {
+			if layer, ok := layers[n]; ok {
+				return layer
+			}
+			if measuring[n] {
+				return -1
+			}
+			measuring[n] = true
+			layer := 0
+			for _, dep := range deps[n] {
+				layer = max(layer, layerOf(dep)+1)
+			}
+			delete(measuring, n)
+			layers[n] = layer
+			return layer
+		}
+
+		sort.Strings(orders)
+		for _, n := range orders {
+			layerOf(n)
+		}
+		sort.SliceStable(orders, func(i, j int) bool {
+			return layers[orders[i]] < layers[orders[j]]
+		})
 	}
 
 	return orders
-} // generateTypeContent generates GraphQL type definitions for a given struct with tracking context
+}
+
+// generateTypeContent generates GraphQL type definitions for a given struct with tracking context
 // This is a shared helper used by all generation strategies
 func (g *Generator) generateTypeContent(typeName string, typeSpec *ast.TypeSpec, d StructDirectives, ctx *GenerationContext) string {
 	buf := strings.Builder{}
@@ -401,9 +953,19 @@ func (g *Generator) generateTypeContent(typeName string, typeSpec *ast.TypeSpec,
 		return ""
 	}
 
-	// Generate all types from @gqlType directives
-	if d.HasTypeDirective {
-		slog.Debug("Generating type from directive", "type", typeName, "count", len(d.Types))
+	// Generate all interfaces from @gqlInterface directives
+	for _, interfaceDef := range d.Interfaces {
+		buf.WriteString(g.generateInterfaceFromDef(typeSpec, st, d, interfaceDef, ctx))
+	}
+
+	// Generate all unions from @gqlUnion directives
+	for _, unionDef := range d.Unions {
+		buf.WriteString(g.generateUnion(typeSpec, d, unionDef, ctx))
+	}
+
+	// Generate all types from @gqlType directives
+	if d.HasTypeDirective {
+		slog.Debug("Generating type from directive", "type", typeName, "count", len(d.Types))
 		for _, typeDef := range d.Types {
 			typeContent := g.generateTypeFromDef(typeSpec, st, d, typeDef, ctx)
 			if typeContent != "" {
@@ -460,7 +1022,7 @@ func (g *Generator) generateInputContent(typeName string, typeSpec *ast.TypeSpec
 		// Auto-generated input without explicit @gqlInput annotation
 		slog.Debug("Auto-generating input", "type", typeName)
 		defaultInputDef := InputDefinition{
-			Name:        typeName + "Input",
+			Name:        g.defaultInputName(d),
 			Description: "",
 		}
 		inputContent := g.generateInputFromDef(typeSpec, st, d, defaultInputDef, ctx)
@@ -477,9 +1039,12 @@ func (g *Generator) generateByNamespace(orders []string) (map[string]string, err
 	slog.Info("Generating schema using namespace strategy")
 	// Group types, inputs, and enums by namespace
 	type namespaceItems struct {
-		types  map[string][]TypeDefinition  // typeName -> type definitions
-		inputs map[string][]InputDefinition // typeName -> input definitions
-		enums  []string                     // enum names
+		interfaces map[string][]InterfaceDefinition // typeName -> interface definitions
+		unions     map[string][]UnionDefinition     // typeName -> union definitions
+		types      map[string][]TypeDefinition      // typeName -> type definitions
+		inputs     map[string][]InputDefinition     // typeName -> input definitions
+		enums      []string                         // enum names
+		scalars    []string                         // scalar names
 	}
 
 	namespaces := make(map[string]*namespaceItems)
@@ -491,9 +1056,11 @@ func (g *Generator) generateByNamespace(orders []string) (map[string]string, err
 		}
 		if namespaces[ns] == nil {
 			namespaces[ns] = &namespaceItems{
-				types:  make(map[string][]TypeDefinition),
-				inputs: make(map[string][]InputDefinition),
-				enums:  []string{},
+				interfaces: make(map[string][]InterfaceDefinition),
+				unions:     make(map[string][]UnionDefinition),
+				types:      make(map[string][]TypeDefinition),
+				inputs:     make(map[string][]InputDefinition),
+				enums:      []string{},
 			}
 		}
 		return namespaces[ns]
@@ -514,6 +1081,26 @@ func (g *Generator) generateByNamespace(orders []string) (map[string]string, err
 		// Get file-level namespace for this type
 		fileNamespace := g.P.TypeNamespaces[typeName]
 
+		// Group interfaces by their namespace (directive-level overrides file-level)
+		for _, interfaceDef := range d.Interfaces {
+			ns := interfaceDef.Namespace
+			if ns == "" {
+				ns = fileNamespace
+			}
+			nsItems := getNamespace(ns)
+			nsItems.interfaces[typeName] = append(nsItems.interfaces[typeName], interfaceDef)
+		}
+
+		// Group unions by their namespace (directive-level overrides file-level)
+		for _, unionDef := range d.Unions {
+			ns := unionDef.Namespace
+			if ns == "" {
+				ns = fileNamespace
+			}
+			nsItems := getNamespace(ns)
+			nsItems.unions[typeName] = append(nsItems.unions[typeName], unionDef)
+		}
+
 		// Group types by their namespace (directive-level overrides file-level)
 		if d.HasTypeDirective {
 			for _, typeDef := range d.Types {
@@ -548,7 +1135,7 @@ func (g *Generator) generateByNamespace(orders []string) (map[string]string, err
 		} else if g.AutoGeneratedInputs[typeName] {
 			// Auto-generated input without explicit @gqlInput annotation
 			defaultInputDef := InputDefinition{
-				Name:        typeName + "Input",
+				Name:        g.defaultInputName(d),
 				Description: "",
 				Namespace:   fileNamespace,
 			}
@@ -564,11 +1151,19 @@ func (g *Generator) generateByNamespace(orders []string) (map[string]string, err
 		nsItems.enums = append(nsItems.enums, enumName)
 	}
 
+	// Group scalars by namespace
+	for _, scalarName := range g.P.ScalarNames {
+		ns := g.P.ScalarNamespaces[scalarName]
+		nsItems := getNamespace(ns)
+		nsItems.scalars = append(nsItems.scalars, scalarName)
+	}
+
 	// Collect all content in memory first (map of file path -> content)
 	fileContents := make(map[string]*strings.Builder)
 
 	// Generate content for each namespace
-	for namespace, items := range namespaces {
+	for _, namespace := range sortedKeys(namespaces) {
+		items := namespaces[namespace]
 		var outFile string
 
 		if namespace == "_default" {
@@ -604,6 +1199,11 @@ func (g *Generator) generateByNamespace(orders []string) (map[string]string, err
 			Namespace:  namespace,
 		}
 
+		// Generate scalars for this namespace
+		for _, scalarName := range items.scalars {
+			buf.WriteString(g.generateScalar(g.P.ScalarTypes[scalarName], ctx))
+		}
+
 		// Generate enums for this namespace
 		slog.Debug("Generating enums for namespace", "namespace", namespace, "count", len(items.enums))
 		for _, enumName := range items.enums {
@@ -618,6 +1218,14 @@ func (g *Generator) generateByNamespace(orders []string) (map[string]string, err
 			}
 		}
 
+		// Generate interfaces for this namespace
+		slog.Debug("Generating interfaces for namespace", "namespace", namespace, "count", len(items.interfaces))
+		g.writeGroupedInterfaces(buf, items.interfaces, ctx)
+
+		// Generate unions for this namespace
+		slog.Debug("Generating unions for namespace", "namespace", namespace, "count", len(items.unions))
+		g.writeGroupedUnions(buf, items.unions, ctx)
+
 		// Generate types and inputs for this namespace
 		slog.Debug("Generating types for namespace", "namespace", namespace, "count", len(items.types))
 
@@ -642,7 +1250,8 @@ func (g *Generator) generateByNamespace(orders []string) (map[string]string, err
 			}
 		} // Generate inputs for this namespace
 		slog.Debug("Generating inputs for namespace", "namespace", namespace, "count", len(items.inputs))
-		for typeName, inputDefs := range items.inputs {
+		for _, typeName := range sortedKeys(items.inputs) {
+			inputDefs := items.inputs[typeName]
 			typeSpec := g.P.StructTypes[typeName]
 			structType := typeSpec.Type.(*ast.StructType)
 			d := ParseDirectives(typeSpec, g.P.TypeToDecl[typeName])
@@ -663,7 +1272,8 @@ func (g *Generator) generateByNamespace(orders []string) (map[string]string, err
 
 	// Add concrete type contents to their respective files
 	slog.Debug("Adding concrete types to files", "count", len(g.ConcreteTypeContents))
-	for typeName, content := range g.ConcreteTypeContents {
+	for _, typeName := range sortedKeys(g.ConcreteTypeContents) {
+		content := g.ConcreteTypeContents[typeName]
 		// Find which file this concrete type should go to
 		// For now, check the registered item to see which output file it was assigned
 		var targetFile string
@@ -679,6 +1289,10 @@ func (g *Generator) generateByNamespace(orders []string) (map[string]string, err
 
 		if targetFile != "" {
 			buf := fileContents[targetFile]
+			if buf == nil {
+				// The generic type was sent to its own file with @gqlFile
+				buf = g.routedFiles[targetFile]
+			}
 			if buf != nil {
 				buf.WriteString(content)
 				slog.Debug("Added concrete type content", "typeName", typeName)
@@ -715,6 +1329,37 @@ func (g *Generator) generateByNamespaceAndPackage(orders []string) (map[string]s
 		return buf
 	}
 
+	// Process scalars
+	for _, scalarName := range g.P.ScalarNames {
+		var outFile string
+		ns := g.P.ScalarNamespaces[scalarName]
+
+		if ns != "" {
+			// Use namespace
+			namespacePath := ns
+			if g.Config.NamespaceSeparator != "/" {
+				namespacePath = strings.ReplaceAll(ns, g.Config.NamespaceSeparator, string(filepath.Separator))
+			}
+			outFile = filepath.Join(g.Config.Output, namespacePath+g.Config.OutputFileExtension)
+		} else {
+			// Use package name
+			pkgName := g.P.PackageNames[scalarName]
+			outFile = filepath.Join(g.Config.Output, pkgName+g.Config.OutputFileExtension)
+		}
+
+		if g.Config.SkipExisting && FileExists(outFile) {
+			continue
+		}
+
+		// Create context for this namespace/package file
+		scalarCtx := &GenerationContext{
+			OutputFile: outFile,
+			Strategy:   "namespace+package",
+			Namespace:  ns,
+		}
+		getBuffer(outFile).WriteString(g.generateScalar(g.P.ScalarTypes[scalarName], scalarCtx))
+	}
+
 	// Process enums
 	for _, enumName := range g.P.EnumNames {
 		enumType := g.P.EnumTypes[enumName]
@@ -769,13 +1414,61 @@ func (g *Generator) generateByNamespaceAndPackage(orders []string) (map[string]s
 		}
 
 		// Skip if no directives present and not auto-generated
-		if !d.HasTypeDirective && !d.HasInputDirective && !g.AutoGeneratedTypes[typeName] && !g.AutoGeneratedInputs[typeName] {
+		if !d.HasTypeDirective && !d.HasInputDirective && !d.HasInterfaceDirective && !d.HasUnionDirective && !g.AutoGeneratedTypes[typeName] && !g.AutoGeneratedInputs[typeName] {
 			continue
 		}
 
 		// Get file-level namespace for this type
 		fileNamespace := g.P.TypeNamespaces[typeName]
 
+		// Resolves the file and context for a directive-level namespace (falls back to file-level, then package)
+		// Returns a nil buffer when the file should be skipped
+		resolveTarget := func(directiveNamespace string) (*strings.Builder, *GenerationContext) {
+			ns := directiveNamespace
+			if ns == "" {
+				ns = fileNamespace
+			}
+
+			var outFile string
+			if ns != "" {
+				// Use namespace
+				namespacePath := ns
+				if g.Config.NamespaceSeparator != "/" {
+					namespacePath = strings.ReplaceAll(ns, g.Config.NamespaceSeparator, string(filepath.Separator))
+				}
+				outFile = filepath.Join(g.Config.Output, namespacePath+g.Config.OutputFileExtension)
+			} else {
+				// Use package name
+				pkgName := g.P.PackageNames[typeName]
+				outFile = filepath.Join(g.Config.Output, pkgName+g.Config.OutputFileExtension)
+			}
+
+			if g.Config.SkipExisting && FileExists(outFile) {
+				return nil, nil
+			}
+
+			// Create context for this namespace+package file
+			return getBuffer(outFile), &GenerationContext{
+				OutputFile: outFile,
+				Strategy:   "namespace+package",
+				Namespace:  ns,
+			}
+		}
+
+		// Process interfaces
+		for _, interfaceDef := range d.Interfaces {
+			if buf, ctx := resolveTarget(interfaceDef.Namespace); buf != nil {
+				buf.WriteString(g.generateInterfaceFromDef(typeSpec, typeSpec.Type.(*ast.StructType), d, interfaceDef, ctx))
+			}
+		}
+
+		// Process unions
+		for _, unionDef := range d.Unions {
+			if buf, ctx := resolveTarget(unionDef.Namespace); buf != nil {
+				buf.WriteString(g.generateUnion(typeSpec, d, unionDef, ctx))
+			}
+		}
+
 		// Process types
 		if d.HasTypeDirective || g.AutoGeneratedTypes[typeName] {
 			var typeDefs []TypeDefinition
@@ -835,7 +1528,7 @@ func (g *Generator) generateByNamespaceAndPackage(orders []string) (map[string]s
 			} else if g.AutoGeneratedInputs[typeName] {
 				// Auto-generated input without explicit @gqlInput annotation
 				inputDefs = []InputDefinition{{
-					Name:        typeName + "Input",
+					Name:        g.defaultInputName(d),
 					Description: "",
 				}}
 			}
@@ -893,19 +1586,9 @@ func (g *Generator) generateByNamespaceAndPackage(orders []string) (map[string]s
 func (g *Generator) generateSingleFile(orders []string) (map[string]string, error) {
 	slog.Info("Generating single schema file")
 
-	// Determine output file path
-	// If Output ends with an extension (old style), use it directly
-	// If Output is a directory (new style), join with OutputFileName
-	var outFile string
-	if strings.HasSuffix(g.Config.Output, ".graphqls") || strings.HasSuffix(g.Config.Output, ".graphql") || strings.HasSuffix(g.Config.Output, ".gql") {
-		// Old style: Output is the full file path
-		outFile = g.Config.Output
-	} else {
-		// New style: Output is directory, use OutputFileName
-		outFile = filepath.Join(g.Config.Output, g.Config.OutputFileName)
-	}
+	outFile := g.singleOutputFile()
 
-	if g.Config.SkipExisting && FileExists(outFile) {
+	if g.Config.SkipExisting && outFile != OutputStdout && FileExists(outFile) {
 		slog.Info("Skipping existing file", "file", outFile)
 		return map[string]string{}, nil
 	}
@@ -933,6 +1616,11 @@ func (g *Generator) generateSingleFile(orders []string) (map[string]string, erro
 		}
 	}
 
+	// Generate scalars declared with @gqlScalar
+	for _, scalarName := range g.P.ScalarNames {
+		buf.WriteString(g.generateScalar(g.P.ScalarTypes[scalarName], ctx))
+	}
+
 	// Generate enums first
 	slog.Debug("Generating enums", "count", len(g.P.EnumNames))
 	for _, enumName := range g.P.EnumNames {
@@ -972,7 +1660,8 @@ func (g *Generator) generateSingleFile(orders []string) (map[string]string, erro
 
 	// Add concrete type contents to their respective files
 	slog.Debug("Adding concrete types to files", "count", len(g.ConcreteTypeContents))
-	for typeName, content := range g.ConcreteTypeContents {
+	for _, typeName := range sortedKeys(g.ConcreteTypeContents) {
+		content := g.ConcreteTypeContents[typeName]
 		// Find which file this concrete type should go to
 		var targetFile string
 		for _, item := range g.GeneratedItems {
@@ -987,6 +1676,10 @@ func (g *Generator) generateSingleFile(orders []string) (map[string]string, erro
 
 		if targetFile != "" {
 			buf := fileContents[targetFile]
+			if buf == nil {
+				// The generic type was sent to its own file with @gqlFile
+				buf = g.routedFiles[targetFile]
+			}
 			if buf != nil {
 				buf.WriteString(content)
 				slog.Debug("Added concrete type content", "typeName", typeName)
@@ -1014,9 +1707,13 @@ func (g *Generator) generatePackageFiles(orders []string) (map[string]string, er
 
 	// Group types, inputs, and enums by their Go package path
 	type packageItems struct {
-		types  map[string][]TypeDefinition  // typeName -> type definitions
-		inputs map[string][]InputDefinition // typeName -> input definitions
-		enums  []string                     // enum names
+		interfaces map[string][]InterfaceDefinition // typeName -> interface definitions
+		unions     map[string][]UnionDefinition     // typeName -> union definitions
+		types      map[string][]TypeDefinition      // typeName -> type definitions
+		inputs     map[string][]InputDefinition     // typeName -> input definitions
+		enums      []string                         // enum names
+		scalars    []string                         // scalar names
+		importPath string                           // Go import path of the package (if known)
 	}
 
 	packages := make(map[string]*packageItems)
@@ -1028,9 +1725,11 @@ func (g *Generator) generatePackageFiles(orders []string) (map[string]string, er
 		}
 		if packages[pkgPath] == nil {
 			packages[pkgPath] = &packageItems{
-				types:  make(map[string][]TypeDefinition),
-				inputs: make(map[string][]InputDefinition),
-				enums:  []string{},
+				interfaces: make(map[string][]InterfaceDefinition),
+				unions:     make(map[string][]UnionDefinition),
+				types:      make(map[string][]TypeDefinition),
+				inputs:     make(map[string][]InputDefinition),
+				enums:      []string{},
 			}
 		}
 		return packages[pkgPath]
@@ -1059,6 +1758,18 @@ func (g *Generator) generatePackageFiles(orders []string) (map[string]string, er
 		pkgName := g.P.PackageNames[enumName]
 		pkg := getPackage(pkgName)
 		pkg.enums = append(pkg.enums, enumName)
+		if pkg.importPath == "" {
+			pkg.importPath = g.P.PackagePaths[enumName]
+		}
+	}
+
+	// Group scalars by package
+	for _, scalarName := range g.P.ScalarNames {
+		pkg := getPackage(g.P.PackageNames[scalarName])
+		pkg.scalars = append(pkg.scalars, scalarName)
+		if pkg.importPath == "" {
+			pkg.importPath = g.P.PackagePaths[scalarName]
+		}
 	}
 
 	// Group types and inputs by package
@@ -1076,6 +1787,19 @@ func (g *Generator) generatePackageFiles(orders []string) (map[string]string, er
 		// Get package name from PackageNames
 		pkgName := g.P.PackageNames[typeName]
 		pkg := getPackage(pkgName)
+		if pkg.importPath == "" {
+			pkg.importPath = g.P.PackagePaths[typeName]
+		}
+
+		// Add interfaces
+		if d.HasInterfaceDirective {
+			pkg.interfaces[typeName] = append(pkg.interfaces[typeName], d.Interfaces...)
+		}
+
+		// Add unions
+		if d.HasUnionDirective {
+			pkg.unions[typeName] = append(pkg.unions[typeName], d.Unions...)
+		}
 
 		// Add types
 		if d.HasTypeDirective {
@@ -1095,7 +1819,7 @@ func (g *Generator) generatePackageFiles(orders []string) (map[string]string, er
 		} else if g.AutoGeneratedInputs[typeName] {
 			// Auto-generated input without explicit @gqlInput annotation
 			defaultInputDef := InputDefinition{
-				Name:        typeName + "Input",
+				Name:        g.defaultInputName(d),
 				Description: "",
 			}
 			pkg.inputs[typeName] = append(pkg.inputs[typeName], defaultInputDef)
@@ -1127,7 +1851,8 @@ func (g *Generator) generatePackageFiles(orders []string) (map[string]string, er
 			pkgName := filepath.Base(pkgPath)
 			// Remove .go extension if present
 			pkgName = strings.TrimSuffix(pkgName, ".go")
-			outFile = filepath.Join(g.Config.Output, pkgName+g.Config.OutputFileExtension)
+			// Mapped packages go to their configured subdirectory
+			outFile = filepath.Join(g.Config.Output, g.packageOutputSubdir(items.importPath), pkgName+g.Config.OutputFileExtension)
 		}
 
 		if g.Config.SkipExisting && FileExists(outFile) {
@@ -1156,6 +1881,11 @@ func (g *Generator) generatePackageFiles(orders []string) (map[string]string, er
 			Namespace:  "",
 		}
 
+		// Generate scalars for this package
+		for _, scalarName := range items.scalars {
+			buf.WriteString(g.generateScalar(g.P.ScalarTypes[scalarName], ctx))
+		}
+
 		// Generate enums for this package
 		slog.Debug("Generating enums for package", "package", pkgPath, "count", len(items.enums))
 		for _, enumName := range items.enums {
@@ -1170,6 +1900,14 @@ func (g *Generator) generatePackageFiles(orders []string) (map[string]string, er
 			}
 		}
 
+		// Generate interfaces for this package
+		slog.Debug("Generating interfaces for package", "package", pkgPath, "count", len(items.interfaces))
+		g.writeGroupedInterfaces(buf, items.interfaces, ctx)
+
+		// Generate unions for this package
+		slog.Debug("Generating unions for package", "package", pkgPath, "count", len(items.unions))
+		g.writeGroupedUnions(buf, items.unions, ctx)
+
 		// Generate types and inputs for this package
 		slog.Debug("Generating types for package", "package", pkgPath, "count", len(items.types))
 
@@ -1196,7 +1934,8 @@ func (g *Generator) generatePackageFiles(orders []string) (map[string]string, er
 			}
 		} // Generate inputs for this package
 		slog.Debug("Generating inputs for package", "package", pkgPath, "count", len(items.inputs))
-		for typeName, inputDefs := range items.inputs {
+		for _, typeName := range sortedKeys(items.inputs) {
+			inputDefs := items.inputs[typeName]
 			typeSpec := g.P.StructTypes[typeName]
 			structType := typeSpec.Type.(*ast.StructType)
 			d := ParseDirectives(typeSpec, g.P.TypeToDecl[typeName])
@@ -1240,6 +1979,24 @@ func (g *Generator) generateMultipleFiles(orders []string) (map[string]string, e
 		fileContents[scalarFile] = scalarBuf
 	}
 
+	// Scalars declared with @gqlScalar share the scalars file
+	if len(g.P.ScalarNames) > 0 {
+		scalarFile := filepath.Join(g.Config.Output, "_scalars"+g.Config.OutputFileExtension)
+		scalarBuf, exists := fileContents[scalarFile]
+		if !exists {
+			scalarBuf = &strings.Builder{}
+			fileContents[scalarFile] = scalarBuf
+		}
+		scalarCtx := &GenerationContext{
+			OutputFile: scalarFile,
+			Strategy:   "multiple",
+			Namespace:  "",
+		}
+		for _, scalarName := range g.P.ScalarNames {
+			scalarBuf.WriteString(g.generateScalar(g.P.ScalarTypes[scalarName], scalarCtx))
+		}
+	}
+
 	// Generate enums first
 	slog.Debug("Generating enum files", "count", len(g.P.EnumNames))
 	for _, enumName := range g.P.EnumNames {
@@ -1291,7 +2048,7 @@ func (g *Generator) generateMultipleFiles(orders []string) (map[string]string, e
 		}
 
 		// Skip if no directives present and not auto-generated
-		if !d.HasTypeDirective && !d.HasInputDirective && !g.AutoGeneratedTypes[typeName] && !g.AutoGeneratedInputs[typeName] {
+		if !d.HasTypeDirective && !d.HasInputDirective && !d.HasInterfaceDirective && !d.HasUnionDirective && !g.AutoGeneratedTypes[typeName] && !g.AutoGeneratedInputs[typeName] {
 			continue
 		}
 
@@ -1353,229 +2110,1596 @@ func (g *Generator) writeGoModelDirective(buf *strings.Builder, goTypeName strin
 	if g.Config.UseGqlGenDirectives || useDirective {
 		pkgPath := g.P.GetPackageImportPath(goTypeName, g.Config.ModelPath)
 		fmt.Fprintf(buf, " @goModel(model: \"%s.%s\")", pkgPath, goTypeName)
+		g.usedDirectives["goModel"] = true
+	}
+}
+
+// writeGoFieldDirective writes the @goField directive if enabled: forceResolver: true for
+// resolved fields, or name: "GoName" for fields gqlgen could not bind by name. gqlgen matches
+// names ignoring case, so only renames (json:"fullName" on Name) need the Go field name
+func (g *Generator) writeGoFieldDirective(buf *strings.Builder, forceResolver bool, fieldName, goFieldName string) {
+	if !g.Config.UseGqlGenDirectives {
+		return
+	}
+	if forceResolver {
+		buf.WriteString(" @goField(forceResolver: true)")
+		g.usedDirectives["goField"] = true
+	} else if goFieldName != "" && !strings.EqualFold(fieldName, goFieldName) {
+		fmt.Fprintf(buf, " @goField(name: %s)", strconv.Quote(goFieldName))
+		g.usedDirectives["goField"] = true
+	}
+}
+
+// writeExtraField writes a @gqlTypeExtraField or @gqlInputExtraField field of the type or input named owner
+// Extra fields are not part of the Go struct, so both kinds get @goField(forceResolver: true)
+func (g *Generator) writeExtraField(buf *strings.Builder, ef ExtraField, owner string, forInput bool) {
+	if ef.Description != "" {
+		buf.WriteString(formatDescription(ef.Description, g.Config.indent()))
+	}
+	args := ""
+	if !forInput {
+		args = formatFieldArgs(ef.Args)
+	}
+	fmt.Fprintf(buf, "%s%s%s: %s", g.Config.indent(), ef.Name, args, ef.Type)
+	g.writeGoFieldDirective(buf, true, "", "")
+	g.writeDeprecatedDirective(buf, g.allowDeprecation(ef.Deprecated, forInput, ef.Type, "", owner, ef.Name), ef.DeprecatedReason)
+	buf.WriteString("\n")
+}
+
+// allowDeprecation tells whether a field marked as deprecated can carry @deprecated
+// GraphQL only allows deprecating input fields that are not required (nullable or with a default value)
+func (g *Generator) allowDeprecation(deprecated, forInput bool, fieldType, defaultValue, owner, field string) bool {
+	if !deprecated {
+		return false
+	}
+	if forInput && strings.HasSuffix(fieldType, "!") && defaultValue == "" {
+		slog.Warn("Required input field cannot be deprecated, @deprecated was skipped", "input", owner, "field", field)
+		return false
+	}
+	return true
+}
+
+// deprecateRenderedFields adds @deprecated with the reason of a @gqlDeprecated type to the
+// rendered fields that are not deprecated themselves
+func (g *Generator) deprecateRenderedFields(fields []renderedField, reason string) []renderedField {
+	for i, field := range fields {
+		content := strings.TrimSuffix(field.Content, "\n")
+		// The field definition is the last line, after its description
+		if strings.Contains(content[strings.LastIndex(content, "\n")+1:], " @deprecated") {
+			continue
+		}
+		buf := strings.Builder{}
+		buf.WriteString(content)
+		g.writeDeprecatedDirective(&buf, true, reason)
+		buf.WriteString("\n")
+		fields[i].Content = buf.String()
+	}
+	return fields
+}
+
+// deprecatedDescription prepends "[DEPRECATED] reason" to the description of a @gqlDeprecated type or enum
+func deprecatedDescription(reason, description string) string {
+	result := "[DEPRECATED]"
+	if reason != "" {
+		result += " " + reason
+	}
+	if description != "" {
+		result += " " + description
+	}
+	return result
+}
+
+// definitionDescription returns the description of a @gqlType, @gqlInput, @gqlInterface or
+// @gqlUnion definition, or "" when it was taken from the doc comment and those are disabled
+func (g *Generator) definitionDescription(description string, docComment bool) string {
+	if docComment && g.Config.DisableDocDescriptions {
+		return ""
+	}
+	return description
+}
+
+// formatDescription renders a description at the given indent: single-line text
+// becomes an inline "string", multi-line text a """block""" with each line indented.
+func formatDescription(text, indent string) string {
+	if !strings.Contains(text, "\n") {
+		escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text)
+		return fmt.Sprintf("%s\"%s\"\n", indent, escaped)
+	}
+	buf := strings.Builder{}
+	buf.WriteString(indent + `"""` + "\n")
+	for _, line := range strings.Split(strings.ReplaceAll(text, `"""`, `\"""`), "\n") {
+		if line == "" {
+			buf.WriteString("\n")
+			continue
+		}
+		buf.WriteString(indent + line + "\n")
+	}
+	buf.WriteString(indent + `"""` + "\n")
+	return buf.String()
+}
+
+// writeDeprecatedDirective writes the @deprecated directive with optional reason
+func (g *Generator) writeDeprecatedDirective(buf *strings.Builder, deprecated bool, reason string) {
+	if !deprecated {
+		return
+	}
+	if reason != "" {
+		// Escape quotes in the reason
+		escapedReason := strings.ReplaceAll(reason, `"`, `\"`)
+		fmt.Fprintf(buf, ` @deprecated(reason: "%s")`, escapedReason)
+	} else {
+		buf.WriteString(" @deprecated")
+	}
+}
+
+// writeFederationFieldDirectives writes the @external and @shareable federation directives
+func (g *Generator) writeFederationFieldDirectives(buf *strings.Builder, opt FieldOptions) {
+	if opt.External {
+		buf.WriteString(" @external")
+	}
+	if opt.Shareable {
+		buf.WriteString(" @shareable")
+	}
+}
+
+// writeGoEnumDirective writes the @goEnum directive if enabled
+func (g *Generator) writeGoEnumDirective(buf *strings.Builder, valuePkgPath string, valueGoName string) {
+	if g.Config.UseGqlGenDirectives {
+		fmt.Fprintf(buf, " @goEnum(value: \"%s.%s\")", valuePkgPath, valueGoName)
+		g.usedDirectives["goEnum"] = true
+	}
+}
+
+// generateTypeFromDef generates a GraphQL type from a specific TypeDefinition
+// generateTypeFromDef generates a GraphQL type with generation context for tracking
+func (g *Generator) generateTypeFromDef(typeSpec *ast.TypeSpec, st *ast.StructType, d StructDirectives, typeDef TypeDefinition, ctx *GenerationContext) string {
+	// @gqlFile sends the type to its own file (auto-generated definitions only carry it in d)
+	outputFile := typeDef.OutputFile
+	if outputFile == "" {
+		outputFile = d.OutputFile
+	}
+	if routedCtx := g.fileOverrideContext(outputFile, ctx); routedCtx != nil {
+		g.writeRoutedContent(routedCtx.OutputFile, g.generateTypeFromDef(typeSpec, st, d, typeDef, routedCtx))
+		return ""
+	}
+
+	typeName := typeSpec.Name.Name
+	name := g.typeDefName(typeName, d, typeDef)
+
+	buf := strings.Builder{}
+
+	// Extensions are written as "extend type X", which cannot carry a description
+	extend := typeDef.Extend
+
+	// Add description if present
+	description := g.definitionDescription(typeDef.Description, typeDef.DocComment)
+	deprecateFields := typeDef.Deprecated && g.Config.TypeDeprecationMode != TypeDeprecationDescription
+	if typeDef.Deprecated && !deprecateFields {
+		description = deprecatedDescription(typeDef.DeprecatedReason, description)
+	}
+	if description != "" && !extend {
+		buf.WriteString(formatDescription(description, ""))
+	}
+
+	// Projection types (from:"User") take their fields from another struct
+	fieldStruct, fieldGoType := st, typeName
+	if typeDef.From != "" {
+		srcSpec, exists := g.P.StructTypes[typeDef.From]
+		if !exists {
+			slog.Warn("Source struct for @gqlType from: not found", "type", name, "from", typeDef.From)
+			return ""
+		}
+		srcStruct, ok := srcSpec.Type.(*ast.StructType)
+		if !ok {
+			slog.Warn("Source for @gqlType from: is not a struct", "type", name, "from", typeDef.From)
+			return ""
+		}
+		fieldStruct, fieldGoType = srcStruct, typeDef.From
+	}
+
+	// Type declaration
+	if extend {
+		buf.WriteString("extend ")
+	}
+	buf.WriteString(fmt.Sprintf("type %s", name))
+
+	// Relay types implement the Node interface
+	relay := typeDef.Relay && g.Config.Relay.Enabled
+	implements := typeDef.Implements
+	if relay {
+		implements = append([]string{"Node"}, implements...)
+	}
+
+	// Add implements clause for the implements parameter and embedded @gqlInterface structs
+	// (the clause comes before @goModel)
+	g.writeImplementsClause(&buf, fieldStruct, implements)
+
+	// Add federation @key directives
+	if g.Config.Federation {
+		for _, key := range typeDef.Keys {
+			fmt.Fprintf(&buf, " @key(fields: %s)", strconv.Quote(key))
+		}
+	}
+
+	// Add @goModel directive if enabled (synthesized Relay types have no Go model,
+	// and extensions take it from the base type)
+	if !g.syntheticTypes[typeName] && !extend {
+		g.writeGoModelDirective(&buf, typeSpec.Name.Name, d.UseModelDirective)
+	}
+
+	buf.WriteString(" {\n")
+
+	// Ensure context has TypeSubstitutions initialized
+	if ctx.TypeSubstitutions == nil {
+		ctx.TypeSubstitutions = make(map[string]ast.Expr)
+	}
+
+	// Generate fields from struct (use typeDef.IgnoreAll instead of d.TypeIgnoreAll)
+	renderedFields := g.orderedFieldsForTypeNamed(fieldStruct, d, typeDef.IgnoreAll, false, name, fieldGoType, ctx, "", nil, typeDef.FieldOrder)
+	if len(typeDef.Fields) > 0 {
+		renderedFields = filterRenderedFields(renderedFields, typeDef.Fields, name)
+	}
+	if deprecateFields {
+		renderedFields = g.deprecateRenderedFields(renderedFields, typeDef.DeprecatedReason)
+	}
+	fields := joinRenderedFields(renderedFields)
+	if relay && !hasRenderedField(renderedFields, "id", "ID!") {
+		slog.Warn("Relay type needs an id: ID! field to implement Node", "type", name)
+	}
+
+	// Extra fields from @gqlTypeExtraField annotations that apply to this type
+	var extraFields []ExtraField
+	for _, ef := range d.TypeExtraFields {
+		if !shouldApplyExtraField(ef, name) {
+			continue
+		}
+		if deprecateFields && !ef.Deprecated {
+			ef.Deprecated, ef.DeprecatedReason = true, typeDef.DeprecatedReason
+		}
+		extraFields = append(extraFields, ef)
+	}
+
+	// Root structs contribute their fields to the merged Query/Mutation/Subscription type
+	if typeDef.Root != "" {
+		if rootName := rootTypeName(typeDef.Root); rootName != "" {
+			g.addRootFields(rootName, name, renderedFields, extraFields, ctx)
+			return ""
+		}
+		slog.Warn("Unknown @gqlType root, generating a regular type", "type", name, "root", typeDef.Root)
+	}
+
+	if len(fields) == 0 && len(extraFields) == 0 && !g.Config.IncludeEmptyTypes {
+		return "" // Skip empty types
+	}
+
+	buf.WriteString(fields)
+	for _, ef := range extraFields {
+		g.writeExtraField(&buf, ef, name, false)
+	}
+
+	buf.WriteString("}\n\n")
+
+	// Register generated item
+	goType := g.P.GetPackageImportPath(typeName, g.Config.ModelPath) + "." + typeName
+	if g.syntheticTypes[typeName] {
+		goType = ""
+	}
+	g.registerGeneratedItem(GQLSchemaItem{
+		OutputFile:    ctx.OutputFile,
+		GoSourceFile:  g.P.SourceFiles[typeName],
+		GoType:        goType,
+		GoTypeName:    typeName,
+		GQLName:       name,
+		GQLKind:       "type",
+		Strategy:      ctx.Strategy,
+		AutoGenerated: g.AutoGeneratedTypes[typeName],
+		Namespace:     ctx.Namespace,
+		Extension:     extend,
+		Fields:        schemaFields(renderedFields, d.TypeExtraFields, name),
+	})
+
+	// Append key-value pair types synthesized for map fields
+	buf.WriteString(g.flushPairTypes())
+
+	// Append the Relay edge and connection types
+	if relay {
+		buf.WriteString(g.generateRelayTypes(name, ctx))
+	}
+
+	return buf.String()
+}
+
+// relayField is a field of a synthesized Relay helper type
+type relayField struct {
+	goName  string
+	name    string
+	gqlType string
+}
+
+// generateRelayTypes generates the edge and connection types of a @gqlType(relay:true) type,
+// preceded by the Node interface and the PageInfo type the first time it is called
+func (g *Generator) generateRelayTypes(name string, ctx *GenerationContext) string {
+	buf := strings.Builder{}
+
+	if !g.relayBaseEmitted {
+		g.relayBaseEmitted = true
+		buf.WriteString(g.generateRelayNodeInterface(ctx))
+		buf.WriteString(g.generateRelayType("PageInfo", []relayField{
+			{"HasNextPage", "hasNextPage", "Boolean!"},
+			{"HasPreviousPage", "hasPreviousPage", "Boolean!"},
+			{"StartCursor", "startCursor", "String"},
+			{"EndCursor", "endCursor", "String"},
+		}, ctx))
+	}
+
+	edgeName := name + g.Config.Relay.EdgeSuffix
+	connectionName := name + g.Config.Relay.ConnectionSuffix
+	buf.WriteString(g.generateRelayType(edgeName, []relayField{
+		{"Cursor", "cursor", "String!"},
+		{"Node", "node", name + "!"},
+	}, ctx))
+	buf.WriteString(g.generateRelayType(connectionName, []relayField{
+		{"Edges", "edges", "[" + edgeName + "!]!"},
+		{"PageInfo", "pageInfo", "PageInfo!"},
+	}, ctx))
+
+	return buf.String()
+}
+
+// generateRelayType generates a Relay helper type from the Go model of the same name when it
+// exists (so @goModel points at it), or from a struct synthesized from fields otherwise
+// Go models that are generated on their own (annotated or auto-generated) are skipped
+func (g *Generator) generateRelayType(name string, fields []relayField, ctx *GenerationContext) string {
+	if typeSpec, exists := g.P.StructTypes[name]; exists {
+		if st, ok := typeSpec.Type.(*ast.StructType); ok {
+			d := ParseDirectives(typeSpec, g.P.TypeToDecl[name])
+			if d.HasTypeDirective || d.SkipType || g.AutoGeneratedTypes[name] {
+				return ""
+			}
+			return g.generateTypeFromDef(typeSpec, st, d, TypeDefinition{Name: name}, ctx)
+		}
+	}
+
+	st := &ast.StructType{Fields: &ast.FieldList{}}
+	for _, f := range fields {
+		st.Fields.List = append(st.Fields.List, &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(f.goName)},
+			Type:  ast.NewIdent("string"),
+			Tag:   &ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf("`gql:\"%s,type:%s\"`", f.name, f.gqlType)},
+		})
+	}
+	typeSpec := &ast.TypeSpec{Name: ast.NewIdent(name), Type: st}
+	g.syntheticTypes[name] = true
+
+	return g.generateTypeFromDef(typeSpec, st, StructDirectives{GQLName: name}, TypeDefinition{Name: name}, ctx)
+}
+
+// generateRelayNodeInterface generates the Relay Node interface unless a @gqlInterface named Node exists
+func (g *Generator) generateRelayNodeInterface(ctx *GenerationContext) string {
+	for typeName, typeSpec := range g.P.StructTypes {
+		d := ParseDirectives(typeSpec, g.P.TypeToDecl[typeName])
+		for _, interfaceDef := range d.Interfaces {
+			if g.interfaceName(typeName, interfaceDef) == "Node" {
+				return ""
+			}
+		}
+	}
+
+	g.registerGeneratedItem(GQLSchemaItem{
+		OutputFile: ctx.OutputFile,
+		GQLName:    "Node",
+		GQLKind:    "interface",
+		Strategy:   ctx.Strategy,
+		Namespace:  ctx.Namespace,
+	})
+
+	return "interface Node {\n" + g.Config.indent() + "id: ID!\n}\n\n"
+}
+
+// hasRenderedField reports whether fields contain a field with the given name and type
+func hasRenderedField(fields []renderedField, name, fieldType string) bool {
+	for _, f := range fields {
+		if f.Name == name && f.Type == fieldType {
+			return true
+		}
+	}
+	return false
+}
+
+// generateInterfaceFromDef generates a GraphQL interface from a specific InterfaceDefinition
+// The interface fields are taken from the annotated struct, optionally narrowed by the fields whitelist
+func (g *Generator) generateInterfaceFromDef(typeSpec *ast.TypeSpec, st *ast.StructType, d StructDirectives, interfaceDef InterfaceDefinition, ctx *GenerationContext) string {
+	typeName := typeSpec.Name.Name
+	name := g.interfaceName(typeName, interfaceDef)
+
+	buf := strings.Builder{}
+
+	// Add description if present
+	if description := g.definitionDescription(interfaceDef.Description, interfaceDef.DocComment); description != "" {
+		buf.WriteString(formatDescription(description, ""))
+	}
+
+	// Interface declaration
+	buf.WriteString(fmt.Sprintf("interface %s", name))
+
+	// Interfaces may implement other interfaces they embed
+	g.writeImplementsClause(&buf, st, nil)
+
+	// Add @goModel directive if enabled
+	g.writeGoModelDirective(&buf, typeName, d.UseModelDirective)
+
+	buf.WriteString(" {\n")
+
+	// Ensure context has TypeSubstitutions initialized
+	if ctx.TypeSubstitutions == nil {
+		ctx.TypeSubstitutions = make(map[string]ast.Expr)
+	}
+
+	renderedFields := g.orderedFieldsForTypeNamed(st, d, interfaceDef.IgnoreAll, false, name, typeName, ctx, "", nil, nil)
+	if len(interfaceDef.Fields) > 0 {
+		renderedFields = filterRenderedFields(renderedFields, interfaceDef.Fields, name)
+	}
+	fields := joinRenderedFields(renderedFields)
+
+	// GraphQL interfaces must declare at least one field
+	if len(fields) == 0 {
+		slog.Warn("Skipping @gqlInterface without fields", "interface", name, "type", typeName)
+		return ""
+	}
+
+	buf.WriteString(fields)
+	buf.WriteString("}\n\n")
+
+	// Register generated item
+	pkgPath := g.P.GetPackageImportPath(typeName, g.Config.ModelPath)
+	g.registerGeneratedItem(GQLSchemaItem{
+		OutputFile:   ctx.OutputFile,
+		GoSourceFile: g.P.SourceFiles[typeName],
+		GoType:       pkgPath + "." + typeName,
+		GoTypeName:   typeName,
+		GQLName:      name,
+		GQLKind:      "interface",
+		Strategy:     ctx.Strategy,
+		Namespace:    ctx.Namespace,
+		Fields:       schemaFields(renderedFields, nil, name),
+	})
+
+	// Append key-value pair types synthesized for map fields
+	buf.WriteString(g.flushPairTypes())
+
+	return buf.String()
+}
+
+// generateUnion generates a GraphQL union from a specific UnionDefinition
+// Members are resolved to the names of their generated types; members that are not generated are reported
+func (g *Generator) generateUnion(typeSpec *ast.TypeSpec, d StructDirectives, unionDef UnionDefinition, ctx *GenerationContext) string {
+	typeName := typeSpec.Name.Name
+	name := g.unionName(typeName, unionDef)
+
+	var members []string
+	seen := make(map[string]bool)
+	for _, member := range unionDef.Members {
+		memberName, generated := g.resolveUnionMember(member)
+		if !generated {
+			slog.Warn("@gqlUnion member is not a generated type", "union", name, "member", member)
+		}
+		if !seen[memberName] {
+			seen[memberName] = true
+			members = append(members, memberName)
+		}
+	}
+
+	if len(members) == 0 {
+		slog.Warn("Skipping @gqlUnion without members", "union", name, "type", typeName)
+		return ""
+	}
+
+	buf := strings.Builder{}
+
+	// Add description if present
+	if description := g.definitionDescription(unionDef.Description, unionDef.DocComment); description != "" {
+		buf.WriteString(formatDescription(description, ""))
+	}
+
+	// Union declaration
+	buf.WriteString(fmt.Sprintf("union %s", name))
+
+	// Add @goModel directive if enabled
+	g.writeGoModelDirective(&buf, typeName, d.UseModelDirective)
+
+	buf.WriteString(fmt.Sprintf(" = %s\n\n", strings.Join(members, " | ")))
+
+	// Register generated item
+	pkgPath := g.P.GetPackageImportPath(typeName, g.Config.ModelPath)
+	g.registerGeneratedItem(GQLSchemaItem{
+		OutputFile:   ctx.OutputFile,
+		GoSourceFile: g.P.SourceFiles[typeName],
+		GoType:       pkgPath + "." + typeName,
+		GoTypeName:   typeName,
+		GQLName:      name,
+		GQLKind:      "union",
+		Strategy:     ctx.Strategy,
+		Namespace:    ctx.Namespace,
+	})
+
+	return buf.String()
+}
+
+// resolveUnionMember returns the GraphQL type name for a union member and whether that type is generated
+// Members may be Go struct names (named like generateTypeFromDef names them) or GraphQL type names
+func (g *Generator) resolveUnionMember(member string) (string, bool) {
+	if typeSpec, exists := g.P.StructTypes[member]; exists {
+		if genDecl := g.P.TypeToDecl[member]; genDecl != nil {
+			d := ParseDirectives(typeSpec, genDecl)
+			if d.HasTypeDirective {
+				return g.typeDefName(member, d, d.Types[0]), !d.SkipType
+			}
+			return g.defaultTypeName(d.GQLName), !d.SkipType && g.AutoGeneratedTypes[member]
+		}
+	}
+
+	// Not a Go struct name, accept it if a scanned struct generates a type with this name
+	for _, info := range g.P.ScannedTypes {
+		for _, generatedType := range info.GeneratedTypes {
+			if generatedType == member {
+				return member, true
+			}
+		}
+	}
+	return member, false
+}
+
+// typeDefName returns the GraphQL name of a @gqlType definition: its custom name, the Go name
+// as written with rawName:true, or the default name otherwise
+func (g *Generator) typeDefName(goName string, d StructDirectives, typeDef TypeDefinition) string {
+	if typeDef.Name != "" {
+		return typeDef.Name
+	}
+	if typeDef.RawName {
+		return goName
+	}
+	// Apply prefix/suffix stripping and addition only when no custom name is specified
+	return g.defaultTypeName(d.GQLName)
+}
+
+// referenceTypeName returns the name fields reference a Go type by: the GraphQL name of its
+// enum, scalar, type, interface or union definition, so renamed definitions are referenced as defined
+func (g *Generator) referenceTypeName(goName string) string {
+	name := goName
+	if enumType := g.P.EnumTypes[goName]; enumType != nil {
+		name = enumType.Name
+	} else if scalarType := g.P.ScalarTypes[goName]; scalarType != nil {
+		name = scalarType.Name
+	} else if typeSpec := g.P.StructTypes[goName]; typeSpec != nil {
+		if genDecl := g.P.TypeToDecl[goName]; genDecl != nil {
+			d := ParseDirectives(typeSpec, genDecl)
+			switch {
+			case d.HasTypeDirective:
+				name = g.typeDefName(goName, d, d.Types[0])
+			case d.HasInterfaceDirective:
+				name = g.interfaceName(goName, d.Interfaces[0])
+			case d.HasUnionDirective:
+				name = g.unionName(goName, d.Unions[0])
+			default:
+				name = g.defaultTypeName(d.GQLName)
+			}
+		}
+	}
+	if name != goName {
+		g.referenceNames[name] = goName
+	}
+	return name
+}
+
+// defaultTypeName applies the configured prefix/suffix stripping and addition and the type name case to a type name
+// This is the name generateTypeFromDef uses for types without a custom name
+func (g *Generator) defaultTypeName(name string) string {
+	name = StripPrefixSuffix(name, g.Config.StripPrefix, g.Config.StripSuffix)
+	if g.Config.AddTypePrefix != "" {
+		name = g.Config.AddTypePrefix + name
+	}
+	if g.Config.AddTypeSuffix != "" {
+		name = name + g.Config.AddTypeSuffix
+	}
+	return TransformTypeName(name, g.Config.TypeNameCase)
+}
+
+// writeGroupedUnions writes the unions collected by the grouping strategies (package, namespace)
+func (g *Generator) writeGroupedUnions(buf *strings.Builder, unions map[string][]UnionDefinition, ctx *GenerationContext) {
+	// Sort type names for deterministic output
+	typeNames := make([]string, 0, len(unions))
+	for typeName := range unions {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	for _, typeName := range typeNames {
+		typeSpec := g.P.StructTypes[typeName]
+		d := ParseDirectives(typeSpec, g.P.TypeToDecl[typeName])
+
+		for _, unionDef := range unions[typeName] {
+			buf.WriteString(g.generateUnion(typeSpec, d, unionDef, ctx))
+		}
+	}
+}
+
+// interfaceName returns the GraphQL name of an interface declared on the given struct
+func (g *Generator) interfaceName(typeName string, interfaceDef InterfaceDefinition) string {
+	if interfaceDef.Name != "" {
+		return interfaceDef.Name
+	}
+	return StripPrefixSuffix(typeName, g.Config.StripPrefix, g.Config.StripSuffix)
+}
+
+// unionName returns the GraphQL name of a union: its custom name or the Go name with prefixes/suffixes stripped
+func (g *Generator) unionName(typeName string, unionDef UnionDefinition) string {
+	if unionDef.Name != "" {
+		return unionDef.Name
+	}
+	return StripPrefixSuffix(typeName, g.Config.StripPrefix, g.Config.StripSuffix)
+}
+
+// writeGroupedInterfaces writes the interfaces collected by the grouping strategies (package, namespace)
+func (g *Generator) writeGroupedInterfaces(buf *strings.Builder, interfaces map[string][]InterfaceDefinition, ctx *GenerationContext) {
+	// Sort type names for deterministic output
+	typeNames := make([]string, 0, len(interfaces))
+	for typeName := range interfaces {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	for _, typeName := range typeNames {
+		typeSpec := g.P.StructTypes[typeName]
+		structType := typeSpec.Type.(*ast.StructType)
+		d := ParseDirectives(typeSpec, g.P.TypeToDecl[typeName])
+
+		for _, interfaceDef := range interfaces[typeName] {
+			buf.WriteString(g.generateInterfaceFromDef(typeSpec, structType, d, interfaceDef, ctx))
+		}
+	}
+}
+
+// implementedInterfaces returns the explicitly listed interfaces followed by the
+// GraphQL interfaces declared by the structs embedded in st, without duplicates
+func (g *Generator) implementedInterfaces(st *ast.StructType, explicit []string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range explicit {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, f := range st.Fields.List {
+		if f.Names != nil {
+			continue
+		}
+		if ParseFieldOptions(f, g.Config).Ignore {
+			continue
+		}
+
+		embeddedName := extractBaseTypeName(f.Type)
+		typeSpec := g.P.StructTypes[embeddedName]
+		genDecl := g.P.TypeToDecl[embeddedName]
+		if typeSpec == nil || genDecl == nil {
+			continue
+		}
+
+		d := ParseDirectives(typeSpec, genDecl)
+		if d.SkipType {
+			continue
+		}
+		for _, interfaceDef := range d.Interfaces {
+			name := g.interfaceName(embeddedName, interfaceDef)
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// writeImplementsClause writes the implements clause for the explicit interfaces and those embedded in st
+func (g *Generator) writeImplementsClause(buf *strings.Builder, st *ast.StructType, explicit []string) {
+	if interfaces := g.implementedInterfaces(st, explicit); len(interfaces) > 0 {
+		fmt.Fprintf(buf, " implements %s", strings.Join(interfaces, " & "))
+	}
+}
+
+// generateInputFromDef generates a GraphQL input from a specific InputDefinition
+// generateInputFromDef generates a GraphQL input with generation context for tracking
+func (g *Generator) generateInputFromDef(typeSpec *ast.TypeSpec, st *ast.StructType, d StructDirectives, inputDef InputDefinition, ctx *GenerationContext) string {
+	// @gqlFile sends the input to its own file (auto-generated definitions only carry it in d)
+	outputFile := inputDef.OutputFile
+	if outputFile == "" {
+		outputFile = d.OutputFile
+	}
+	if routedCtx := g.fileOverrideContext(outputFile, ctx); routedCtx != nil {
+		g.writeRoutedContent(routedCtx.OutputFile, g.generateInputFromDef(typeSpec, st, d, inputDef, routedCtx))
+		return ""
+	}
+
+	typeName := typeSpec.Name.Name
+	inputName := inputDef.Name
+	if inputName == "" {
+		inputName = g.defaultInputName(d)
+	}
+
+	// from: takes the fields (and the Go model) from another struct, checked to exist by checkInputSources
+	// Field rules (@gqlIgnoreAll) come from that struct, extra fields from the annotated one
+	fieldDirectives := d
+	if inputDef.FromType != "" {
+		fromSpec := g.P.StructTypes[inputDef.FromType]
+		fromStruct, ok := fromSpec.Type.(*ast.StructType)
+		if !ok {
+			return ""
+		}
+		st = fromStruct
+		typeName = inputDef.FromType
+		fieldDirectives = ParseDirectives(fromSpec, g.P.TypeToDecl[inputDef.FromType])
+	}
+
+	buf := strings.Builder{}
+
+	// Add description if present (extensions cannot carry one)
+	if description := g.definitionDescription(inputDef.Description, inputDef.DocComment); description != "" && !inputDef.Extend {
+		buf.WriteString(formatDescription(description, ""))
+	}
+
+	// Input declaration
+	if inputDef.Extend {
+		buf.WriteString("extend ")
+	}
+	buf.WriteString(fmt.Sprintf("input %s", inputName))
+
+	// Add @oneOf directive for tagged-union inputs
+	if inputDef.OneOf {
+		buf.WriteString(" @oneOf")
+	}
+
+	// Add @goModel directive if enabled (extensions take it from the base input)
+	if !inputDef.Extend {
+		g.writeGoModelDirective(&buf, typeName, d.UseModelDirective)
+	}
+
+	buf.WriteString(" {\n")
+
+	// Ensure context has TypeSubstitutions initialized
+	if ctx.TypeSubstitutions == nil {
+		ctx.TypeSubstitutions = make(map[string]ast.Expr)
+	}
+
+	// Required fields whose object type has no input cannot be rendered, fail instead of dropping them
+	g.checkInputFieldTypes(st, fieldDirectives, inputDef.IgnoreAll, inputName, typeName)
+
+	// @oneOf requires every field to be nullable: fields are rendered as if tagged optional,
+	// which takes precedence over required, the same way embedded fields inherit optional
+	var fieldOpts *FieldOptions
+	if inputDef.OneOf {
+		fieldOpts = &FieldOptions{Optional: true}
+	}
+
+	// Generate fields from struct (use inputDef.IgnoreAll instead of d.InputIgnoreAll)
+	renderedFields := g.orderedFieldsForTypeNamed(st, fieldDirectives, inputDef.IgnoreAll, true, inputName, typeName, ctx, "", fieldOpts, nil)
+	renderedFields = g.appendEmbeddedInputFields(renderedFields, inputDef.EmbedInputs, inputName, ctx, fieldOpts)
+
+	inputExtraFields := d.InputExtraFields
+	if inputDef.OneOf {
+		inputExtraFields = oneOfExtraFields(renderedFields, inputExtraFields, inputName)
+	}
+	fields := joinRenderedFields(renderedFields)
+
+	// Count applicable extra fields for this input
+	applicableExtraFields := 0
+	for _, ef := range inputExtraFields {
+		if shouldApplyExtraField(ef, inputName) {
+			applicableExtraFields++
+		}
+	}
+
+	if len(fields) == 0 && applicableExtraFields == 0 && !g.Config.IncludeEmptyTypes {
+		return "" // Skip empty inputs
+	}
+
+	buf.WriteString(fields)
+
+	// Add extra fields from @gqlInputExtraField annotations
+	for _, ef := range inputExtraFields {
+		// Check if this field applies to this input
+		if !shouldApplyExtraField(ef, inputName) {
+			continue
+		}
+		g.writeExtraField(&buf, ef, inputName, true)
+	}
+
+	buf.WriteString("}\n\n")
+
+	// Register generated item
+	pkgPath := g.P.GetPackageImportPath(typeName, g.Config.ModelPath)
+	g.registerGeneratedItem(GQLSchemaItem{
+		OutputFile:    ctx.OutputFile,
+		GoSourceFile:  g.P.SourceFiles[typeName],
+		GoType:        pkgPath + "." + typeName,
+		GoTypeName:    typeName,
+		GQLName:       inputName,
+		GQLKind:       "input",
+		Strategy:      ctx.Strategy,
+		AutoGenerated: g.AutoGeneratedInputs[typeName],
+		Namespace:     ctx.Namespace,
+		Extension:     inputDef.Extend,
+		Fields:        schemaFields(renderedFields, inputExtraFields, inputName),
+	})
+
+	// Append key-value pair inputs synthesized for map fields
+	buf.WriteString(g.flushPairTypes())
+
+	return buf.String()
+}
+
+// oneOfExtraFields makes the extra fields of a @oneOf input nullable, as the spec requires, and
+// warns about the fields whose required tag or non-null extra type is ignored because of it
+func oneOfExtraFields(fields []renderedField, extraFields []ExtraField, inputName string) []ExtraField {
+	var relaxed []string
+	for _, field := range fields {
+		if field.Required {
+			relaxed = append(relaxed, field.Name)
+		}
+	}
+
+	nullableExtra := make([]ExtraField, len(extraFields))
+	for i, ef := range extraFields {
+		if shouldApplyExtraField(ef, inputName) && strings.HasSuffix(ef.Type, "!") {
+			relaxed = append(relaxed, ef.Name)
+			ef.Type = strings.TrimSuffix(ef.Type, "!")
+		}
+		nullableExtra[i] = ef
+	}
+
+	if len(relaxed) > 0 {
+		slog.Warn("@oneOf input fields must be nullable, required is ignored",
+			"input", inputName, "fields", strings.Join(relaxed, ", "))
+	}
+	return nullableExtra
+}
+
+// appendEmbeddedInputFields inlines the fields of the inputs listed in embedInput, as if their structs were embedded
+// Fields the input already has are kept, the embedded ones with the same name are dropped
+// fieldOpts are inherited by the inlined fields like the options of an embedded field
+func (g *Generator) appendEmbeddedInputFields(fields []renderedField, embedInputs []string, inputName string, ctx *GenerationContext, fieldOpts *FieldOptions) []renderedField {
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		seen[field.Name] = true
+	}
+
+	for _, embedInput := range embedInputs {
+		goTypeName, ok := g.resolveEmbeddedInput(embedInput)
+		if !ok {
+			slog.Warn("embedInput is not a @gqlInput struct", "input", inputName, "embedInput", embedInput)
+			continue
+		}
+
+		embedded := &ast.Field{Type: ast.NewIdent(goTypeName)}
+		for _, field := range g.expandEmbeddedFieldNamed(embedded, StructDirectives{}, false, true, inputName, ctx, "", fieldOpts) {
+			if !seen[field.Name] {
+				seen[field.Name] = true
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	return fields
+}
+
+// resolveEmbeddedInput returns the Go struct behind an embedInput entry and whether it is a @gqlInput struct
+// Entries may be Go struct names or GraphQL input names (named like generateInputFromDef names them)
+func (g *Generator) resolveEmbeddedInput(name string) (string, bool) {
+	if typeSpec, exists := g.P.StructTypes[name]; exists {
+		d := ParseDirectives(typeSpec, g.P.TypeToDecl[name])
+		return name, d.HasInputDirective
+	}
+
+	for _, typeName := range g.P.TypeNames {
+		typeSpec, exists := g.P.StructTypes[typeName]
+		if !exists {
+			continue
+		}
+		d := ParseDirectives(typeSpec, g.P.TypeToDecl[typeName])
+		for _, inputDef := range d.Inputs {
+			inputName := inputDef.Name
+			if inputName == "" {
+				inputName = g.defaultInputName(d)
+			}
+			if inputName == name {
+				return typeName, true
+			}
+		}
+	}
+	return name, false
+}
+
+// checkInputFieldTypes records required fields of an input whose type is an object type without an input
+// Such fields would otherwise be silently dropped from the input (or reference an object type)
+func (g *Generator) checkInputFieldTypes(st *ast.StructType, d StructDirectives, inputIgnoreAll bool, inputName string, goTypeName string) {
+	ignoreAll := d.IgnoreAll || inputIgnoreAll
+	for _, f := range st.Fields.List {
+		// Embedded fields are flattened into the input, their own fields are checked when rendered
+		if f.Names == nil {
+			continue
+		}
+
+		opt := ParseFieldOptions(f, g.Config)
+		if !shouldIncludeField(opt, ignoreAll, true, inputName) {
+			continue
+		}
+		// Custom types and optional fields are the user's responsibility
+		if opt.Type != "" || opt.Optional {
+			continue
+		}
+
+		refType := g.objectTypeWithoutInput(f.Type)
+		if refType == "" {
+			continue
+		}
+
+		fieldName := opt.Name
+		if fieldName == "" {
+			fieldName = ResolveFieldName(f, g.Config)
+		}
+		g.MissingInputFields = append(g.MissingInputFields, MissingInputReference{
+			InputName:      inputName,
+			GoTypeName:     goTypeName,
+			GoFieldName:    f.Names[0].Name,
+			FieldName:      fieldName,
+			ReferencedType: refType,
+		})
+	}
+}
+
+// objectTypeWithoutInput returns the name of the struct referenced by expr (through pointers and lists)
+// when it has neither a @gqlInput directive nor an auto-generated input, or "" otherwise
+// Scalars, enums and scalar mappings never need an input
+func (g *Generator) objectTypeWithoutInput(expr ast.Expr) string {
+	if resolveScalarMapping(expr, g, g.Config) != "" {
+		return ""
+	}
+
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return g.objectTypeWithoutInput(t.X)
+	case *ast.ArrayType:
+		return g.objectTypeWithoutInput(t.Elt)
+	}
+
+	typeName := extractBaseTypeName(expr)
+	if typeName == "" {
+		return ""
+	}
+	if _, isEnum := g.P.EnumTypes[typeName]; isEnum {
+		return ""
+	}
+	typeSpec := g.P.StructTypes[typeName]
+	genDecl := g.P.TypeToDecl[typeName]
+	if typeSpec == nil || genDecl == nil {
+		return ""
+	}
+	if _, isStruct := typeSpec.Type.(*ast.StructType); !isStruct {
+		return ""
+	}
+
+	// @gqlIgnore types are reported as out of scope instead
+	d := ParseDirectives(typeSpec, genDecl)
+	if d.HasInputDirective || d.SkipType || g.AutoGeneratedInputs[typeName] {
+		return ""
+	}
+	return typeName
+}
+
+// missingInputFieldsError builds the error for required input fields referencing object types
+func (g *Generator) missingInputFieldsError() error {
+	var msg strings.Builder
+	msg.WriteString("input fields reference object types without an input:\n")
+	for _, ref := range g.MissingInputFields {
+		msg.WriteString(fmt.Sprintf("  - %s.%s (Go: %s.%s) references %s, which has no input\n",
+			ref.InputName, ref.FieldName, ref.GoTypeName, ref.GoFieldName, ref.ReferencedType))
+	}
+	msg.WriteString("\nTo resolve this, add @gqlInput to the referenced types, enable auto_generate, ")
+	msg.WriteString("or mark the fields optional/ignored in the input")
+	return fmt.Errorf("%s", msg.String())
+}
+
+// checkInputSources returns an error listing @gqlInput(from:...) references to structs that were not scanned
+func (g *Generator) checkInputSources() error {
+	var msg strings.Builder
+	for _, typeName := range g.P.TypeNames {
+		typeSpec, exists := g.P.StructTypes[typeName]
+		if !exists {
+			continue
+		}
+		d := ParseDirectives(typeSpec, g.P.TypeToDecl[typeName])
+		for _, inputDef := range d.Inputs {
+			if inputDef.FromType == "" {
+				continue
+			}
+			if fromSpec, exists := g.P.StructTypes[inputDef.FromType]; exists {
+				if _, isStruct := fromSpec.Type.(*ast.StructType); isStruct {
+					continue
+				}
+			}
+			inputName := inputDef.Name
+			if inputName == "" {
+				inputName = g.defaultInputName(d)
+			}
+			msg.WriteString(fmt.Sprintf("  - %s (on %s) takes its fields from %s, which is not a scanned struct\n",
+				inputName, typeName, inputDef.FromType))
+		}
+	}
+	if msg.Len() == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("inputs reference unknown structs with from:\n%s\nTo resolve this, add the package declaring them to 'packages'", msg.String())
+}
+
+// enumValueGoRef returns the package path and identifier @goEnum references for an enum value.
+// value: overrides the identifier; "import/path.Name" also overrides the package, and a short
+// qualifier ("models.Name") is resolved through the imports of the file declaring the const.
+// ok is false when that qualifier is not imported there
+func (g *Generator) enumValueGoRef(enumType *EnumType, value EnumValue) (pkgPath string, goName string, ok bool) {
+	// Use the package path where the const value is defined, not where the type is defined
+	if value.PackagePath != "" {
+		// Use the stored package info for this specific const value
+		pkgPath = g.P.GetPackageImportPathFromFile(value.PackagePath, value.PackageName, g.Config.ModelPath)
+	} else {
+		// Fallback to using the enum type's package (for backwards compatibility)
+		pkgPath = g.P.GetPackageImportPath(enumType.GoTypeName, g.Config.ModelPath)
+	}
+
+	idx := strings.LastIndex(value.GoValue, ".")
+	if idx == -1 {
+		if value.GoValue != "" {
+			return pkgPath, value.GoValue, true
+		}
+		return pkgPath, value.GoName, true
+	}
+
+	qualifier, goName := value.GoValue[:idx], value.GoValue[idx+1:]
+	if strings.Contains(qualifier, "/") {
+		return qualifier, goName, true
+	}
+
+	// Inline values have no const, their qualifier is looked up in the file of the enum type
+	file, pkgName := value.PackagePath, value.PackageName
+	if file == "" {
+		file, pkgName = g.P.EnumSourceFiles[enumType.GoTypeName], g.P.PackageNames[enumType.GoTypeName]
+	}
+	if qualifier == pkgName {
+		return pkgPath, goName, true
+	}
+	if importPath := importPathInFile(qualifier, file); importPath != "" {
+		return importPath, goName, true
+	}
+	return qualifier, goName, false
+}
+
+// checkEnumGoValues returns an error listing @gqlEnumValue(value:...) identifiers qualified by
+// a package the file declaring them does not import; only @goEnum directives reference them
+func (g *Generator) checkEnumGoValues() error {
+	if !g.Config.UseGqlGenDirectives {
+		return nil
+	}
+
+	var msg strings.Builder
+	for _, enumName := range g.P.EnumNames {
+		enumType := g.P.EnumTypes[enumName]
+		if enumType == nil {
+			continue
+		}
+		for _, value := range enumType.Values {
+			if _, _, ok := g.enumValueGoRef(enumType, value); !ok {
+				msg.WriteString(fmt.Sprintf("  - enum %s: value %s references %q, whose package is not imported\n",
+					enumType.Name, value.GraphQLName, value.GoValue))
+			}
+		}
+	}
+	if msg.Len() == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("unresolved @gqlEnumValue(value:...) packages:\n%s\nTo resolve this, import the package in the file declaring the enum value or use its full import path (value:\"example.com/app/models.Name\")", msg.String())
+}
+
+// checkEnumValues returns an error listing enum values that are not legal GraphQL names
+// (including true, false and null) and values produced by more than one const of an enum
+func (g *Generator) checkEnumValues() error {
+	var msg strings.Builder
+	for _, enumName := range g.P.EnumNames {
+		enumType := g.P.EnumTypes[enumName]
+		if enumType == nil {
+			continue
+		}
+
+		consts := make(map[string]string) // GraphQL value -> first Go const producing it
+		for _, value := range enumType.Values {
+			// Inline values (@gqlEnum(values:...)) have no Go const
+			if value.GoName == "" {
+				if !isValidEnumValueName(value.GraphQLName) {
+					msg.WriteString(fmt.Sprintf("  - enum %s: inline value %q is not a valid GraphQL name\n", enumType.Name, value.GraphQLName))
+				} else if _, exists := consts[value.GraphQLName]; exists {
+					msg.WriteString(fmt.Sprintf("  - enum %s: inline value %s is listed twice\n", enumType.Name, value.GraphQLName))
+				}
+				consts[value.GraphQLName] = value.GraphQLName
+				continue
+			}
+			if !isValidEnumValueName(value.GraphQLName) {
+				msg.WriteString(fmt.Sprintf("  - enum %s: const %s produces %q, which is not a valid GraphQL name\n",
+					enumType.Name, value.GoName, value.GraphQLName))
+				continue
+			}
+			if first, exists := consts[value.GraphQLName]; exists {
+				msg.WriteString(fmt.Sprintf("  - enum %s: consts %s and %s both produce %s\n",
+					enumType.Name, first, value.GoName, value.GraphQLName))
+				continue
+			}
+			consts[value.GraphQLName] = value.GoName
+		}
+	}
+	if msg.Len() == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("invalid enum values:\n%s\nTo resolve this, set the GraphQL name with @gqlEnumValue(name:\"...\") or fix the @gqlEnum(values:\"...\") list", msg.String())
+}
+
+// isValidEnumValueName reports whether name matches /[_A-Za-z][_0-9A-Za-z]*/ and is not true, false or null
+func isValidEnumValueName(name string) bool {
+	if name == "" || name == "true" || name == "false" || name == "null" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// inputEdge is a non-null, non-list field of an input referencing another input
+type inputEdge struct {
+	input  string
+	field  string
+	target string
+}
+
+// checkInputCycles finds cycles of inputs referencing each other through non-null fields, which no
+// input value can satisfy. Direct self-references are already nullable, lists end the recursion.
+// With RelaxInputCycles the field closing each cycle is made nullable, otherwise the cycles are reported
+func (g *Generator) checkInputCycles(fileContents map[string]string) error {
+	isInput := make(map[string]bool)
+	for _, item := range g.GeneratedItems {
+		if item.GQLKind == "input" {
+			isInput[item.GQLName] = true
+		}
+	}
+
+	edges := make(map[string][]inputEdge)
+	seen := make(map[inputEdge]bool)
+	for _, item := range g.GeneratedItems {
+		if item.GQLKind != "input" {
+			continue
+		}
+		for _, field := range item.Fields {
+			if strings.HasPrefix(field.Type, "[") || !strings.HasSuffix(field.Type, "!") {
+				continue
+			}
+			target := strings.TrimSuffix(field.Type, "!")
+			edge := inputEdge{input: item.GQLName, field: field.Name, target: target}
+			if !isInput[target] || target == item.GQLName || seen[edge] {
+				continue
+			}
+			seen[edge] = true
+			edges[item.GQLName] = append(edges[item.GQLName], edge)
+		}
+	}
+
+	// Depth-first search, an edge back into an input on the current path closes a cycle
+	var cycles [][]inputEdge
+	onPath := make(map[string]bool)
+	done := make(map[string]bool)
+	var path []inputEdge
+	var visit func(string)
+	visit = func(input string) {
+		onPath[input] = true
+		for _, edge := range edges[input] {
+			switch {
+			case onPath[edge.target]:
+				start := 0
+				for i := len(path) - 1; i >= 0; i-- {
+					if path[i].input == edge.target {
+						start = i
+						break
+					}
+				}
+				cycle := append(append([]inputEdge{}, path[start:]...), edge)
+				cycles = append(cycles, cycle)
+			case !done[edge.target]:
+				path = append(path, edge)
+				visit(edge.target)
+				path = path[:len(path)-1]
+			}
+		}
+		onPath[input] = false
+		done[input] = true
+	}
+	for _, input := range sortedKeys(isInput) {
+		if !done[input] {
+			visit(input)
+		}
+	}
+	if len(cycles) == 0 {
+		return nil
+	}
+
+	if g.Config.RelaxInputCycles {
+		for _, cycle := range cycles {
+			closing := cycle[len(cycle)-1]
+			g.relaxInputField(fileContents, closing.input, closing.field, closing.target+"!")
+			slog.Warn("Made input field nullable to break a non-null reference cycle",
+				"input", closing.input, "field", closing.field, "cycle", formatInputCycle(cycle))
+		}
+		return nil
 	}
-}
 
-// writeGoFieldDirective writes the @goField(forceResolver: true) directive if enabled
-func (g *Generator) writeGoFieldDirective(buf *strings.Builder, forceResolver bool) {
-	if g.Config.UseGqlGenDirectives && forceResolver {
-		buf.WriteString(" @goField(forceResolver: true)")
+	var msg strings.Builder
+	for _, cycle := range cycles {
+		msg.WriteString(fmt.Sprintf("  - %s\n", formatInputCycle(cycle)))
 	}
+	return fmt.Errorf("non-null input reference cycles:\n%s\nTo resolve this, make one field of each cycle optional "+
+		"(gql:\"name,optional\") or set relax_input_cycles", msg.String())
 }
 
-// writeDeprecatedDirective writes the @deprecated directive with optional reason
-func (g *Generator) writeDeprecatedDirective(buf *strings.Builder, deprecated bool, reason string) {
-	if !deprecated {
-		return
-	}
-	if reason != "" {
-		// Escape quotes in the reason
-		escapedReason := strings.ReplaceAll(reason, `"`, `\"`)
-		fmt.Fprintf(buf, ` @deprecated(reason: "%s")`, escapedReason)
-	} else {
-		buf.WriteString(" @deprecated")
+// formatInputCycle renders a cycle as "AInput.b -> BInput.a -> AInput"
+func formatInputCycle(cycle []inputEdge) string {
+	var parts []string
+	for _, edge := range cycle {
+		parts = append(parts, edge.input+"."+edge.field)
 	}
+	return strings.Join(parts, " -> ") + " -> " + cycle[0].input
 }
 
-// writeGoEnumDirective writes the @goEnum directive if enabled
-func (g *Generator) writeGoEnumDirective(buf *strings.Builder, valuePkgPath string, valueGoName string) {
-	if g.Config.UseGqlGenDirectives {
-		fmt.Fprintf(buf, " @goEnum(value: \"%s.%s\")", valuePkgPath, valueGoName)
+// relaxInputField drops the non-null marker of an input field in the rendered files and the generated items
+func (g *Generator) relaxInputField(fileContents map[string]string, input, field, fieldType string) {
+	fieldPrefix := g.Config.indent() + field + ": " + fieldType
+	for file, content := range fileContents {
+		lines := strings.Split(content, "\n")
+		inInput := false
+		for i, line := range lines {
+			switch {
+			case strings.HasPrefix(line, "input "+input+" ") || strings.HasPrefix(line, "extend input "+input+" "):
+				inInput = true
+			case line == "}":
+				inInput = false
+			case inInput && strings.HasPrefix(line, fieldPrefix):
+				rest := line[len(fieldPrefix):]
+				if rest == "" || rest[0] == ' ' {
+					lines[i] = strings.TrimSuffix(fieldPrefix, "!") + rest
+				}
+			}
+		}
+		fileContents[file] = strings.Join(lines, "\n")
 	}
-}
 
-// generateTypeFromDef generates a GraphQL type from a specific TypeDefinition
-// generateTypeFromDef generates a GraphQL type with generation context for tracking
-func (g *Generator) generateTypeFromDef(typeSpec *ast.TypeSpec, st *ast.StructType, d StructDirectives, typeDef TypeDefinition, ctx *GenerationContext) string {
-	typeName := typeSpec.Name.Name
-	name := d.GQLName
-	if typeDef.Name != "" {
-		// Use custom type name from @gqlType annotation
-		name = typeDef.Name
-	} else {
-		// Apply prefix/suffix stripping only when no custom name is specified
-		name = StripPrefixSuffix(name, g.Config.StripPrefix, g.Config.StripSuffix)
-		// Apply prefix/suffix addition
-		if g.Config.AddTypePrefix != "" {
-			name = g.Config.AddTypePrefix + name
+	for i := range g.GeneratedItems {
+		item := &g.GeneratedItems[i]
+		if item.GQLKind != "input" || item.GQLName != input {
+			continue
 		}
-		if g.Config.AddTypeSuffix != "" {
-			name = name + g.Config.AddTypeSuffix
+		for j := range item.Fields {
+			if item.Fields[j].Name == field && item.Fields[j].Type == fieldType {
+				item.Fields[j].Type = strings.TrimSuffix(fieldType, "!")
+			}
 		}
 	}
+}
 
-	buf := strings.Builder{}
-
-	// Add description if present
-	if typeDef.Description != "" {
-		buf.WriteString(fmt.Sprintf("\"\"\"%s\"\"\"\n", typeDef.Description))
+// checkNameCollisions returns an error listing every GraphQL name produced by more
+// than one definition (types, inputs, enums, scalars, interfaces and unions share one namespace)
+func (g *Generator) checkNameCollisions() error {
+	type definition struct {
+		kind   string
+		goType string
+		file   string
 	}
-
-	// Type declaration
-	buf.WriteString(fmt.Sprintf("type %s", name))
-
-	// Add @goModel directive if enabled
-	g.writeGoModelDirective(&buf, typeSpec.Name.Name, d.UseModelDirective)
-
-	buf.WriteString(" {\n")
-
-	// Ensure context has TypeSubstitutions initialized
-	if ctx.TypeSubstitutions == nil {
-		ctx.TypeSubstitutions = make(map[string]ast.Expr)
+	type definitionKey struct {
+		name   string
+		kind   string
+		goType string
 	}
 
-	// Generate fields from struct (use typeDef.IgnoreAll instead of d.TypeIgnoreAll)
-	fields := g.generateFieldsForTypeNamed(st, d, typeDef.IgnoreAll, false, name, typeName, ctx, "", nil)
+	var names []string
+	definitions := make(map[string][]definition)
+	seen := make(map[definitionKey]bool)
+	for _, item := range g.GeneratedItems {
+		// Extensions add to a definition instead of redefining it
+		if item.Extension {
+			continue
+		}
 
-	// Count applicable extra fields for this type
-	applicableExtraFields := 0
-	for _, ef := range d.TypeExtraFields {
-		if shouldApplyExtraField(ef, name) {
-			applicableExtraFields++
+		// The same definition emitted more than once (e.g. into several files) is not a collision
+		key := definitionKey{name: item.GQLName, kind: item.GQLKind, goType: item.GoType}
+		if seen[key] {
+			continue
 		}
-	}
+		seen[key] = true
 
-	if len(fields) == 0 && applicableExtraFields == 0 && !g.Config.IncludeEmptyTypes {
-		return "" // Skip empty types
+		def := definition{kind: item.GQLKind, goType: item.GoType, file: item.GoSourceFile}
+		if _, ok := definitions[item.GQLName]; !ok {
+			names = append(names, item.GQLName)
+		}
+		definitions[item.GQLName] = append(definitions[item.GQLName], def)
 	}
 
-	buf.WriteString(fields)
-
-	// Add extra fields from @gqlTypeExtraField annotations
-	for _, ef := range d.TypeExtraFields {
-		// Check if this field applies to this type
-		if !shouldApplyExtraField(ef, name) {
+	var msg strings.Builder
+	for _, name := range names {
+		defs := definitions[name]
+		if len(defs) < 2 {
 			continue
 		}
-		if ef.Description != "" {
-			buf.WriteString(fmt.Sprintf("\t\"\"\"%s\"\"\"\n", ef.Description))
+		msg.WriteString(fmt.Sprintf("  - %s is defined by:\n", name))
+		for _, def := range defs {
+			if def.file != "" {
+				msg.WriteString(fmt.Sprintf("      %s %s (%s)\n", def.kind, def.goType, def.file))
+			} else {
+				msg.WriteString(fmt.Sprintf("      %s %s\n", def.kind, def.goType))
+			}
 		}
-		buf.WriteString(fmt.Sprintf("\t%s: %s", ef.Name, ef.Type))
-		g.writeGoFieldDirective(&buf, true)
-		buf.WriteString("\n")
+	}
+	if msg.Len() == 0 {
+		return nil
 	}
 
-	buf.WriteString("}\n\n")
+	return fmt.Errorf("duplicate GraphQL names:\n%s\nTo resolve this, rename the types with name:, strip/add prefixes, "+
+		"or set allow_duplicate_names to merge them intentionally", msg.String())
+}
 
-	// Register generated item
-	pkgPath := g.P.GetPackageImportPath(typeName, g.Config.ModelPath)
-	g.registerGeneratedItem(GQLSchemaItem{
-		OutputFile:    ctx.OutputFile,
-		GoSourceFile:  g.P.SourceFiles[typeName],
-		GoType:        pkgPath + "." + typeName,
-		GoTypeName:    typeName,
-		GQLName:       name,
-		GQLKind:       "type",
-		Strategy:      ctx.Strategy,
-		AutoGenerated: g.AutoGeneratedTypes[typeName],
-		Namespace:     ctx.Namespace,
-	})
+// defaultInputName returns the name of an input generated without an explicit name
+// Field references to the input must use the same name, so both go through this helper
+func (g *Generator) defaultInputName(d StructDirectives) string {
+	// Apply prefix/suffix stripping before adding "Input" suffix
+	baseName := StripPrefixSuffix(d.GQLName, g.Config.StripPrefix, g.Config.StripSuffix)
 
-	return buf.String()
+	// For auto-generated inputs, check if the type name already ends with "Input"
+	// to avoid CreateUserInput -> CreateUserInputInput
+	inputName := baseName
+	if !strings.HasSuffix(baseName, "Input") {
+		inputName = baseName + "Input"
+	}
+
+	// Apply prefix/suffix addition
+	if g.Config.AddInputPrefix != "" {
+		inputName = g.Config.AddInputPrefix + inputName
+	}
+	if g.Config.AddInputSuffix != "" {
+		inputName = inputName + g.Config.AddInputSuffix
+	}
+	return TransformTypeName(inputName, g.Config.TypeNameCase)
 }
 
-// generateInputFromDef generates a GraphQL input from a specific InputDefinition
-// generateInputFromDef generates a GraphQL input with generation context for tracking
-func (g *Generator) generateInputFromDef(typeSpec *ast.TypeSpec, st *ast.StructType, d StructDirectives, inputDef InputDefinition, ctx *GenerationContext) string {
-	typeName := typeSpec.Name.Name
-	inputName := inputDef.Name
-	if inputName == "" {
-		// Apply prefix/suffix stripping before adding "Input" suffix
-		baseName := StripPrefixSuffix(d.GQLName, g.Config.StripPrefix, g.Config.StripSuffix)
+// generateFieldsForType generates fields with specific ignoreAll setting
+// func (g *Generator) generateFieldsForType(st *ast.StructType, d StructDirectives, typeIgnoreAll bool, forInput bool) string {
+// 	return g.generateFieldsForTypeNamed(st, d, typeIgnoreAll, forInput, "")
+// }
 
-		// For auto-generated inputs, check if the type name already ends with "Input"
-		// to avoid CreateUserInput -> CreateUserInputInput
-		if !strings.HasSuffix(baseName, "Input") {
-			inputName = baseName + "Input"
-		} else {
-			inputName = baseName
-		}
+// renderedField is a single generated GraphQL field (including its description)
+// together with the names used to order it in the output
+type renderedField struct {
+	Name     string // GraphQL field name
+	JSONName string // json tag name, falls back to the GraphQL field name
+	Type     string // GraphQL field type (e.g., "[String!]!")
+	Content  string // rendered field definition
+	Required bool   // tagged required
+	Depth    int    // embedding depth, 0 for the struct's own fields
+}
 
-		// Apply prefix/suffix addition
-		if g.Config.AddInputPrefix != "" {
-			inputName = g.Config.AddInputPrefix + inputName
-		}
-		if g.Config.AddInputSuffix != "" {
-			inputName = inputName + g.Config.AddInputSuffix
+// generateFieldsForTypeNamed generates fields with specific ignoreAll setting and type/input name for filtering
+// pinnedOrder optionally lists field names to render first ("*" marks the remaining fields)
+func (g *Generator) generateFieldsForTypeNamed(st *ast.StructType, d StructDirectives, typeIgnoreAll bool, forInput bool, typeName string, goTypeName string, ctx *GenerationContext, fieldPrefix string, embeddedOpts *FieldOptions, pinnedOrder []string) string {
+	return joinRenderedFields(g.orderedFieldsForTypeNamed(st, d, typeIgnoreAll, forInput, typeName, goTypeName, ctx, fieldPrefix, embeddedOpts, pinnedOrder))
+}
+
+// orderedFieldsForTypeNamed collects the fields of a struct and applies the configured ordering
+func (g *Generator) orderedFieldsForTypeNamed(st *ast.StructType, d StructDirectives, typeIgnoreAll bool, forInput bool, typeName string, goTypeName string, ctx *GenerationContext, fieldPrefix string, embeddedOpts *FieldOptions, pinnedOrder []string) []renderedField {
+	fields := g.collectFieldsForTypeNamed(st, d, typeIgnoreAll, forInput, typeName, goTypeName, ctx, fieldPrefix, embeddedOpts)
+	fields = dedupeRenderedFields(fields, g.Config.DuplicateFields, typeName)
+	sortRenderedFields(fields, g.Config.FieldOrder)
+	return applyPinnedFieldOrder(fields, pinnedOrder)
+}
+
+// filterRenderedFields keeps only the fields whose GraphQL name is listed in names
+// Names that match no field are reported, since they usually indicate a typo
+func filterRenderedFields(fields []renderedField, names []string, typeName string) []renderedField {
+	keep := make(map[string]bool, len(names))
+	for _, name := range names {
+		keep[name] = true
+	}
+
+	var result []renderedField
+	for _, field := range fields {
+		if keep[field.Name] {
+			result = append(result, field)
+			delete(keep, field.Name)
 		}
 	}
 
-	buf := strings.Builder{}
+	for _, name := range names {
+		if keep[name] {
+			slog.Warn("Field listed in @gqlType fields: not found", "type", typeName, "field", name)
+		}
+	}
+	return result
+}
 
-	// Add description if present
-	if inputDef.Description != "" {
-		buf.WriteString(fmt.Sprintf("\"\"\"%s\"\"\"\n", inputDef.Description))
+// formatFieldArgs renders field arguments as "(first: Int = 10, after: String)", or "" without arguments
+func formatFieldArgs(args []FieldArg) string {
+	if len(args) == 0 {
+		return ""
+	}
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = fmt.Sprintf("%s: %s", arg.Name, arg.Type)
+		if arg.Default != "" {
+			parts[i] += " = " + arg.Default
+		}
 	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
 
-	// Input declaration
-	buf.WriteString(fmt.Sprintf("input %s", inputName))
+// formatDefaultValue renders a default value literal for a field of the given GraphQL type
+// Numbers, booleans, enum values, lists and objects are written as-is, anything else is quoted as a string
+func (g *Generator) formatDefaultValue(value string, fieldType string) string {
+	if strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{") || value == "null" {
+		return value
+	}
 
-	// Add @goModel directive if enabled
-	g.writeGoModelDirective(&buf, typeSpec.Name.Name, d.UseModelDirective)
+	baseType := strings.Trim(fieldType, "[]!")
+	switch baseType {
+	case "Int", "Int32", "Int64", "Uint", "Uint64", "Float", "Float32", "Float64", "Boolean":
+		return value
+	}
+	for _, enumType := range g.P.EnumTypes {
+		if enumType.Name == baseType || enumType.GoTypeName == baseType {
+			return value
+		}
+	}
+	return strconv.Quote(value)
+}
 
-	buf.WriteString(" {\n")
+// joinRenderedFields concatenates the rendered field definitions
+func joinRenderedFields(fields []renderedField) string {
+	buf := strings.Builder{}
+	for _, field := range fields {
+		buf.WriteString(field.Content)
+	}
+	return buf.String()
+}
 
-	// Ensure context has TypeSubstitutions initialized
-	if ctx.TypeSubstitutions == nil {
-		ctx.TypeSubstitutions = make(map[string]ast.Expr)
+// schemaFields lists the rendered fields and the extra fields applied to the named type or input
+func schemaFields(fields []renderedField, extraFields []ExtraField, name string) []GQLSchemaField {
+	result := make([]GQLSchemaField, 0, len(fields))
+	for _, field := range fields {
+		result = append(result, GQLSchemaField{Name: field.Name, Type: field.Type})
+	}
+	for _, ef := range extraFields {
+		if shouldApplyExtraField(ef, name) {
+			result = append(result, GQLSchemaField{Name: ef.Name, Type: ef.Type})
+		}
 	}
+	return result
+}
 
-	// Generate fields from struct (use inputDef.IgnoreAll instead of d.InputIgnoreAll)
-	fields := g.generateFieldsForTypeNamed(st, d, inputDef.IgnoreAll, true, inputName, typeName, ctx, "", nil)
+// dedupeRenderedFields drops fields whose GraphQL name was already rendered (e.g., an ID in two
+// embedded structs). Like Go field promotion, the shallowest field is kept and the first one wins
+// among equally deep fields; with DuplicateFieldsLast the last one is kept. The kept field takes
+// the position of the first one
+func dedupeRenderedFields(fields []renderedField, policy DuplicateFieldPolicy, typeName string) []renderedField {
+	indexByName := make(map[string]int, len(fields))
+	result := make([]renderedField, 0, len(fields))
+	for _, field := range fields {
+		idx, exists := indexByName[field.Name]
+		if !exists {
+			indexByName[field.Name] = len(result)
+			result = append(result, field)
+			continue
+		}
 
-	// Count applicable extra fields for this input
-	applicableExtraFields := 0
-	for _, ef := range d.InputExtraFields {
-		if shouldApplyExtraField(ef, inputName) {
-			applicableExtraFields++
+		slog.Warn("Duplicate field name, keeping the "+string(policy)+" definition",
+			"type", typeName, "field", field.Name)
+		if policy == DuplicateFieldsLast || field.Depth < result[idx].Depth {
+			result[idx] = field
 		}
 	}
+	return result
+}
 
-	if len(fields) == 0 && applicableExtraFields == 0 && !g.Config.IncludeEmptyTypes {
-		return "" // Skip empty inputs
+// sortRenderedFields orders fields according to the configured FieldOrder
+// The sort is stable, so fields with equal keys keep their struct order
+func sortRenderedFields(fields []renderedField, order FieldOrder) {
+	switch order {
+	case FieldOrderAlpha:
+		sort.SliceStable(fields, func(i, j int) bool {
+			return fields[i].Name < fields[j].Name
+		})
+	case FieldOrderJSON:
+		sort.SliceStable(fields, func(i, j int) bool {
+			return fields[i].JSONName < fields[j].JSONName
+		})
+	case FieldOrderRequiredFirst:
+		sort.SliceStable(fields, func(i, j int) bool {
+			iRequired := strings.HasSuffix(fields[i].Type, "!")
+			jRequired := strings.HasSuffix(fields[j].Type, "!")
+			if iRequired != jRequired {
+				return iRequired
+			}
+			return fields[i].Name < fields[j].Name
+		})
 	}
+}
 
-	buf.WriteString(fields)
+// applyPinnedFieldOrder moves the fields named in order to the front, in the listed order
+// "*" stands for the remaining fields (in their current order); names listed after it go last
+// Without "*" the remaining fields follow the pinned ones. Unknown names are ignored
+func applyPinnedFieldOrder(fields []renderedField, order []string) []renderedField {
+	if len(order) == 0 {
+		return fields
+	}
 
-	// Add extra fields from @gqlInputExtraField annotations
-	for _, ef := range d.InputExtraFields {
-		// Check if this field applies to this input
-		if !shouldApplyExtraField(ef, inputName) {
+	indexByName := make(map[string]int, len(fields))
+	for i, field := range fields {
+		indexByName[field.Name] = i
+	}
+
+	used := make([]bool, len(fields))
+	var head, tail []renderedField
+	afterRest := false
+	for _, name := range order {
+		if name == "*" {
+			afterRest = true
+			continue
+		}
+		idx, ok := indexByName[name]
+		if !ok || used[idx] {
 			continue
 		}
-		if ef.Description != "" {
-			buf.WriteString(fmt.Sprintf("\t\"\"\"%s\"\"\"\n", ef.Description))
+		used[idx] = true
+		if afterRest {
+			tail = append(tail, fields[idx])
+		} else {
+			head = append(head, fields[idx])
 		}
-		buf.WriteString(fmt.Sprintf("\t%s: %s\n", ef.Name, ef.Type))
 	}
 
-	buf.WriteString("}\n\n")
-
-	// Register generated item
-	pkgPath := g.P.GetPackageImportPath(typeName, g.Config.ModelPath)
-	g.registerGeneratedItem(GQLSchemaItem{
-		OutputFile:    ctx.OutputFile,
-		GoSourceFile:  g.P.SourceFiles[typeName],
-		GoType:        pkgPath + "." + typeName,
-		GoTypeName:    typeName,
-		GQLName:       inputName,
-		GQLKind:       "input",
-		Strategy:      ctx.Strategy,
-		AutoGenerated: g.AutoGeneratedInputs[typeName],
-		Namespace:     ctx.Namespace,
-	})
-
-	return buf.String()
+	result := make([]renderedField, 0, len(fields))
+	result = append(result, head...)
+	for i, field := range fields {
+		if !used[i] {
+			result = append(result, field)
+		}
+	}
+	return append(result, tail...)
 }
 
-// generateFieldsForType generates fields with specific ignoreAll setting
-// func (g *Generator) generateFieldsForType(st *ast.StructType, d StructDirectives, typeIgnoreAll bool, forInput bool) string {
-// 	return g.generateFieldsForTypeNamed(st, d, typeIgnoreAll, forInput, "")
-// }
+// collectFieldsForTypeNamed renders the fields of a struct (expanding embedded structs) in struct order
+func (g *Generator) collectFieldsForTypeNamed(st *ast.StructType, d StructDirectives, typeIgnoreAll bool, forInput bool, typeName string, goTypeName string, ctx *GenerationContext, fieldPrefix string, embeddedOpts *FieldOptions) []renderedField {
+	var fields []renderedField
 
-// generateFieldsForTypeNamed generates fields with specific ignoreAll setting and type/input name for filtering
-func (g *Generator) generateFieldsForTypeNamed(st *ast.StructType, d StructDirectives, typeIgnoreAll bool, forInput bool, typeName string, goTypeName string, ctx *GenerationContext, fieldPrefix string, embeddedOpts *FieldOptions) string {
-	buf := strings.Builder{}
+	// Track the type while its fields are collected so embedded cycles can be detected
+	if goTypeName != "" {
+		g.expandingTypes[goTypeName] = true
+		defer delete(g.expandingTypes, goTypeName)
+	}
 
 	// Determine which ignoreAll flag to use
 	ignoreAll := d.IgnoreAll || typeIgnoreAll
@@ -1585,7 +3709,10 @@ func (g *Generator) generateFieldsForTypeNamed(st *ast.StructType, d StructDirec
 		if f.Names == nil {
 			// This is an embedded field - expand its fields with context
 			embeddedFields := g.expandEmbeddedFieldNamed(f, d, ignoreAll, forInput, typeName, ctx, fieldPrefix, embeddedOpts)
-			buf.WriteString(embeddedFields)
+			for i := range embeddedFields {
+				embeddedFields[i].Depth++
+			}
+			fields = append(fields, embeddedFields...)
 			continue
 		}
 
@@ -1626,6 +3753,8 @@ func (g *Generator) generateFieldsForTypeNamed(st *ast.StructType, d StructDirec
 		// Determine if field should be included using new type-specific logic
 		include := shouldIncludeField(opt, ignoreAll, forInput, typeName)
 		if !include {
+			g.verbose("field excluded", "type", typeName, "field", f.Names[0].Name,
+				"reason", fieldExclusionReason(opt, ignoreAll, forInput, typeName))
 			continue
 		}
 
@@ -1642,7 +3771,7 @@ func (g *Generator) generateFieldsForTypeNamed(st *ast.StructType, d StructDirec
 							Type:  substituted,
 						}
 						embeddedFields := g.expandEmbeddedFieldNamed(syntheticField, d, ignoreAll, forInput, typeName, ctx, fieldPrefix, embeddedOpts)
-						buf.WriteString(embeddedFields)
+						fields = append(fields, embeddedFields...)
 						continue
 					}
 				}
@@ -1659,10 +3788,23 @@ func (g *Generator) generateFieldsForTypeNamed(st *ast.StructType, d StructDirec
 			fieldName = fieldPrefix + strings.ToUpper(fieldName[:1]) + fieldName[1:]
 		}
 
+		// Interfaces with methods are skipped unless they should render as the any scalar
+		if opt.Type == "" && !g.Config.InterfacesAsAnyScalar && g.isInterfaceWithMethods(f.Type) {
+			slog.Warn("Skipping field of interface type (set interfaces_as_any_scalar to map it to the any scalar)",
+				"type", typeName, "field", fieldName, "goType", ExprToGoType(f.Type))
+			continue
+		}
+
 		// Resolve field type with context for type parameter substitution
 		fieldType := opt.Type
 		if fieldType == "" {
-			if forInput {
+			if mapType := g.pairsMapType(f.Type, opt); mapType != nil {
+				// Render the map as a list of synthesized key-value pair types
+				fieldType = g.mapPairFieldType(mapType, forInput, ctx)
+			} else if inline := g.inlineStructType(f.Type); inline != nil {
+				// Render the anonymous struct as a synthesized type named after its parent and field
+				fieldType = g.inlineStructFieldType(f.Type, inline, goTypeName+f.Names[0].Name, forInput, ctx)
+			} else if forInput {
 				// For inputs, convert type references to input references with context
 				fieldType = ExprToGraphQLTypeForInputWithContext(f.Type, g.Config.KnownScalars, g.P.EnumTypes, g.Config, ctx, g)
 			} else {
@@ -1672,16 +3814,21 @@ func (g *Generator) generateFieldsForTypeNamed(st *ast.StructType, d StructDirec
 
 		// Skip field if type resolution returned empty (means the type has no input and shouldn't be in inputs)
 		if fieldType == "" {
+			g.verbose("field excluded", "type", typeName, "field", f.Names[0].Name,
+				"reason", "type has no input", "goType", ExprToGoType(f.Type))
 			continue
 		}
 
 		// Check if field type is out of scope (if no custom type was specified)
+		// Excluded types (see Config.ExcludeTypes) and @gqlIgnore types are always treated as out of scope
+		placeholderTodo := ""
 		if opt.Type == "" {
 			baseTypeName := g.extractBaseTypeName(fieldType)
-			if baseTypeName != "" && !g.isTypeInScope(baseTypeName) {
+			excluded := g.isExcludedType(f.Type) || g.isIgnoredType(f.Type)
+			if baseTypeName != "" && (excluded || !g.isTypeInScope(baseTypeName)) {
 				// Try to load the type on-demand from the field expression to check for annotations
 				// This handles types from packages not in the scan list
-				if g.P.HasGQLAnnotations(f.Type, goTypeName) {
+				if !excluded && g.P.HasGQLAnnotations(f.Type, goTypeName) {
 					// Type has GQL annotations - it's intentionally scoped, don't flag as out-of-scope
 				} else if g.Config.AutoGenerate.SuppressGenericTypeWarnings && g.isTypeParameter(baseTypeName) {
 					// Skip adding to OutOfScopeTypes - it's just a type parameter
@@ -1708,11 +3855,15 @@ func (g *Generator) generateFieldsForTypeNamed(st *ast.StructType, d StructDirec
 						ReferencedType: baseTypeName,
 					}
 					g.OutOfScopeTypes[baseTypeName] = append(g.OutOfScopeTypes[baseTypeName], ref)
+					g.verbose("field references out-of-scope type", "type", typeName, "field", goFieldName,
+						"referencedType", baseTypeName, "action", g.Config.AutoGenerate.OutOfScopeTypes)
 
 					// Handle based on configuration
 					switch g.Config.AutoGenerate.OutOfScopeTypes {
 					case OutOfScopeExclude:
 						// Skip this field - don't include in schema
+						g.verbose("field excluded", "type", typeName, "field", goFieldName,
+							"reason", "out-of-scope type "+baseTypeName)
 						continue
 					case OutOfScopeFail:
 						// Include field but error will be reported at end
@@ -1721,11 +3872,27 @@ func (g *Generator) generateFieldsForTypeNamed(st *ast.StructType, d StructDirec
 						// Continue with the field as-is
 					case OutOfScopeWarn:
 						// Continue with the field, warning will be logged later
+					case OutOfScopePlaceholder:
+						// Swap the undefined type for the placeholder scalar, keeping list/non-null wrappers
+						placeholderTodo = baseTypeName
+						fieldType = strings.Replace(fieldType, baseTypeName, g.Config.AutoGenerate.PlaceholderScalar, 1)
 					}
 				}
 			}
 		}
 
+		// A non-null input field referencing its own input can never be satisfied
+		// (GraphQL forbids non-null input cycles), so direct self-references are nullable
+		// List fields are fine as-is since an empty list terminates the recursion
+		if forInput && opt.Type == "" && !strings.HasPrefix(fieldType, "[") && extractBaseTypeName(f.Type) == goTypeName {
+			fieldType = strings.TrimSuffix(fieldType, "!")
+		}
+
+		// With default_nullable only required fields stay non-null (explicit type: overrides are kept)
+		if g.Config.DefaultNullable && opt.Type == "" {
+			fieldType = strings.TrimSuffix(fieldType, "!")
+		}
+
 		// Handle optional/required
 		if opt.Optional {
 			fieldType = strings.TrimSuffix(fieldType, "!")
@@ -1733,22 +3900,52 @@ func (g *Generator) generateFieldsForTypeNamed(st *ast.StructType, d StructDirec
 			fieldType = fieldType + "!"
 		}
 
+		buf := strings.Builder{}
+
+		// Flag placeholder fields so the missing type is easy to find
+		if placeholderTodo != "" {
+			buf.WriteString(fmt.Sprintf("%s# TODO: define scalar %s\n", g.Config.indent(), placeholderTodo))
+		}
+
 		// Add field with description if present
 		if opt.Description != "" {
-			buf.WriteString(fmt.Sprintf("    \"\"\"%s\"\"\"\n", opt.Description))
+			buf.WriteString(formatDescription(opt.Description, g.Config.indent()))
 		}
 
-		buf.WriteString(fmt.Sprintf("    %s: %s", fieldName, fieldType))
+		buf.WriteString(fmt.Sprintf("%s%s: %s", g.Config.indent(), fieldName, fieldType))
+
+		// Default values are only valid on input fields, they are dropped from type fields
+		if forInput && opt.Default != "" {
+			buf.WriteString(" = " + g.formatDefaultValue(opt.Default, fieldType))
+		}
 
-		// Add @goField directive if forceResolver is set
-		g.writeGoFieldDirective(&buf, opt.ForceResolver)
+		// Add @goField directive if forceResolver is set or the field is renamed
+		g.writeGoFieldDirective(&buf, opt.ForceResolver, fieldName, f.Names[0].Name)
 
 		// Add @deprecated directive if field is deprecated
-		g.writeDeprecatedDirective(&buf, opt.Deprecated, opt.DeprecatedReason)
+		g.writeDeprecatedDirective(&buf, g.allowDeprecation(opt.Deprecated, forInput, fieldType, opt.Default, typeName, fieldName), opt.DeprecatedReason)
+
+		// Add federation field directives (object types only)
+		if g.Config.Federation && !forInput {
+			g.writeFederationFieldDirectives(&buf, opt)
+		}
 		buf.WriteString("\n")
+
+		jsonName := JSONTagName(f)
+		if jsonName == "" {
+			jsonName = fieldName
+		}
+		g.verbose("field included", "type", typeName, "field", f.Names[0].Name, "name", fieldName, "gqlType", fieldType)
+		fields = append(fields, renderedField{
+			Name:     fieldName,
+			JSONName: jsonName,
+			Type:     fieldType,
+			Content:  buf.String(),
+			Required: opt.Required,
+		})
 	}
 
-	return buf.String()
+	return fields
 }
 
 // shouldApplyExtraField checks if an extra field should be applied to a given type/input name
@@ -1829,13 +4026,20 @@ func matchesTypeList(list []string, typeName string) bool {
 // }
 
 // expandEmbeddedFieldNamed recursively expands an embedded struct field into GraphQL fields with type name and context
-func (g *Generator) expandEmbeddedFieldNamed(f *ast.Field, d StructDirectives, ignoreAll bool, forInput bool, typeName string, ctx *GenerationContext, parentPrefix string, parentEmbeddedOpts *FieldOptions) string {
+func (g *Generator) expandEmbeddedFieldNamed(f *ast.Field, d StructDirectives, ignoreAll bool, forInput bool, typeName string, ctx *GenerationContext, parentPrefix string, parentEmbeddedOpts *FieldOptions) []renderedField {
 	// Parse the gql struct tag on the embedded field itself
 	embeddedFieldOpt := ParseFieldOptions(f, g.Config)
 
 	// If the embedded field is explicitly ignored, skip it entirely
 	if embeddedFieldOpt.Ignore {
-		return ""
+		g.verbose("embedded field excluded", "type", typeName, "embedded", ExprToGoType(f.Type), "reason", "ignored by tag")
+		return nil
+	}
+
+	// Excluded infrastructure types (e.g., sync.Mutex) contribute no fields
+	if g.isExcludedType(f.Type) {
+		g.verbose("embedded field excluded", "type", typeName, "embedded", ExprToGoType(f.Type), "reason", "exclude_types")
+		return nil
 	}
 
 	// Get the prefix from the embedded field's name tag (e.g., gql:"prefix")
@@ -1958,38 +4162,72 @@ func (g *Generator) expandEmbeddedFieldNamed(f *ast.Field, d StructDirectives, i
 	}
 
 	if embeddedTypeName == "" {
-		return "" // Unable to determine type name
+		return nil // Unable to determine type name
+	}
+
+	// Embedding a type that is already being expanded would recurse forever
+	// Its fields are already collected by the outer expansion, so skip it
+	if g.expandingTypes[embeddedTypeName] {
+		return nil
 	}
 
 	// Look up the embedded struct in the parser
 	typeSpec, exists := g.P.StructTypes[embeddedTypeName]
 	if !exists {
-		return "" // Embedded struct not found in parsed types
+		// Embedded struct not found in parsed types, its fields are dropped
+		g.recordDroppedEmbedded(f, typeName, embeddedTypeName)
+		return nil
 	}
 	embeddedStruct, ok := typeSpec.Type.(*ast.StructType)
 	if !ok {
-		return "" // Not a struct type
+		// Not a struct type (e.g., an embedded Go interface), there are no fields to expand
+		g.recordDroppedEmbedded(f, typeName, embeddedTypeName)
+		return nil
+	}
+
+	// Recursively generate fields for the embedded struct with the NEW context that has substitutions
+	// Create a minimal StructDirectives for the embedded type (no special directives)
+	embeddedDirectives := StructDirectives{
+		IgnoreAll: ignoreAll, // Inherit ignoreAll setting
 	}
 
 	// If generating for input and the embedded type should be auto-generated as input,
-	// mark it for generation
+	// mark it for generation. Generic definitions are only expanded with the substitutions
+	// of each embedding struct, the bare definition (Connection[T]) is never generated
 	if forInput {
-		if typeSpec != nil {
-			genDecl := g.P.TypeToDecl[embeddedTypeName]
-			directives := ParseDirectives(typeSpec, genDecl)
-			if !directives.HasInputDirective && !directives.SkipType {
-				g.AutoGeneratedInputs[embeddedTypeName] = true
-			}
+		directives := ParseDirectives(typeSpec, g.P.TypeToDecl[embeddedTypeName])
+		isGeneric := typeSpec.TypeParams != nil && typeSpec.TypeParams.NumFields() > 0
+		if !directives.HasInputDirective && !directives.SkipType && !isGeneric {
+			g.AutoGeneratedInputs[embeddedTypeName] = true
+		}
+		// An embedded @gqlInput struct keeps its own input rules (@gqlIgnoreAll, ignoreAll)
+		if directives.HasInputDirective && (directives.IgnoreAll || directives.Inputs[0].IgnoreAll) {
+			embeddedDirectives.IgnoreAll = true
 		}
 	}
 
-	// Recursively generate fields for the embedded struct with the NEW context that has substitutions
-	// Create a minimal StructDirectives for the embedded type (no special directives)
-	embeddedDirectives := StructDirectives{
-		IgnoreAll: ignoreAll, // Inherit ignoreAll setting
+	return g.collectFieldsForTypeNamed(embeddedStruct, embeddedDirectives, false, forInput, typeName, embeddedTypeName, embeddedCtx, fieldPrefix, &embeddedOpts)
+}
+
+// recordDroppedEmbedded records an embedded type whose fields could not be expanded
+// as an out-of-scope reference, so it is reported according to OutOfScopeTypes
+func (g *Generator) recordDroppedEmbedded(f *ast.Field, typeName string, embeddedTypeName string) {
+	// Types and inputs of the same struct expand the same embedded fields, report each once
+	for _, ref := range g.OutOfScopeTypes[embeddedTypeName] {
+		if ref.Embedded && ref.ParentGQLName == typeName {
+			return
+		}
 	}
 
-	return g.generateFieldsForTypeNamed(embeddedStruct, embeddedDirectives, false, forInput, typeName, embeddedTypeName, embeddedCtx, fieldPrefix, &embeddedOpts)
+	goFieldType := ExprToGoType(f.Type)
+	g.OutOfScopeTypes[embeddedTypeName] = append(g.OutOfScopeTypes[embeddedTypeName], OutOfScopeReference{
+		ParentGoType:   typeName,
+		ParentGQLName:  typeName,
+		GoFieldName:    embeddedTypeName,
+		GoFieldType:    goFieldType,
+		ReferencedType: embeddedTypeName,
+		Embedded:       true,
+	})
 }
 
 // generateGenericAliasType generates GraphQL type for a type alias to a generic instantiation
@@ -2067,8 +4305,8 @@ func (g *Generator) generateGenericAliasType(typeName string, indexListExpr *ast
 	// Generate as a regular type
 	var buf strings.Builder
 	buf.WriteString(fmt.Sprintf("type %s {\n", typeName))
-	fields := g.generateFieldsForTypeNamed(st, d, false, false, typeName, genericTypeName, newCtx, "", nil)
-	buf.WriteString(fields)
+	renderedFields := g.orderedFieldsForTypeNamed(st, d, false, false, typeName, genericTypeName, newCtx, "", nil, nil)
+	buf.WriteString(joinRenderedFields(renderedFields))
 	buf.WriteString("}\n\n")
 
 	// Register the generated item
@@ -2094,6 +4332,7 @@ func (g *Generator) generateGenericAliasType(typeName string, indexListExpr *ast
 		Strategy:      ctx.Strategy,
 		AutoGenerated: false, // This is explicitly defined by user
 		Namespace:     ctx.Namespace,
+		Fields:        schemaFields(renderedFields, nil, typeName),
 	})
 
 	return buf.String()
@@ -2181,8 +4420,9 @@ func (g *Generator) generateGenericAliasInput(typeName string, indexListExpr *as
 		// User specified custom input names via directives
 		for _, inputDef := range d.Inputs {
 			buf.WriteString(fmt.Sprintf("input %s {\n", inputDef.Name))
-			fields := g.generateFieldsForTypeNamed(st, d, false, true, typeName, genericTypeName, newCtx, "", nil)
-			buf.WriteString(fields)
+			// Filter fields by this input's name so per-input lists (wo:UpdateInput) apply
+			renderedFields := g.orderedFieldsForTypeNamed(st, d, false, true, inputDef.Name, genericTypeName, newCtx, "", nil, nil)
+			buf.WriteString(joinRenderedFields(renderedFields))
 			buf.WriteString("}\n\n")
 
 			// Register the generated item
@@ -2206,14 +4446,15 @@ func (g *Generator) generateGenericAliasInput(typeName string, indexListExpr *as
 				GQLName:      inputDef.Name,
 				GQLKind:      "input",
 				Namespace:    ctx.Namespace,
+				Fields:       schemaFields(renderedFields, nil, inputDef.Name),
 			})
 		}
 	} else {
 		// Auto-generate with default name: {TypeName}Input
 		inputName := typeName + "Input"
 		buf.WriteString(fmt.Sprintf("input %s {\n", inputName))
-		fields := g.generateFieldsForTypeNamed(st, d, false, true, typeName, genericTypeName, newCtx, "", nil)
-		buf.WriteString(fields)
+		renderedFields := g.orderedFieldsForTypeNamed(st, d, false, true, inputName, genericTypeName, newCtx, "", nil, nil)
+		buf.WriteString(joinRenderedFields(renderedFields))
 		buf.WriteString("}\n\n")
 
 		// Register the generated item
@@ -2237,6 +4478,7 @@ func (g *Generator) generateGenericAliasInput(typeName string, indexListExpr *as
 			GQLName:      inputName,
 			GQLKind:      "input",
 			Namespace:    ctx.Namespace,
+			Fields:       schemaFields(renderedFields, nil, inputName),
 		})
 	}
 
@@ -2258,109 +4500,132 @@ func (g *Generator) generateGenericAliasInputSingle(typeName string, indexExpr *
 func (g *Generator) generateConcreteTypesFromInstantiations() error {
 	slog.Info("Generating concrete types from instantiations", "count", len(g.GenericInstantiations))
 
-	for concreteTypeName, inst := range g.GenericInstantiations {
-		// Skip if this type was already generated (e.g., explicitly annotated struct)
-		alreadyGenerated := false
-		for _, item := range g.GeneratedItems {
-			if item.GQLName == concreteTypeName {
-				slog.Debug("Skipping concrete type generation - already generated",
-					"concreteType", concreteTypeName,
-					"existingType", item.GoTypeName)
-				alreadyGenerated = true
-				break
+	// Generating a concrete type can track further instantiations (e.g. Edge[*T]
+	// inside Connection[T]), so keep taking passes in sorted order until none are left
+	processed := make(map[string]bool)
+	for {
+		var pending []string
+		for _, name := range sortedKeys(g.GenericInstantiations) {
+			if !processed[name] {
+				pending = append(pending, name)
 			}
 		}
-		if alreadyGenerated {
-			continue
+		if len(pending) == 0 {
+			break
 		}
+		for _, concreteTypeName := range pending {
+			processed[concreteTypeName] = true
+			inst := g.GenericInstantiations[concreteTypeName]
+			g.generateConcreteTypeFromInstantiation(concreteTypeName, inst)
+		}
+	}
 
-		slog.Debug("Processing concrete type",
-			"concreteTypeName", concreteTypeName,
-			"genericType", inst.GenericTypeName)
-		// Get the generic type's TypeSpec
-		typeSpec, exists := g.P.StructTypes[inst.GenericTypeName]
-		if !exists {
-			// Try with pointer
-			typeSpec, exists = g.P.StructTypes["*"+inst.GenericTypeName]
-			if !exists {
-				slog.Warn("Generic type not found for instantiation",
-					"concreteType", concreteTypeName,
-					"genericType", inst.GenericTypeName)
-				continue
-			}
+	return nil
+}
+
+// generateConcreteTypeFromInstantiation generates the concrete type for a single tracked instantiation
+func (g *Generator) generateConcreteTypeFromInstantiation(concreteTypeName string, inst *GenericInstantiation) {
+	// Skip if this type was already generated (e.g., explicitly annotated struct)
+	alreadyGenerated := false
+	for _, item := range g.GeneratedItems {
+		if item.GQLName == concreteTypeName {
+			slog.Debug("Skipping concrete type generation - already generated",
+				"concreteType", concreteTypeName,
+				"existingType", item.GoTypeName)
+			alreadyGenerated = true
+			break
 		}
+	}
+	if alreadyGenerated {
+		return
+	}
 
-		// typeSpec is *ast.TypeSpec, get the struct type
-		st, ok := typeSpec.Type.(*ast.StructType)
-		if !ok {
-			slog.Warn("Generic type is not a struct",
+	slog.Debug("Processing concrete type",
+		"concreteTypeName", concreteTypeName,
+		"genericType", inst.GenericTypeName)
+	// Get the generic type's TypeSpec
+	typeSpec, exists := g.P.StructTypes[inst.GenericTypeName]
+	if !exists {
+		// Try with pointer
+		typeSpec, exists = g.P.StructTypes["*"+inst.GenericTypeName]
+		if !exists {
+			slog.Warn("Generic type not found for instantiation",
 				"concreteType", concreteTypeName,
 				"genericType", inst.GenericTypeName)
-			continue
+			return
 		}
+	}
 
-		// Build substitution context
-		typeSubstitutions := make(map[string]ast.Expr)
-		if typeSpec.TypeParams != nil {
-			for i, typeParam := range typeSpec.TypeParams.List {
-				if i < len(inst.TypeArguments) {
-					for _, name := range typeParam.Names {
-						typeSubstitutions[name.Name] = inst.TypeArguments[i]
-					}
-				}
-			}
-		}
+	// typeSpec is *ast.TypeSpec, get the struct type
+	st, ok := typeSpec.Type.(*ast.StructType)
+	if !ok {
+		slog.Warn("Generic type is not a struct",
+			"concreteType", concreteTypeName,
+			"genericType", inst.GenericTypeName)
+		return
+	}
 
-		// Determine namespace from the first type argument
-		// e.g., for CommentEdge (Edge[*Comment]), use Comment's namespace
-		namespace := ""
-		if len(inst.TypeArguments) > 0 {
-			if argIdent := extractTypeIdentFromExpr(inst.TypeArguments[0]); argIdent != "" {
-				if argNamespace, exists := g.P.TypeNamespaces[argIdent]; exists {
-					namespace = argNamespace
+	// Build substitution context
+	typeSubstitutions := make(map[string]ast.Expr)
+	if typeSpec.TypeParams != nil {
+		for i, typeParam := range typeSpec.TypeParams.List {
+			if i < len(inst.TypeArguments) {
+				for _, name := range typeParam.Names {
+					typeSubstitutions[name.Name] = inst.TypeArguments[i]
 				}
 			}
 		}
+	}
 
-		// Calculate output file based on namespace
-		var outputFile string
-		if namespace != "" {
-			namespacePath := namespace
-			if g.Config.NamespaceSeparator != "/" {
-				namespacePath = strings.ReplaceAll(namespace, g.Config.NamespaceSeparator, string(filepath.Separator))
-			}
-			outputFile = filepath.Join(g.Config.Output, namespacePath+g.Config.OutputFileExtension)
-		} else {
-			// No namespace - use default output file name
-			if strings.HasSuffix(g.Config.Output, ".graphqls") || strings.HasSuffix(g.Config.Output, ".graphql") || strings.HasSuffix(g.Config.Output, ".gql") {
-				// Old style: Output is the full file path
-				outputFile = g.Config.Output
-			} else {
-				// New style: Output is directory, use OutputFileName
-				outputFile = filepath.Join(g.Config.Output, g.Config.OutputFileName)
+	// Determine namespace from the first type argument
+	// e.g., for CommentEdge (Edge[*Comment]), use Comment's namespace
+	namespace := ""
+	if len(inst.TypeArguments) > 0 {
+		if argIdent := extractTypeIdentFromExpr(inst.TypeArguments[0]); argIdent != "" {
+			if argNamespace, exists := g.P.TypeNamespaces[argIdent]; exists {
+				namespace = argNamespace
 			}
 		}
+	}
 
-		// Create generation context for this instantiation
-		ctx := &GenerationContext{
-			TypeSubstitutions: typeSubstitutions,
-			OutputFile:        outputFile,
-			Strategy:          string(GenStrategySingle),
-			Namespace:         namespace,
+	// Calculate output file based on namespace
+	var outputFile string
+	if namespace != "" {
+		namespacePath := namespace
+		if g.Config.NamespaceSeparator != "/" {
+			namespacePath = strings.ReplaceAll(namespace, g.Config.NamespaceSeparator, string(filepath.Separator))
 		}
-
-		// Generate the GraphQL type content
-		content := g.generateConcreteTypeContent(concreteTypeName, typeSpec, st, ctx)
-		if content != "" {
-			slog.Debug("Generated concrete type from generic instantiation",
-				"concreteType", concreteTypeName,
-				"genericType", inst.GenericTypeName,
-				"typeArgs", len(inst.TypeArguments),
-				"namespace", namespace)
+		outputFile = filepath.Join(g.Config.Output, namespacePath+g.Config.OutputFileExtension)
+	} else {
+		// No namespace - use default output file name
+		if g.Config.Output == OutputStdout {
+			outputFile = OutputStdout
+		} else if g.Config.isSchemaFile(g.Config.Output) {
+			// Old style: Output is the full file path
+			outputFile = g.Config.Output
+		} else {
+			// New style: Output is directory, use OutputFileName
+			outputFile = filepath.Join(g.Config.Output, g.Config.OutputFileName)
 		}
 	}
 
-	return nil
+	// Create generation context for this instantiation
+	ctx := &GenerationContext{
+		TypeSubstitutions: typeSubstitutions,
+		OutputFile:        outputFile,
+		Strategy:          string(GenStrategySingle),
+		Namespace:         namespace,
+	}
+
+	// Generate the GraphQL type content
+	content := g.generateConcreteTypeContent(concreteTypeName, typeSpec, st, ctx)
+	if content != "" {
+		slog.Debug("Generated concrete type from generic instantiation",
+			"concreteType", concreteTypeName,
+			"genericType", inst.GenericTypeName,
+			"typeArgs", len(inst.TypeArguments),
+			"namespace", namespace)
+	}
 }
 
 // extractTypeIdentFromExpr extracts the type identifier name from an expression
@@ -2388,11 +4653,14 @@ func (g *Generator) generateConcreteTypeContent(typeName string, typeSpec *ast.T
 	// This will handle embedded fields, type substitution, directives, etc.
 	d := StructDirectives{} // Empty directives for concrete types
 	goTypeName := typeSpec.Name.Name
-	fields := g.generateFieldsForTypeNamed(st, d, false, false, typeName, goTypeName, ctx, "", nil)
-	buf.WriteString(fields)
+	renderedFields := g.orderedFieldsForTypeNamed(st, d, false, false, typeName, goTypeName, ctx, "", nil, nil)
+	buf.WriteString(joinRenderedFields(renderedFields))
 
 	buf.WriteString("}\n\n")
 
+	// Append key-value pair types synthesized for map fields
+	buf.WriteString(g.flushPairTypes())
+
 	content := buf.String()
 
 	// Store the content so it can be added to the appropriate output file
@@ -2410,6 +4678,7 @@ func (g *Generator) generateConcreteTypeContent(typeName string, typeSpec *ast.T
 		Strategy:      ctx.Strategy,
 		AutoGenerated: true, // Concrete types are always auto-generated
 		Namespace:     ctx.Namespace,
+		Fields:        schemaFields(renderedFields, nil, typeName),
 	})
 
 	return content
@@ -2509,30 +4778,12 @@ func (g *Generator) isGenericInstantiationExpr(expr ast.Expr) bool {
 // resolveTypeArgument resolves a type argument expression through the parent context
 // This handles nested generics at any depth like Connection[Test[X[D]]] where each level needs resolution
 func (g *Generator) resolveTypeArgument(arg ast.Expr, parentCtx *GenerationContext) ast.Expr {
-	if parentCtx == nil || parentCtx.TypeSubstitutions == nil {
+	if parentCtx == nil {
 		return arg
 	}
 
-	// If the argument is a simple identifier that exists in parent substitutions, resolve it
-	if ident, ok := arg.(*ast.Ident); ok {
-		if substituted, exists := parentCtx.TypeSubstitutions[ident.Name]; exists {
-			return substituted
-		}
-	}
-
-	// If it's a pointer to an identifier, check if the base needs resolution
-	if starExpr, ok := arg.(*ast.StarExpr); ok {
-		if ident, ok := starExpr.X.(*ast.Ident); ok {
-			if substituted, exists := parentCtx.TypeSubstitutions[ident.Name]; exists {
-				// Return pointer to the substituted type
-				return &ast.StarExpr{X: substituted}
-			}
-		}
-	}
-
-	// For other cases (including IndexExpr/IndexListExpr), return as-is
-	// The nested generic will be handled when we expand that embedded field
-	return arg
+	// Substitute the parent's type parameters wherever they appear (T, *T, []T, Edge[T], ...)
+	return substituteTypeParams(arg, parentCtx.TypeSubstitutions)
 }
 
 // extractGenericBaseName extracts the base type name from a generic type expression
@@ -2560,6 +4811,11 @@ func (g *Generator) extractBaseTypeName(graphQLType string) string {
 	cleaned = strings.ReplaceAll(cleaned, "]", "")
 	cleaned = strings.TrimSpace(cleaned)
 
+	// GraphQL built-in scalars are in scope even when known_scalars omits them
+	if IsBuiltInScalar(cleaned) {
+		return ""
+	}
+
 	// Check if it's a known scalar (built-in or custom)
 	for _, scalar := range g.Config.KnownScalars {
 		if scalar == cleaned {
@@ -2578,11 +4834,13 @@ func (g *Generator) buildScannedTypesRegistry() {
 			directives := ParseDirectives(typeSpec, genDecl)
 
 			info := &ScannedTypeInfo{
-				TypeName:          typeName,
-				HasTypeDirective:  directives.HasTypeDirective,
-				HasInputDirective: directives.HasInputDirective,
-				GeneratedTypes:    make([]string, 0),
-				GeneratedInputs:   make([]string, 0),
+				TypeName:              typeName,
+				HasTypeDirective:      directives.HasTypeDirective,
+				HasInputDirective:     directives.HasInputDirective,
+				HasInterfaceDirective: directives.HasInterfaceDirective,
+				HasUnionDirective:     directives.HasUnionDirective,
+				GeneratedTypes:        make([]string, 0),
+				GeneratedInputs:       make([]string, 0),
 			}
 
 			// Collect generated type names from @gqlType annotations
@@ -2613,6 +4871,11 @@ func (g *Generator) isTypeInScope(typeName string) bool {
 		return true
 	}
 
+	// Field references use the GraphQL name of renamed definitions, check their Go type
+	if goName, renamed := g.referenceNames[typeName]; renamed {
+		typeName = goName
+	}
+
 	// Check if it's auto-generated as a type
 	if g.AutoGeneratedTypes[typeName] {
 		return true
@@ -2638,11 +4901,21 @@ func (g *Generator) isTypeInScope(typeName string) bool {
 		return true
 	}
 
+	// Check if it's a synthesized key-value pair type for a map field
+	if g.MapPairTypes[typeName] {
+		return true
+	}
+
+	// Check if it's a synthesized type or input for an inline struct field
+	if g.InlineStructTypes[typeName] {
+		return true
+	}
+
 	// Check if this type name was generated from a scanned type with GQL annotations
 	// This is the primary registry that tracks all scanned types with their annotations
 	if scannedInfo, exists := g.P.ScannedTypes[typeName]; exists {
-		// If it has type, input, or include directives, it's definitely in scope
-		if scannedInfo.HasTypeDirective || scannedInfo.HasInputDirective || scannedInfo.HasIncludeDirective {
+		// If it has type, input, interface, union, or include directives, it's definitely in scope
+		if scannedInfo.HasTypeDirective || scannedInfo.HasInputDirective || scannedInfo.HasInterfaceDirective || scannedInfo.HasUnionDirective || scannedInfo.HasIncludeDirective {
 			return true
 		}
 	}
@@ -2687,6 +4960,258 @@ func (g *Generator) isTypeInScope(typeName string) bool {
 	return false
 }
 
+// packageOutputSubdir returns the output subdirectory configured in PackageOutputMap for
+// a package import path, or "" when no pattern matches
+// When several patterns match, the longest (most specific) one wins
+func (g *Generator) packageOutputSubdir(importPath string) string {
+	if importPath == "" || len(g.Config.PackageOutputMap) == 0 {
+		return ""
+	}
+
+	patterns := make([]string, 0, len(g.Config.PackageOutputMap))
+	for pattern := range g.Config.PackageOutputMap {
+		patterns = append(patterns, pattern)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+
+	for _, pattern := range patterns {
+		if matchPackagePattern(pattern, importPath) {
+			return g.Config.PackageOutputMap[pattern]
+		}
+	}
+	return ""
+}
+
+// matchPackagePattern matches a glob against an import path or any of its trailing
+// segments, so "*/internal/billing" matches "github.com/acme/app/internal/billing"
+func matchPackagePattern(pattern, importPath string) bool {
+	candidate := importPath
+	for {
+		if matched, _ := path.Match(pattern, candidate); matched {
+			return true
+		}
+		idx := strings.Index(candidate, "/")
+		if idx < 0 {
+			return false
+		}
+		candidate = candidate[idx+1:]
+	}
+}
+
+// pairsMapType returns the map type of a field that should be rendered as a key-value
+// pair list, or nil when the field is not a map or pairs are disabled for it
+func (g *Generator) pairsMapType(expr ast.Expr, opt FieldOptions) *ast.MapType {
+	enabled := g.Config.MapAsPairs
+	if opt.MapAsPairs != nil {
+		enabled = *opt.MapAsPairs
+	}
+	if !enabled {
+		return nil
+	}
+
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	mapType, _ := expr.(*ast.MapType)
+	return mapType
+}
+
+// mapPairFieldType synthesizes the key-value pair type (or input) for a map and returns
+// the GraphQL list type referencing it, e.g. map[string]*Product -> [StringProductPair!]!
+// Returns empty string for inputs whose value type has no input variant
+func (g *Generator) mapPairFieldType(mapType *ast.MapType, forInput bool, ctx *GenerationContext) string {
+	keyType := ExprToGraphQLTypeWithContext(mapType.Key, g.Config, ctx, g)
+	valueType := ExprToGraphQLTypeWithContext(mapType.Value, g.Config, ctx, g)
+
+	// Pair names are always derived from the type-side names so inputs are just <pair>Input
+	pairName := strings.NewReplacer(
+		"{Key}", pairTypeSegment(keyType),
+		"{Value}", pairTypeSegment(valueType),
+	).Replace(g.Config.MapPairTypeName)
+
+	kind := "type"
+	if forInput {
+		valueType = ExprToGraphQLTypeForInputWithContext(mapType.Value, g.Config.KnownScalars, g.P.EnumTypes, g.Config, ctx, g)
+		if valueType == "" {
+			return ""
+		}
+		keyType = ExprToGraphQLTypeForInputWithContext(mapType.Key, g.Config.KnownScalars, g.P.EnumTypes, g.Config, ctx, g)
+		pairName += "Input"
+		kind = "input"
+	}
+
+	if !g.MapPairTypes[pairName] {
+		g.MapPairTypes[pairName] = true
+		g.pendingPairTypes = append(g.pendingPairTypes,
+			fmt.Sprintf("%s %s {\n%[3]skey: %[4]s\n%[3]svalue: %[5]s\n}\n\n", kind, pairName, g.Config.indent(), keyType, valueType))
+	}
+
+	return "[" + pairName + "!]!"
+}
+
+// inlineStructType returns the anonymous struct of a field type (behind pointers and lists),
+// or nil when the field is not an inline struct or inline_struct_types is disabled
+func (g *Generator) inlineStructType(expr ast.Expr) *ast.StructType {
+	if !g.Config.InlineStructTypes {
+		return nil
+	}
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.ArrayType:
+			expr = t.Elt
+		case *ast.StructType:
+			return t
+		default:
+			return nil
+		}
+	}
+}
+
+// inlineStructFieldType synthesizes the type (or input) for an inline struct field and returns the
+// field type referencing it, keeping the pointer and list wrappers, e.g. []struct{...} -> [UserMeta!]!
+func (g *Generator) inlineStructFieldType(expr ast.Expr, st *ast.StructType, name string, forInput bool, ctx *GenerationContext) string {
+	// Inputs are always named after the type, like the pair inputs
+	gqlName, kind := name, "type"
+	if forInput {
+		gqlName, kind = name+"Input", "input"
+	}
+
+	if !g.InlineStructTypes[gqlName] {
+		g.InlineStructTypes[gqlName] = true
+		// Nested inline structs are queued while rendering the fields, so they come first
+		fields := g.generateFieldsForTypeNamed(st, StructDirectives{}, false, forInput, gqlName, name, ctx, "", nil, nil)
+		g.pendingPairTypes = append(g.pendingPairTypes, fmt.Sprintf("%s %s {\n%s}\n\n", kind, gqlName, fields))
+	}
+
+	named := replaceInlineStruct(expr, &ast.Ident{Name: name})
+	if forInput {
+		return ExprToGraphQLTypeForInputWithContext(named, g.Config.KnownScalars, g.P.EnumTypes, g.Config, ctx, g)
+	}
+	return ExprToGraphQLTypeWithContext(named, g.Config, ctx, g)
+}
+
+// replaceInlineStruct returns a copy of a field type with its anonymous struct replaced by ident
+func replaceInlineStruct(expr ast.Expr, ident *ast.Ident) ast.Expr {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: replaceInlineStruct(t.X, ident)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: t.Len, Elt: replaceInlineStruct(t.Elt, ident)}
+	}
+	return ident
+}
+
+// pairTypeSegment converts a GraphQL type into a name segment for pair type names
+// List wrappers become a "List" suffix, e.g. [Product!]! -> ProductList
+func pairTypeSegment(graphQLType string) string {
+	lists := strings.Count(graphQLType, "[")
+	base := strings.NewReplacer("!", "", "[", "", "]", "").Replace(graphQLType)
+	return base + strings.Repeat("List", lists)
+}
+
+// flushPairTypes returns the pending pair type definitions and clears them
+func (g *Generator) flushPairTypes() string {
+	if len(g.pendingPairTypes) == 0 {
+		return ""
+	}
+	content := strings.Join(g.pendingPairTypes, "")
+	g.pendingPairTypes = nil
+	return content
+}
+
+// isInterfaceWithMethods reports whether a field type (or its pointer/list element) is an interface
+// declaring methods, either inline or as a parsed named interface
+func (g *Generator) isInterfaceWithMethods(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return g.isInterfaceWithMethods(t.X)
+	case *ast.ArrayType:
+		return g.isInterfaceWithMethods(t.Elt)
+	case *ast.InterfaceType:
+		return t.Methods != nil && len(t.Methods.List) > 0
+	case *ast.Ident:
+		iface := g.P.InterfaceTypes[t.Name]
+		return iface != nil && iface.Methods != nil && len(iface.Methods.List) > 0
+	}
+	return false
+}
+
+// isIgnoredType checks if a field type expression (or its pointer/list element) is a struct marked @gqlIgnore
+func (g *Generator) isIgnoredType(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return g.isIgnoredType(t.X)
+	case *ast.ArrayType:
+		return g.isIgnoredType(t.Elt)
+	case *ast.Ident:
+		typeSpec, exists := g.P.StructTypes[t.Name]
+		return exists && ParseDirectives(typeSpec, g.P.TypeToDecl[t.Name]).SkipType
+	}
+	return false
+}
+
+// isExcludedType checks if a field type expression refers to a type listed in Config.ExcludeTypes
+// Package-qualified types match either as written ("sync.Mutex") or by full import path
+func (g *Generator) isExcludedType(expr ast.Expr) bool {
+	if len(g.Config.ExcludeTypes) == 0 {
+		return false
+	}
+
+	// Unwrap pointers and slices to get the referenced type
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+			continue
+		case *ast.ArrayType:
+			expr = t.Elt
+			continue
+		}
+		break
+	}
+
+	var candidates []string
+	switch t := expr.(type) {
+	case *ast.Ident:
+		candidates = append(candidates, t.Name)
+	case *ast.SelectorExpr:
+		if pkgIdent, ok := t.X.(*ast.Ident); ok {
+			candidates = append(candidates, pkgIdent.Name+"."+t.Sel.Name)
+			if importPath, ok := g.P.fileImports[pkgIdent.Name]; ok {
+				candidates = append(candidates, importPath+"."+t.Sel.Name)
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		if contains(g.Config.ExcludeTypes, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDeclaredTypeParameter checks if a name is a type parameter of a parsed generic type
+// (Parser.TypeParameters) and not also the name of a parsed type
+func (g *Generator) isDeclaredTypeParameter(name string) bool {
+	if g.P.StructTypes[name] != nil || g.P.EnumTypes[name] != nil || g.P.ScalarTypes[name] != nil {
+		return false
+	}
+	for _, params := range g.P.TypeParameters {
+		if slices.Contains(params, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // isTypeParameter checks if a name is likely a generic type parameter
 // It checks against known type parameters from parsed generic types and common conventions
 func (g *Generator) isTypeParameter(name string) bool {
@@ -2722,6 +5247,29 @@ func (g *Generator) reportOutOfScopeTypes() error {
 		return nil
 	}
 
+	msg := g.outOfScopeReport()
+
+	switch g.Config.AutoGenerate.OutOfScopeTypes {
+	case OutOfScopeFail:
+		return fmt.Errorf("%s", msg)
+	case OutOfScopeWarn:
+		fmt.Fprintf(os.Stderr, "%s", msg)
+	case OutOfScopeIgnore:
+		// Do nothing - types are allowed even if undefined
+	case OutOfScopeExclude:
+		// Fields were already excluded during generation
+		fmt.Fprintf(os.Stderr, "\nNote: %d field(s) were automatically excluded due to out-of-scope type references.\n", g.countExcludedFields())
+		fmt.Fprintf(os.Stderr, "Set 'out_of_scope_types: ignore' if you want to include them anyway.\n\n")
+	case OutOfScopePlaceholder:
+		// Fields were already mapped to the placeholder scalar during generation
+		fmt.Fprintf(os.Stderr, "\nNote: %d field(s) were mapped to placeholder scalar '%s' and marked with TODO comments.\n\n", g.countExcludedFields(), g.Config.AutoGenerate.PlaceholderScalar)
+	}
+
+	return nil
+}
+
+// outOfScopeReport describes every out-of-scope type reference and how to resolve them
+func (g *Generator) outOfScopeReport() string {
 	action := g.Config.AutoGenerate.OutOfScopeTypes
 
 	// Build a sorted list of out-of-scope types for consistent output
@@ -2761,6 +5309,11 @@ func (g *Generator) reportOutOfScopeTypes() error {
 				parentGoTypeFull = ref.ParentGoType
 			}
 
+			if ref.Embedded {
+				msg.WriteString(fmt.Sprintf("  - %s (embedded, its fields were dropped)\n", ref.ParentGQLName))
+				msg.WriteString(fmt.Sprintf("    Go: %s embeds %s\n", parentGoTypeFull, ref.GoFieldType))
+				continue
+			}
 			msg.WriteString(fmt.Sprintf("  - %s.%s (GraphQL field)\n", ref.ParentGQLName, ref.FieldName))
 			msg.WriteString(fmt.Sprintf("    Go: %s.%s (type: %s)\n", parentGoTypeFull, ref.GoFieldName, ref.GoFieldType))
 		}
@@ -2792,22 +5345,14 @@ func (g *Generator) reportOutOfScopeTypes() error {
 	} else {
 		msg.WriteString("     - exclude: Automatically exclude these fields from schema\n")
 	}
-	msg.WriteString("\n")
-
-	switch action {
-	case OutOfScopeFail:
-		return fmt.Errorf("%s", msg.String())
-	case OutOfScopeWarn:
-		fmt.Fprintf(os.Stderr, "%s", msg.String())
-	case OutOfScopeIgnore:
-		// Do nothing - types are allowed even if undefined
-	case OutOfScopeExclude:
-		// Fields were already excluded during generation
-		fmt.Fprintf(os.Stderr, "\nNote: %d field(s) were automatically excluded due to out-of-scope type references.\n", g.countExcludedFields())
-		fmt.Fprintf(os.Stderr, "Set 'out_of_scope_types: ignore' if you want to include them anyway.\n\n")
+	if action == OutOfScopePlaceholder {
+		msg.WriteString("     - placeholder: Map these fields to a placeholder scalar with a TODO comment (current)\n")
+	} else {
+		msg.WriteString("     - placeholder: Map these fields to a placeholder scalar with a TODO comment\n")
 	}
+	msg.WriteString("\n")
 
-	return nil
+	return msg.String()
 }
 
 // countExcludedFields counts the total number of fields that reference out-of-scope types
@@ -2824,8 +5369,13 @@ func (g *Generator) generateEnum(enumType *EnumType, ctx *GenerationContext) str
 	buf := strings.Builder{}
 
 	// Add description if present
-	if enumType.Description != "" {
-		buf.WriteString(fmt.Sprintf("\"\"\"\n%s\n\"\"\"\n", enumType.Description))
+	description := enumType.Description
+	deprecateValues := enumType.Deprecated && g.Config.TypeDeprecationMode != TypeDeprecationDescription
+	if enumType.Deprecated && !deprecateValues {
+		description = deprecatedDescription(enumType.DeprecatedReason, description)
+	}
+	if description != "" {
+		buf.WriteString(formatDescription(description, ""))
 	}
 
 	buf.WriteString(fmt.Sprintf("enum %s", enumType.Name))
@@ -2835,32 +5385,41 @@ func (g *Generator) generateEnum(enumType *EnumType, ctx *GenerationContext) str
 
 	buf.WriteString(" {\n")
 
+	// Each value keeps its Go const, so @goEnum still points at the right one after sorting
+	enumValues := enumType.Values
+	if g.Config.EnumValueSort == EnumValueSortAlphabetical {
+		enumValues = slices.Clone(enumValues)
+		slices.SortStableFunc(enumValues, func(a, b EnumValue) int {
+			return strings.Compare(a.GraphQLName, b.GraphQLName)
+		})
+	}
+
 	// Generate enum values
-	for _, value := range enumType.Values {
+	values := make([]string, 0, len(enumValues))
+	for _, value := range enumValues {
+		values = append(values, value.GraphQLName)
+
 		// Add description if present
 		if value.Description != "" {
-			buf.WriteString(fmt.Sprintf("  \"\"\"\n  %s\n  \"\"\"\n", value.Description))
+			buf.WriteString(formatDescription(value.Description, g.Config.indent()))
 		}
 
 		// Add the enum value
-		buf.WriteString(fmt.Sprintf("  %s", value.GraphQLName))
+		buf.WriteString(g.Config.indent() + value.GraphQLName)
 
 		// Add @goEnum directive if gqlgen directives are enabled
-		if g.Config.UseGqlGenDirectives {
-			// Use the package path where the const value is defined, not where the type is defined
-			var valuePkgPath string
-			if value.PackagePath != "" {
-				// Use the stored package info for this specific const value
-				valuePkgPath = g.P.GetPackageImportPathFromFile(value.PackagePath, value.PackageName, g.Config.ModelPath)
-			} else {
-				// Fallback to using the enum type's package (for backwards compatibility)
-				valuePkgPath = g.P.GetPackageImportPath(enumType.GoTypeName, g.Config.ModelPath)
-			}
-			g.writeGoEnumDirective(&buf, valuePkgPath, value.GoName)
+		// Inline values (@gqlEnum(values:...)) have no Go const to reference unless value: names one
+		if g.Config.UseGqlGenDirectives && (value.GoName != "" || value.GoValue != "") {
+			valuePkgPath, valueGoName, _ := g.enumValueGoRef(enumType, value)
+			g.writeGoEnumDirective(&buf, valuePkgPath, valueGoName)
 		}
 
-		// Add deprecated directive if present
-		g.writeDeprecatedDirective(&buf, value.Deprecated != "", value.Deprecated)
+		// Add deprecated directive if present, values of a deprecated enum take its reason
+		if value.Deprecated != "" || !deprecateValues {
+			g.writeDeprecatedDirective(&buf, value.Deprecated != "", value.Deprecated)
+		} else {
+			g.writeDeprecatedDirective(&buf, true, enumType.DeprecatedReason)
+		}
 
 		buf.WriteString("\n")
 	}
@@ -2878,11 +5437,106 @@ func (g *Generator) generateEnum(enumType *EnumType, ctx *GenerationContext) str
 		Strategy:      ctx.Strategy,
 		AutoGenerated: false, // Enums are not auto-generated
 		Namespace:     ctx.Namespace,
+		Values:        values,
+	})
+
+	return buf.String()
+}
+
+// generateScalar generates a GraphQL scalar definition for a @gqlScalar type
+func (g *Generator) generateScalar(scalarType *ScalarType, ctx *GenerationContext) string {
+	buf := strings.Builder{}
+
+	// Add description if present
+	if scalarType.Description != "" {
+		buf.WriteString(formatDescription(scalarType.Description, ""))
+	}
+
+	buf.WriteString(fmt.Sprintf("scalar %s", scalarType.Name))
+
+	// Add @goModel directive if gqlgen directives are enabled
+	g.writeGoModelDirective(&buf, scalarType.GoTypeName, false)
+
+	buf.WriteString("\n\n")
+
+	// Register the generated scalar
+	pkgPath := g.P.GetPackageImportPath(scalarType.GoTypeName, g.Config.ModelPath)
+	g.registerGeneratedItem(GQLSchemaItem{
+		OutputFile:   ctx.OutputFile,
+		GoSourceFile: g.P.SourceFiles[scalarType.GoTypeName],
+		GoType:       pkgPath + "." + scalarType.GoTypeName,
+		GoTypeName:   scalarType.GoTypeName,
+		GQLName:      scalarType.Name,
+		GQLKind:      "scalar",
+		Strategy:     ctx.Strategy,
+		Namespace:    ctx.Namespace,
 	})
 
 	return buf.String()
 }
 
+// registerScalarTypes adds @gqlScalar types to the known scalars and maps their Go types to the scalar
+// Fields using the Go type then render the scalar name and are never reported as out of scope
+func (g *Generator) registerScalarTypes() {
+	for _, scalarName := range g.P.ScalarNames {
+		scalarType := g.P.ScalarTypes[scalarName]
+
+		if !slices.Contains(g.Config.KnownScalars, scalarType.Name) {
+			g.Config.KnownScalars = append(g.Config.KnownScalars, scalarType.Name)
+		}
+
+		if g.Config.Scalars == nil {
+			g.Config.Scalars = make(map[string]ScalarMapping)
+		}
+		goType := g.P.PackagePaths[scalarType.GoTypeName] + "." + scalarType.GoTypeName
+		mapping := g.Config.Scalars[scalarType.Name]
+		if !slices.Contains(mapping.Model, goType) {
+			mapping.Model = append(mapping.Model, goType)
+			g.Config.Scalars[scalarType.Name] = mapping
+		}
+	}
+}
+
+// loadExternalEmbeddedStructs loads the structs embedded from packages that are not scanned
+// (e.g., an embedded base.Model) so expandEmbeddedFieldNamed can inline their fields
+// Loaded structs are checked too, so embedding chains across packages are followed
+func (g *Generator) loadExternalEmbeddedStructs() {
+	if !g.Config.AutoGenerate.IncludeEmbedded {
+		return
+	}
+
+	checked := make(map[string]bool)
+	for {
+		initialCount := len(g.P.StructTypes)
+		for _, typeName := range sortedKeys(g.P.StructTypes) {
+			if checked[typeName] {
+				continue
+			}
+			checked[typeName] = true
+
+			structType, ok := g.P.StructTypes[typeName].Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			for _, field := range structType.Fields.List {
+				// Only embedded fields, never excluded or ignored ones
+				if len(field.Names) > 0 || g.isExcludedType(field.Type) || ParseFieldOptions(field, g.Config).Ignore {
+					continue
+				}
+				known := len(g.P.StructTypes)
+				if _, found := g.P.LoadExternalStruct(field.Type, typeName); found && len(g.P.StructTypes) > known {
+					g.verbose("embedded struct loaded", "type", typeName, "embedded", ExprToGoType(field.Type))
+				}
+			}
+		}
+
+		// Stop once no new structs were loaded
+		if len(g.P.StructTypes) == initialCount {
+			break
+		}
+	}
+}
+
 // discoverReferencedAnnotatedTypes scans all struct fields and discovers types with GQL annotations
 // from packages not in the scan list, adding them to the parser for generation
 func (g *Generator) discoverReferencedAnnotatedTypes() {
@@ -2896,6 +5550,14 @@ func (g *Generator) discoverReferencedAnnotatedTypes() {
 
 		// Check all fields in the struct
 		for _, field := range structType.Fields.List {
+			// Never load packages for excluded types
+			if g.isExcludedType(field.Type) {
+				continue
+			}
+			// Embedded structs are only loaded from other packages with include_embedded
+			if len(field.Names) == 0 && !g.Config.AutoGenerate.IncludeEmbedded {
+				continue
+			}
 			// Try to discover annotated types from this field
 			g.P.HasGQLAnnotations(field.Type, typeName)
 		}
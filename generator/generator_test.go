@@ -158,6 +158,77 @@ const (
 	}
 }
 
+func TestIntEnumWithIotaAcrossFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"priority.go": `package models
+
+// @gqlEnum
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+)
+`,
+		"priority_more.go": `package models
+
+const (
+	PriorityHigh Priority = iota + 10
+	PriorityUrgent
+)
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	// Walk the file holding the second block first
+	parser := NewParser()
+	for _, name := range []string{"priority_more.go", "priority.go"} {
+		if err := parser.Walk(filepath.Join(tmpDir, name)); err != nil {
+			t.Fatalf("Walk(%s) error = %v", name, err)
+		}
+	}
+	parser.MatchEnumConstants()
+	// Matching again must not duplicate values
+	parser.MatchEnumConstants()
+
+	enumType, exists := parser.EnumTypes["Priority"]
+	if !exists {
+		t.Fatal("Priority enum not found")
+	}
+
+	// Values follow source order (file, then position) and iota restarts in each block
+	expected := []struct {
+		goName string
+		value  int
+	}{
+		{"PriorityNone", 0},
+		{"PriorityLow", 1},
+		{"PriorityMedium", 2},
+		{"PriorityHigh", 10},
+		{"PriorityUrgent", 11},
+	}
+	var got []string
+	for _, v := range enumType.Values {
+		got = append(got, v.GoName)
+	}
+	if len(enumType.Values) != len(expected) {
+		t.Fatalf("Expected %d enum values, got %v", len(expected), got)
+	}
+	for i, want := range expected {
+		v := enumType.Values[i]
+		if v.GoName != want.goName || v.Value != want.value {
+			t.Errorf("Value %d: expected %s = %d, got %s = %v (values: %v)", i, want.goName, want.value, v.GoName, v.Value, got)
+		}
+	}
+}
+
 func TestEnumAutoNaming(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -269,6 +340,103 @@ const (
 	}
 }
 
+func TestEnumInlineValues(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	content := `package models
+
+// @gqlEnum(values:"PENDING, ACTIVE,DONE", description:"Task status, stored as text")
+type Status string
+
+// @gqlEnum(values:"LOW,HIGH")
+type Priority int
+
+// Inline values replace the const block
+const (
+	PriorityIgnored Priority = iota
+)
+
+// @gqlType
+type Task struct {
+	Status   Status
+	Priority *Priority
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "task.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	parser := NewParser()
+	if err := parser.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	parser.MatchEnumConstants()
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+	config.UseGqlGenDirectives = true
+	config.ModelPath = "example.com/app/models"
+
+	files, err := NewGenerator(parser, config).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[config.Output]
+
+	status := schemaBlock(schema, "enum Status")
+	if !strings.Contains(status, "  PENDING\n  ACTIVE\n  DONE\n") {
+		t.Errorf("Expected the inline Status values in order, got:\n%s", status)
+	}
+	if !strings.Contains(schema, "Task status, stored as text") {
+		t.Errorf("Expected the enum description, got:\n%s", schema)
+	}
+	priority := schemaBlock(schema, "enum Priority")
+	if !strings.Contains(priority, "  LOW\n  HIGH\n") || strings.Contains(priority, "IGNORED") {
+		t.Errorf("Expected only the inline Priority values, got:\n%s", priority)
+	}
+
+	// There is no Go const for @goEnum to reference
+	if strings.Contains(schema, "@goEnum") {
+		t.Errorf("Expected no @goEnum for inline values, got:\n%s", schema)
+	}
+
+	for _, want := range []string{"status: Status!", "priority: Priority!"} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected %q in schema, got:\n%s", want, schema)
+		}
+	}
+}
+
+func TestEnumInlineValuesInvalid(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	content := "package models\n\n// @gqlEnum(values:\"OK,not-ok,OK\")\ntype Result string\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "result.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	parser := NewParser()
+	if err := parser.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	parser.MatchEnumConstants()
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+
+	_, err := NewGenerator(parser, config).Build()
+	if err == nil {
+		t.Fatal("Expected an error for invalid inline enum values")
+	}
+	for _, want := range []string{`inline value "not-ok" is not a valid GraphQL name`, "inline value OK is listed twice"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got: %v", want, err)
+		}
+	}
+}
+
 func TestEnumCustomName(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -372,6 +540,158 @@ const (
 	}
 }
 
+func TestEnumGoEnumValue(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFile := filepath.Join(tmpDir, "color.go")
+	content := `package models
+
+import shades "example.com/palette"
+
+// @gqlEnum
+type Color string
+
+const (
+	ColorRed   Color = "crimson"
+	ColorGreen Color = "lime"  // @gqlEnumValue(name:"LIME")
+	ColorBlue  Color = "navy"  // @gqlEnumValue(value:"ColorNavy")
+	ColorGray  Color = "slate" // @gqlEnumValue(value:"example.com/palette.Slate")
+	ColorTeal  Color = "teal"  // @gqlEnumValue(value:"shades.Teal")
+	ColorPink  Color = "pink"  // @gqlEnumValue(value:"models.ColorRose")
+)
+
+var _ = shades.Teal
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	parser := NewParser()
+	if err := parser.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	parser.MatchEnumConstants()
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+	config.UseGqlGenDirectives = true
+	config.ModelPath = "example.com/models"
+
+	files, err := NewGenerator(parser, config).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	generatedStr := files[config.Output]
+
+	// @goEnum references the Go const, never its runtime string value
+	expected := []string{
+		`RED @goEnum(value: "example.com/models.ColorRed")`,
+		`LIME @goEnum(value: "example.com/models.ColorGreen")`,
+		`BLUE @goEnum(value: "example.com/models.ColorNavy")`,
+		`GRAY @goEnum(value: "example.com/palette.Slate")`,
+		// Short qualifiers resolve through the file's imports, or name its own package
+		`TEAL @goEnum(value: "example.com/palette.Teal")`,
+		`PINK @goEnum(value: "example.com/models.ColorRose")`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(generatedStr, want) {
+			t.Errorf("Expected %q in generated schema, got:\n%s", want, generatedStr)
+		}
+	}
+	if strings.Contains(generatedStr, "crimson") {
+		t.Errorf("Runtime string values must not appear in the schema, got:\n%s", generatedStr)
+	}
+}
+
+func TestEnumInvalidValueNames(t *testing.T) {
+	tests := []struct {
+		name   string
+		consts string
+		want   []string
+	}{
+		{
+			name:   "numeric leading",
+			consts: "\tStatus2xx Status = \"ok\"\n\tStatusRedirect Status = \"redirect\"\n",
+			want:   []string{"const Status2xx produces \"2XX\""},
+		},
+		{
+			name:   "reserved word",
+			consts: "\tStatusNone Status = \"none\" // @gqlEnumValue(name:\"null\")\n",
+			want:   []string{"const StatusNone produces \"null\""},
+		},
+		{
+			name:   "duplicate",
+			consts: "\tStatusActive Status = \"active\"\n\tStatusLive Status = \"live\" // @gqlEnumValue(name:\"ACTIVE\")\n",
+			want:   []string{"consts StatusActive and StatusLive both produce ACTIVE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			content := "package models\n\n// @gqlEnum\ntype Status string\n\nconst (\n" + tt.consts + ")\n"
+			if err := os.WriteFile(filepath.Join(tmpDir, "status.go"), []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			parser := NewParser()
+			if err := parser.Walk(tmpDir); err != nil {
+				t.Fatalf("Walk() error = %v", err)
+			}
+			parser.MatchEnumConstants()
+
+			config := NewConfig()
+			config.Output = filepath.Join(tmpDir, "schema.graphqls")
+			config.GenStrategy = GenStrategySingle
+
+			_, err := NewGenerator(parser, config).Build()
+			if err == nil {
+				t.Fatal("Expected an error for invalid enum values")
+			}
+			for _, want := range append(tt.want, "@gqlEnumValue(name:") {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected error to contain %q, got: %v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestEnumGoEnumValueUnknownPackage(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := `package models
+
+// @gqlEnum
+type Color string
+
+const (
+	ColorRed Color = "crimson" // @gqlEnumValue(value:"palette.Red")
+)
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "color.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	parser := NewParser()
+	if err := parser.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	parser.MatchEnumConstants()
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+	config.UseGqlGenDirectives = true
+
+	// The file does not import palette, so the qualifier cannot be turned into an import path
+	_, err := NewGenerator(parser, config).Build()
+	if err == nil || !strings.Contains(err.Error(), `value RED references "palette.Red"`) {
+		t.Fatalf("Expected an unresolved package error, got %v", err)
+	}
+}
+
 func TestEnumCrossFile(t *testing.T) {
 	// Create a temp directory for test files
 	tmpDir := t.TempDir()
@@ -602,6 +922,54 @@ const (
 	}
 }
 
+func TestParsePackagesMatchesEnumConstants(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"types/status.go": `package types
+
+// @gqlEnum
+type Status string
+`,
+		"constants/status_values.go": `package constants
+
+import "../types"
+
+const (
+	StatusPending types.Status = "PENDING"
+	StatusActive  types.Status = "ACTIVE"
+)
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// No explicit MatchEnumConstants call: ParsePackages runs it after walking every package
+	p := NewParser()
+	if err := p.ParsePackages([]string{filepath.Join(tmpDir, "types"), filepath.Join(tmpDir, "constants")}); err != nil {
+		t.Fatalf("ParsePackages() error = %v", err)
+	}
+
+	statusEnum, ok := p.EnumTypes["Status"]
+	if !ok {
+		t.Fatal("Status enum not found")
+	}
+	if len(statusEnum.Values) != 2 {
+		t.Errorf("Expected 2 enum values, got %d", len(statusEnum.Values))
+	}
+
+	if err := NewParser().ParsePackages([]string{filepath.Join(tmpDir, "missing")}); err == nil {
+		t.Error("Expected an error for a missing package")
+	}
+}
+
 // ============================================================================
 // Deprecated Field Tests
 // ============================================================================
@@ -729,7 +1097,7 @@ type Product struct {
 
 	schema := string(content)
 
-	if !strings.Contains(schema, `"""Product price"""`) {
+	if !strings.Contains(schema, `"Product price"`) {
 		t.Errorf("Schema should contain description\nGenerated schema:\n%s", schema)
 	}
 
@@ -827,7 +1195,7 @@ func TestDeprecatedInInput(t *testing.T) {
 type UpdateUserInput struct {
 	Name     string  ` + "`" + `gql:"name"` + "`" + `
 	Email    string  ` + "`" + `gql:"email"` + "`" + `
-	Username *string ` + "`" + `gql:"username,deprecated:\"Use email instead\""` + "`" + `
+	Username *string ` + "`" + `gql:"username,optional,deprecated:\"Use email instead\""` + "`" + `
 }
 `
 	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
@@ -864,11 +1232,68 @@ type UpdateUserInput struct {
 		t.Errorf("Schema should contain UpdateUserInput\nGenerated schema:\n%s", schema)
 	}
 
-	if !strings.Contains(schema, `username: String! @deprecated(reason: "Use email instead")`) {
+	if !strings.Contains(schema, `username: String @deprecated(reason: "Use email instead")`) {
 		t.Errorf("Schema should contain deprecated username field\nGenerated schema:\n%s", schema)
 	}
 }
 
+func TestGoDeprecatedComment(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlType
+type User struct {
+	ID string
+
+	// Login name of the user.
+	//
+	// Deprecated: use Email
+	// instead.
+	Username string
+
+	// Deprecated: ignored in favor of the tag
+	Handle string ` + "`gql:\"handle,deprecated:\\\"Use nickname\\\"\"`" + `
+
+	Nick string // Deprecated: use Handle
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+
+	files, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[cfg.Output]
+
+	for _, want := range []string{
+		`"Login name of the user."`,
+		`username: String! @deprecated(reason: "use Email instead.")`,
+		`handle: String! @deprecated(reason: "Use nickname")`,
+		`nick: String! @deprecated(reason: "use Handle")`,
+		"id: String!\n",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected %q, got:\n%s", want, schema)
+		}
+	}
+
+	// The deprecation paragraph is not part of the description
+	if strings.Contains(schema, "Deprecated:") {
+		t.Errorf("Expected the Deprecated: paragraph to be dropped from descriptions, got:\n%s", schema)
+	}
+}
+
 // ============================================================================
 // Package Strategy Tests
 // ============================================================================
@@ -147,6 +147,58 @@ type Container struct {
 	}
 }
 
+// TestUnresolvedGenericTypeStandalone verifies a generic type generated without type arguments
+// renders its type parameters as the configured UnresolvedGenericType
+func TestUnresolvedGenericTypeStandalone(t *testing.T) {
+	code := `package test
+
+/**
+ * @gqlType
+ */
+type Box[T any] struct {
+	Value T   ` + "`json:\"value\"`" + `
+	Items []T ` + "`json:\"items\"`" + `
+}
+`
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.go"), []byte(code), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	parser := NewParser()
+	if err := parser.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphql")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.AutoGenerate.UnresolvedGenericType = "JSON"
+	cfg.AutoGenerate.SuppressGenericTypeWarnings = true
+
+	gen := NewGenerator(parser, cfg)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	generated, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	schema := string(generated)
+
+	if !strings.Contains(schema, "value: JSON\n") || !strings.Contains(schema, "items: [JSON]!") {
+		t.Errorf("Schema should render T as JSON\n\nGenerated:\n%s", schema)
+	}
+	if strings.Contains(schema, "T!") {
+		t.Errorf("Schema should not contain the unresolved parameter T\n\nGenerated:\n%s", schema)
+	}
+	if len(gen.OutOfScopeTypes) != 0 {
+		t.Errorf("Expected no out-of-scope types, got %v", gen.OutOfScopeTypes)
+	}
+}
+
 // TestMultipleTypeParameters verifies substitution with multiple type parameters (K, V)
 func TestMultipleTypeParameters(t *testing.T) {
 	code := `package test
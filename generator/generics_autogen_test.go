@@ -47,7 +47,7 @@ type UserResponse struct {
 			maxDepth:        2,
 			shouldContain: []string{
 				"type UserResponse",
-				"data:",
+				"data: User!",
 				"success:",
 				"message:",
 				"type User",
@@ -125,8 +125,8 @@ type ConfigPair struct {
 			maxDepth:        2,
 			shouldContain: []string{
 				"type ConfigPair",
-				"key:",
-				"value:",
+				"key: IntKey!",
+				"value: StringValue!",
 				"type IntKey",
 				"number:",
 				"type StringValue",
@@ -134,6 +134,43 @@ type ConfigPair struct {
 			},
 			shouldNotContain: []string{},
 		},
+		{
+			name: "resolve type parameters wrapped in pointers and nested generics",
+			code: `package test
+
+type Edge[T any] struct {
+	Node T ` + "`json:\"node\"`" + `
+}
+
+type Page[T any] struct {
+	Edges []*Edge[*T] ` + "`json:\"edges\"`" + `
+	Items []*T        ` + "`json:\"items\"`" + `
+}
+
+type Article struct {
+	Title string ` + "`json:\"title\"`" + `
+}
+
+/**
+ * @gqlType
+ */
+type ArticlePage struct {
+	Page[Article]
+}
+`,
+			autoGenStrategy: AutoGenReferenced,
+			maxDepth:        2,
+			shouldContain: []string{
+				"type ArticlePage",
+				"edges: [ArticleEdge!]!",
+				"items: [Article!]!",
+				"type ArticleEdge",
+				"node: Article!",
+			},
+			shouldNotContain: []string{
+				": T!",
+			},
+		},
 		{
 			name: "auto-generate with depth limit",
 			code: `package test
@@ -211,7 +248,7 @@ type CreateUserResult struct {
 			maxDepth:        2,
 			shouldContain: []string{
 				"type CreateUserResult",
-				"data:",
+				"data: CreateUser!",
 				"error:",
 				"type CreateUser",
 				"username:",
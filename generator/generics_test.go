@@ -250,3 +250,101 @@ type UserResponse struct {
 		t.Errorf("Schema should not contain omitted field 'error'\n\nGenerated schema:\n%s", schema)
 	}
 }
+
+// TestSameGenericEmbeddedWithDifferentArgs verifies that each struct embedding the same
+// generic base gets the fields of its own instantiation
+func TestSameGenericEmbeddedWithDifferentArgs(t *testing.T) {
+	code := `package test
+
+type Connection[T any] struct {
+	Node  T
+	Edges []Edge[T]
+}
+
+type Edge[T any] struct {
+	Cursor string
+	Node   T
+}
+
+type Page[T any] struct {
+	Items []T
+	Total int
+}
+
+// @gqlType
+// @gqlInput
+type Product struct {
+	SKU string
+}
+
+// @gqlType
+// @gqlInput
+type Comment struct {
+	Body string
+}
+
+// @gqlType
+type ProductConnection struct {
+	Connection[*Product]
+}
+
+// @gqlType
+type CommentConnection struct {
+	Connection[Comment]
+}
+
+// @gqlInput
+type ProductPage struct {
+	Page[*Product]
+}
+
+// @gqlInput
+type CommentPage struct {
+	Page[*Comment]
+}
+`
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.go"), []byte(code), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	parser := NewParser()
+	if err := parser.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+
+	files, err := NewGenerator(parser, cfg).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[cfg.Output]
+
+	blocks := map[string][]string{
+		"type ProductConnection {": {"node: Product!", "edges: [ProductEdge!]!"},
+		"type CommentConnection {": {"node: Comment!", "edges: [CommentEdge!]!"},
+		"type ProductEdge {":       {"node: Product!"},
+		"type CommentEdge {":       {"node: Comment!"},
+		"input ProductPageInput {": {"items: [ProductInput!]!"},
+		"input CommentPageInput {": {"items: [CommentInput!]!"},
+	}
+	for header, wants := range blocks {
+		block := schemaBlock(schema, header)
+		for _, want := range wants {
+			if !strings.Contains(block, want) {
+				t.Errorf("Expected %q in %s, got:\n%s", want, header, block)
+			}
+		}
+	}
+
+	// The generic bases are only expanded with substitutions, never generated on their own
+	for _, name := range []string{"type Connection", "input ConnectionInput", "input PageInput", "TEdge"} {
+		if strings.Contains(schema, name) {
+			t.Errorf("Expected no %q, got:\n%s", name, schema)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGoFieldNameForRenamedFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlType
+// @gqlInput
+type User struct {
+	ID       string
+	FullName string
+	Name     string ` + "`json:\"fullTitle\"`" + `
+	Username string ` + "`gql:\"login\"`" + `
+	Posts    []string ` + "`json:\"articles\" gql:\"articles,forceResolver\"`" + `
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	build := func(gqlgen bool) string {
+		p := NewParser()
+		if err := p.Walk(tmpDir); err != nil {
+			t.Fatalf("Walk() error = %v", err)
+		}
+
+		cfg := NewConfig()
+		cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+		cfg.GenStrategy = GenStrategySingle
+		cfg.UseGqlGenDirectives = gqlgen
+		cfg.ModelPath = "example.com/app/models"
+
+		files, err := NewGenerator(p, cfg).Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		return files[cfg.Output]
+	}
+
+	schema := build(true)
+	for _, header := range []string{"type User ", "input UserInput "} {
+		block := schemaBlock(schema, header)
+		for _, want := range []string{
+			"  id: String!\n",
+			"  fullName: String!\n",
+			"  fullTitle: String! @goField(name: \"Name\")\n",
+			"  login: String! @goField(name: \"Username\")\n",
+		} {
+			if !strings.Contains(block, want) {
+				t.Errorf("Expected %q in %s, got:\n%s", want, header, block)
+			}
+		}
+	}
+
+	// Resolved fields are not bound to the struct, they only get forceResolver
+	if want := "articles: [String!]! @goField(forceResolver: true)\n"; !strings.Contains(schemaBlock(schema, "type User "), want) {
+		t.Errorf("Expected %q, got:\n%s", want, schema)
+	}
+
+	// Without gqlgen directives nothing is added
+	if plain := build(false); strings.Contains(plain, "@goField") {
+		t.Errorf("Expected no @goField without gqlgen directives, got:\n%s", plain)
+	}
+}
@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIndentIsUniform(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlEnum
+type Role string
+
+const (
+	RoleAdmin Role = "admin" // Full access
+	RoleGuest Role = "guest"
+)
+
+// @gqlType
+// @gqlTypeExtraField(name:"fullName",type:"String!",description:"Computed name")
+type User struct {
+	// Unique identifier
+	ID   string
+	Role Role
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	for _, indent := range []string{"", "    ", "\t"} {
+		p := NewParser()
+		if err := p.Walk(tmpDir); err != nil {
+			t.Fatalf("Walk() error = %v", err)
+		}
+		p.MatchEnumConstants()
+
+		cfg := NewConfig()
+		cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+		cfg.GenStrategy = GenStrategySingle
+		cfg.DisableHeader = true
+		cfg.Indent = indent
+
+		files, err := NewGenerator(p, cfg).Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		schema := files[cfg.Output]
+
+		want := indent
+		if want == "" {
+			want = DefaultIndent
+		}
+
+		// Every line inside a type or enum body uses exactly the configured indentation
+		inBody, bodyLines := false, 0
+		for _, line := range strings.Split(schema, "\n") {
+			switch {
+			case strings.HasSuffix(line, "{"):
+				inBody = true
+			case line == "}":
+				inBody = false
+			case inBody:
+				bodyLines++
+				rest, found := strings.CutPrefix(line, want)
+				if !found || strings.TrimLeft(rest, " \t") != rest {
+					t.Errorf("indent %q: expected line %q to be indented with exactly %q", indent, line, want)
+				}
+			}
+		}
+		// id with its description, role, the extra field with its description, and the enum values
+		if bodyLines < 7 {
+			t.Errorf("indent %q: expected the type, extra field and enum lines, got:\n%s", indent, schema)
+		}
+	}
+}
+
+func TestConfigValidateIndent(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Normalize()
+	cfg.Packages = []string{"."}
+	cfg.Output = "graph/schema"
+	cfg.Indent = "--"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected an error for an indent that is not whitespace")
+	}
+}
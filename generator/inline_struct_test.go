@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const inlineStructSource = `package models
+
+// @gqlType
+// @gqlInput
+type User struct {
+	ID   string
+	Meta struct {
+		CreatedAt string
+		UpdatedAt string
+	}
+	Notes []*struct {
+		Text string
+	}
+}
+
+// @gqlType
+type Team struct {
+	Members []struct {
+		Name    string
+		Address *struct{ City string }
+	}
+}
+`
+
+func buildInlineStructSchema(t *testing.T, enabled bool) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(inlineStructSource), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.InlineStructTypes = enabled
+
+	files, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return files[cfg.Output]
+}
+
+func TestInlineStructTypes(t *testing.T) {
+	schema := buildInlineStructSchema(t, true)
+
+	user := schemaBlock(schema, "type User {")
+	for _, want := range []string{"meta: UserMeta!", "notes: [UserNotes!]!"} {
+		if !strings.Contains(user, want) {
+			t.Errorf("Expected %q in User, got:\n%s", want, user)
+		}
+	}
+	if meta := schemaBlock(schema, "type UserMeta {"); !strings.Contains(meta, "createdAt: String!") || !strings.Contains(meta, "updatedAt: String!") {
+		t.Errorf("Expected the UserMeta type with the struct fields, got:\n%s", schema)
+	}
+
+	// Inputs reference the synthesized inputs
+	if input := schemaBlock(schema, "input UserInput"); !strings.Contains(input, "meta: UserMetaInput!") {
+		t.Errorf("Expected meta: UserMetaInput! in UserInput, got:\n%s", input)
+	}
+	if !strings.Contains(schema, "input UserMetaInput {") {
+		t.Errorf("Expected the UserMetaInput input, got:\n%s", schema)
+	}
+
+	// Nested inline structs are named after the synthesized parent
+	if members := schemaBlock(schema, "type TeamMembers {"); !strings.Contains(members, "address: TeamMembersAddress!") {
+		t.Errorf("Expected address: TeamMembersAddress! in TeamMembers, got:\n%s", members)
+	}
+	if !strings.Contains(schemaBlock(schema, "type Team {"), "members: [TeamMembers!]!") {
+		t.Errorf("Expected members: [TeamMembers!]! in Team, got:\n%s", schema)
+	}
+	if strings.Count(schema, "type TeamMembersAddress {") != 1 {
+		t.Errorf("Expected TeamMembersAddress once, got:\n%s", schema)
+	}
+}
+
+func TestInlineStructTypesDisabled(t *testing.T) {
+	schema := buildInlineStructSchema(t, false)
+
+	if !strings.Contains(schemaBlock(schema, "type User {"), "meta: String!") {
+		t.Errorf("Expected meta to stay a String without inline_struct_types, got:\n%s", schema)
+	}
+	if strings.Contains(schema, "UserMeta") {
+		t.Errorf("Expected no synthesized types, got:\n%s", schema)
+	}
+}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInputReferenceCycles(t *testing.T) {
+	src := `package models
+
+// @gqlInput
+type Author struct {
+	Name     string ` + "`gql:\"name\"`" + `
+	Favorite Book   ` + "`gql:\"favorite\"`" + `
+}
+
+// @gqlInput
+type Book struct {
+	Title  string ` + "`gql:\"title\"`" + `
+	Author Author ` + "`gql:\"author\"`" + `
+}
+
+// Lists end the recursion, so this pair is fine
+// @gqlInput
+type Folder struct {
+	Files []File ` + "`gql:\"files\"`" + `
+}
+
+// @gqlInput
+type File struct {
+	Folder Folder ` + "`gql:\"folder\"`" + `
+}
+`
+
+	build := func(t *testing.T, relax bool) (string, error) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+
+		p := NewParser()
+		if err := p.Walk(tmpDir); err != nil {
+			t.Fatalf("Walk() error = %v", err)
+		}
+
+		config := NewConfig()
+		config.Output = filepath.Join(tmpDir, "schema.graphqls")
+		config.GenStrategy = GenStrategySingle
+		config.RelaxInputCycles = relax
+
+		files, err := NewGenerator(p, config).Build()
+		return files[config.Output], err
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		_, err := build(t, false)
+		if err == nil {
+			t.Fatal("Expected an error for the AuthorInput/BookInput cycle")
+		}
+		if !strings.Contains(err.Error(), "  - AuthorInput.favorite -> BookInput.author -> AuthorInput\n") {
+			t.Errorf("Expected the cycle path in the error, got: %v", err)
+		}
+		if strings.Contains(err.Error(), "FolderInput") {
+			t.Errorf("Did not expect the list-based cycle to be reported, got: %v", err)
+		}
+	})
+
+	t.Run("relax", func(t *testing.T) {
+		schema, err := build(t, true)
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		for _, want := range []string{"  favorite: BookInput!\n", "  author: AuthorInput\n", "  folder: FolderInput!\n"} {
+			if !strings.Contains(schema, want) {
+				t.Errorf("Expected %q in schema, got:\n%s", want, schema)
+			}
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInputFieldDefaults(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlEnum
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "ASC"
+	SortOrderDesc SortOrder = "DESC"
+)
+
+// @gqlType
+// @gqlInput(name:"SearchInput")
+type Search struct {
+	Limit     int       ` + "`gql:\"limit,default:20\"`" + `
+	Query     string    ` + "`gql:\"query,default:all\"`" + `
+	Quoted    string    ` + "`gql:\"quoted,default:'hello world'\"`" + `
+	Archived  bool      ` + "`gql:\"archived,default:false\"`" + `
+	Order     SortOrder ` + "`gql:\"order,default:ASC\"`" + `
+	Threshold float64   ` + "`gql:\"threshold,optional,default:0.5\"`" + `
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "search.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+	p.MatchEnumConstants()
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+
+	gen := NewGenerator(p, cfg)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	schema := string(content)
+
+	inputStart := strings.Index(schema, "input SearchInput {")
+	if inputStart == -1 {
+		t.Fatalf("Expected SearchInput, got:\n%s", schema)
+	}
+	input := schema[inputStart:]
+	input = input[:strings.Index(input, "}")]
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"int", "limit: Int! = 20"},
+		{"string", `query: String! = "all"`},
+		{"quoted string", `quoted: String! = "hello world"`},
+		{"boolean", "archived: Boolean! = false"},
+		{"enum", "order: SortOrder! = ASC"},
+		{"optional float", "threshold: Float = 0.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(input, tt.want) {
+				t.Errorf("Expected input to contain %q, got:\n%s", tt.want, input)
+			}
+		})
+	}
+
+	// Defaults are invalid on output types and must be dropped there
+	typeStart := strings.Index(schema, "type Search {")
+	if typeStart == -1 {
+		t.Fatalf("Expected Search type, got:\n%s", schema)
+	}
+	typeDef := schema[typeStart:]
+	typeDef = typeDef[:strings.Index(typeDef, "}")]
+	if strings.Contains(typeDef, " = ") {
+		t.Errorf("Did not expect default values on type fields, got:\n%s", typeDef)
+	}
+}
@@ -0,0 +1,139 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInputFromStruct(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+type Address struct {
+	Street string
+}
+
+type CreateUserRequest struct {
+	Name     string
+	Email    string
+	Password string
+	Address  Address
+}
+
+// @gqlType
+// @gqlInput(name:"CreateUserInput", from:"CreateUserRequest")
+// @gqlInputExtraField(name:"captcha",type:"String!")
+type User struct {
+	ID   string ` + "`gql:\"id,type:ID\"`" + `
+	Name string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+	config.UseGqlGenDirectives = true
+	config.AutoGenerate.Enabled = true
+	config.AutoGenerate.Strategy = AutoGenReferenced
+	config.AutoGenerate.OutOfScopeTypes = OutOfScopeFail
+
+	gen := NewGenerator(p, config)
+	files, err := gen.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[config.Output]
+
+	input := schemaBlock(schema, "input CreateUserInput")
+	if input == "" {
+		t.Fatalf("Expected input CreateUserInput, got:\n%s", schema)
+	}
+	pkgPath := p.GetPackageImportPath("CreateUserRequest", config.ModelPath)
+	for _, want := range []string{
+		`@goModel(model: "` + pkgPath + `.CreateUserRequest")`,
+		"  name: String!",
+		"  email: String!",
+		"  password: String!",
+		"  address: AddressInput!",
+		"captcha: String!",
+	} {
+		if !strings.Contains(input, want) {
+			t.Errorf("Expected %q in CreateUserInput, got:\n%s", want, input)
+		}
+	}
+	if strings.Contains(input, "id:") {
+		t.Errorf("Expected CreateUserInput to take no fields from User, got:\n%s", input)
+	}
+
+	// The referenced struct's nested types get inputs, the output type keeps its own fields
+	if !strings.Contains(schema, "input AddressInput") {
+		t.Errorf("Expected AddressInput to be auto-generated, got:\n%s", schema)
+	}
+	if user := schemaBlock(schema, "type User"); !strings.Contains(user, "id: ID") || strings.Contains(user, "password") {
+		t.Errorf("Expected User to keep its own fields, got:\n%s", user)
+	}
+	if strings.Contains(schema, "CreateUserRequestInput") || strings.Contains(schema, "input UserInput") {
+		t.Errorf("Expected no other inputs, got:\n%s", schema)
+	}
+
+	for _, item := range gen.GeneratedItems {
+		if item.GQLName == "CreateUserInput" && item.GoTypeName != "CreateUserRequest" {
+			t.Errorf("Expected CreateUserInput to be registered for CreateUserRequest, got %q", item.GoTypeName)
+		}
+	}
+}
+
+func TestInputFromUnknownStruct(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlType
+// @gqlInput(name:"CreateUserInput", from:"CreateUserRequest")
+type User struct {
+	Name string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+
+	_, err := NewGenerator(p, config).Build()
+	if err == nil {
+		t.Fatal("Expected an error for an unknown from struct")
+	}
+	if !strings.Contains(err.Error(), "CreateUserInput (on User) takes its fields from CreateUserRequest") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+// schemaBlock returns the definition starting with header, up to its closing brace
+func schemaBlock(schema, header string) string {
+	start := strings.Index(schema, header)
+	if start < 0 {
+		return ""
+	}
+	end := strings.Index(schema[start:], "}\n")
+	if end < 0 {
+		return schema[start:]
+	}
+	return schema[start : start+end+1]
+}
@@ -0,0 +1,188 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGqlInterface(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlInterface
+type Node struct {
+	ID string
+}
+
+// @gqlInterface(name:"Timestamped", description:"Has timestamps", fields:"createdAt")
+type Timestamps struct {
+	CreatedAt string
+	Revision  int
+}
+
+// @gqlType
+type User struct {
+	Node
+	Timestamps
+	Name   string
+	Parent *Node
+}
+
+// @gqlType
+type Post struct {
+	Node
+	Title string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.UseGqlGenDirectives = true
+	cfg.ModelPath = "example.com/models"
+
+	gen := NewGenerator(p, cfg)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	schema := string(content)
+
+	for _, want := range []string{
+		"interface Node @goModel(model: \"example.com/models.Node\") {\n  id: String!\n}",
+		"\"Has timestamps\"\ninterface Timestamped @goModel(model: \"example.com/models.Timestamps\") {\n  createdAt: String!\n}",
+		"type User implements Node & Timestamped @goModel(",
+		"type Post implements Node @goModel(",
+		"parent: Node!",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain:\n%s\ngot:\n%s", want, schema)
+		}
+	}
+
+	// Interface-backing structs must not be auto-generated as object types
+	if strings.Contains(schema, "type Node ") || strings.Contains(schema, "type Timestamps ") {
+		t.Errorf("Did not expect object types for interface structs, got:\n%s", schema)
+	}
+}
+
+func TestGqlInterfacePackageStrategy(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlInterface
+type Node struct {
+	ID string
+}
+
+// @gqlType
+type User struct {
+	Node
+	Name string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema")
+	cfg.GenStrategy = GenStrategyPackage
+
+	files, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	var schema string
+	for _, content := range files {
+		schema += content
+	}
+	if !strings.Contains(schema, "interface Node {") || !strings.Contains(schema, "type User implements Node {") {
+		t.Errorf("Expected interface and implementing type, got:\n%s", schema)
+	}
+}
+
+func TestGqlTypeImplementsParameter(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlInterface
+type Node struct {
+	ID string
+}
+
+// @gqlType(implements:"Node")
+type Tag struct {
+	ID   string
+	Name string
+}
+
+// @gqlType(name:"User", implements:"Node,Timestamped")
+type UserModel struct {
+	ID        string
+	CreatedAt string
+}
+
+// @gqlType(implements:"Node,Searchable")
+type Post struct {
+	Node
+	Title string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.UseGqlGenDirectives = true
+	cfg.ModelPath = "example.com/models"
+
+	gen := NewGenerator(p, cfg)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	schema := string(content)
+
+	for _, want := range []string{
+		"type Tag implements Node @goModel(model: \"example.com/models.Tag\") {",
+		"type User implements Node & Timestamped @goModel(model: \"example.com/models.UserModel\") {",
+		// Explicit and embedded interfaces are merged without duplicates
+		"type Post implements Node & Searchable @goModel(model: \"example.com/models.Post\") {",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain:\n%s\ngot:\n%s", want, schema)
+		}
+	}
+}
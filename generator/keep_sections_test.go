@@ -0,0 +1,206 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteFilePreservesKeepSections(t *testing.T) {
+	tmpDir := t.TempDir()
+	output := filepath.Join(tmpDir, "schema.graphqls")
+
+	cfg := NewConfig()
+	cfg.Normalize()
+
+	existing := `# @gqlKeepBegin
+extend type Query {
+    me: User!
+}
+# @gqlKeepEnd
+
+type Old {
+    id: String!
+}
+
+# @gqlKeepBegin
+scalar Upload
+# @gqlKeepEnd
+`
+	if err := os.WriteFile(output, []byte(existing), 0644); err != nil {
+		t.Fatalf("Failed to write existing schema: %v", err)
+	}
+
+	generated := "type User {\n    id: String!\n}\n"
+	if err := WriteFile(output, generated, cfg); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	schema := string(content)
+
+	if strings.Contains(schema, "type Old") {
+		t.Errorf("Expected content outside keep markers to be replaced, got:\n%s", schema)
+	}
+
+	queryIdx := strings.Index(schema, "extend type Query {\n    me: User!\n}")
+	uploadIdx := strings.Index(schema, "# @gqlKeepBegin\nscalar Upload\n# @gqlKeepEnd")
+	userIdx := strings.Index(schema, "type User {")
+	if queryIdx == -1 || uploadIdx == -1 {
+		t.Fatalf("Expected both keep blocks to be preserved, got:\n%s", schema)
+	}
+	if !(userIdx < queryIdx && queryIdx < uploadIdx) {
+		t.Errorf("Expected keep blocks after generated content and in original order, got:\n%s", schema)
+	}
+	if n := strings.Count(schema, "# @gqlKeepBegin"); n != 2 {
+		t.Errorf("Expected 2 keep blocks, got %d:\n%s", n, schema)
+	}
+
+	// Regenerating must be stable
+	if err := WriteFile(output, generated, cfg); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	again, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	if string(again) != schema {
+		t.Errorf("Expected regeneration to be stable, first:\n%s\nsecond:\n%s", schema, string(again))
+	}
+}
+
+func TestWriteFileKeepSectionsAtStart(t *testing.T) {
+	tmpDir := t.TempDir()
+	output := filepath.Join(tmpDir, "schema.graphqls")
+
+	cfg := NewConfig()
+	cfg.KeepSectionPlacement = "start"
+	cfg.Normalize()
+
+	existing := "type Old {\n    id: String!\n}\n\n# @gqlKeepBegin\nscalar Upload\n# @gqlKeepEnd\n"
+	if err := os.WriteFile(output, []byte(existing), 0644); err != nil {
+		t.Fatalf("Failed to write existing schema: %v", err)
+	}
+
+	if err := WriteFile(output, "type User {\n    id: String!\n}\n", cfg); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	schema := string(content)
+
+	if strings.Index(schema, "scalar Upload") > strings.Index(schema, "type User") {
+		t.Errorf("Expected keep block before generated content, got:\n%s", schema)
+	}
+}
+
+func TestWriteFileNewFileGetsKeepPlaceholder(t *testing.T) {
+	tmpDir := t.TempDir()
+	output := filepath.Join(tmpDir, "schema.graphqls")
+
+	cfg := NewConfig()
+	cfg.Normalize()
+
+	generated := "type User {\n    id: String!\n}\n"
+	if err := WriteFile(output, generated, cfg); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	first, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+
+	// Brand-new files get the placeholder markers right away
+	if !strings.Contains(string(first), cfg.KeepBeginMarker+"\n# You can add custom types") || !strings.Contains(string(first), cfg.KeepEndMarker) {
+		t.Errorf("Expected placeholder keep markers in a new file, got:\n%s", first)
+	}
+
+	// So regenerating the same schema is a no-op, and a check right after generation passes
+	rendered, err := RenderFileContent(output, generated, cfg)
+	if err != nil {
+		t.Fatalf("RenderFileContent failed: %v", err)
+	}
+	if rendered != string(first) {
+		t.Errorf("Expected regeneration to be a no-op, got:\n%s\nwant:\n%s", rendered, first)
+	}
+}
+
+func TestWriteFileHeader(t *testing.T) {
+	tmpDir := t.TempDir()
+	output := filepath.Join(tmpDir, "schema.graphqls")
+	generated := "type User {\n    id: String!\n}\n"
+
+	write := func(t *testing.T, cfg *Config) string {
+		t.Helper()
+		if err := WriteFile(output, generated, cfg); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		content, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatalf("Failed to read schema: %v", err)
+		}
+		return string(content)
+	}
+
+	t.Run("default", func(t *testing.T) {
+		cfg := NewConfig()
+		cfg.Normalize()
+
+		schema := write(t, cfg)
+		if !strings.HasPrefix(schema, "# "+DefaultHeaderText+"\n") {
+			t.Errorf("Expected the standard header first, got:\n%s", schema)
+		}
+
+		// Regenerating with keep sections still writes the header once
+		if err := os.WriteFile(output, []byte(schema+"\n# @gqlKeepBegin\nscalar Upload\n# @gqlKeepEnd\n"), 0644); err != nil {
+			t.Fatalf("Failed to write existing schema: %v", err)
+		}
+		schema = write(t, cfg)
+		if n := strings.Count(schema, DefaultHeaderText); n != 1 {
+			t.Errorf("Expected the header once, got %d:\n%s", n, schema)
+		}
+		if !strings.Contains(schema, "scalar Upload") {
+			t.Errorf("Expected the keep section to be preserved, got:\n%s", schema)
+		}
+	})
+
+	t.Run("zero config", func(t *testing.T) {
+		// A Config built without NewConfig keeps the header too
+		cfg := &Config{}
+		cfg.Normalize()
+
+		schema := write(t, cfg)
+		if !strings.HasPrefix(schema, "# "+DefaultHeaderText+"\n") {
+			t.Errorf("Expected the standard header first, got:\n%s", schema)
+		}
+	})
+
+	t.Run("custom text", func(t *testing.T) {
+		cfg := NewConfig()
+		cfg.HeaderText = "Generated schema\n# Edit the Go models instead"
+		cfg.Normalize()
+
+		schema := write(t, cfg)
+		if !strings.HasPrefix(schema, "# Generated schema\n# Edit the Go models instead\n") {
+			t.Errorf("Expected the custom header, got:\n%s", schema)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg := NewConfig()
+		cfg.DisableHeader = true
+		cfg.Normalize()
+
+		schema := write(t, cfg)
+		if strings.Contains(schema, "Code generated") || strings.Contains(schema, "PUT YOUR CUSTOM CONTENT") {
+			t.Errorf("Expected no header, got:\n%s", schema)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"go/parser"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestListNullability(t *testing.T) {
+	tests := []struct {
+		goType   string
+		expected string
+		input    string
+	}{
+		{"[]string", "[String!]!", "[String!]!"},
+		{"[]*string", "[String]!", "[String]!"},
+		{"*[]string", "[String!]", "[String!]"},
+		{"*[]*string", "[String]", "[String]"},
+		{"[][]string", "[[String!]!]!", "[[String!]!]!"},
+		{"[]*[]int", "[[Int!]]!", "[[Int!]]!"},
+		// Object elements stay non-null, gqlgen binds object lists to pointer slices either way
+		{"[]*User", "[User!]!", "[UserInput!]!"},
+		{"*[]*User", "[User!]", "[UserInput!]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goType, func(t *testing.T) {
+			expr, err := parser.ParseExpr(tt.goType)
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", tt.goType, err)
+			}
+			if got := ExprToGraphQLType(expr); got != tt.expected {
+				t.Errorf("ExprToGraphQLType(%s) = %s, expected %s", tt.goType, got, tt.expected)
+			}
+			if got := ExprToGraphQLTypeForInput(expr, nil, nil); got != tt.input {
+				t.Errorf("ExprToGraphQLTypeForInput(%s) = %s, expected %s", tt.goType, got, tt.input)
+			}
+		})
+	}
+}
+
+func TestListNullabilityInSchema(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlEnum
+type Status string
+
+const (
+	StatusActive Status = "active"
+)
+
+// @gqlScalar(name:"DateTime")
+type Timestamp string
+
+// @gqlType
+type Tag struct {
+	Name string
+}
+
+// @gqlType
+type Post struct {
+	Labels   []*string
+	Statuses []*Status
+	Tags     []*Tag
+	Dates    []*Timestamp
+	Archive  *[]Timestamp
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+	p.MatchEnumConstants()
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+
+	schema, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	for _, content := range schema {
+		for _, want := range []string{
+			"labels: [String]!",
+			"statuses: [Status]!",
+			// Object lists keep non-null elements (gqlgen uses pointer slices either way)
+			"tags: [Tag!]!",
+			"dates: [DateTime]!",
+			"archive: [DateTime!]",
+		} {
+			if !strings.Contains(content, want) {
+				t.Errorf("Expected schema to contain %q, got:\n%s", want, content)
+			}
+		}
+	}
+}
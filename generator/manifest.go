@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Manifest is the machine-readable description of a generated schema (format: json)
+type Manifest struct {
+	// Version is the gqlschemagen version that produced the manifest
+	Version string `json:"version"`
+	// Items lists every generated schema item, sorted by GraphQL name
+	Items []ManifestItem `json:"items"`
+}
+
+// ManifestItem describes a generated type, input, interface, union, enum or scalar
+type ManifestItem struct {
+	Kind          string           `json:"kind"`
+	Name          string           `json:"name"`
+	GoType        string           `json:"goType,omitempty"`
+	GoTypeName    string           `json:"goTypeName,omitempty"`
+	Package       string           `json:"package,omitempty"`
+	SourceFile    string           `json:"sourceFile,omitempty"`
+	SchemaFile    string           `json:"schemaFile,omitempty"`
+	Namespace     string           `json:"namespace,omitempty"`
+	AutoGenerated bool             `json:"autoGenerated,omitempty"`
+	Extension     bool             `json:"extension,omitempty"`
+	Fields        []GQLSchemaField `json:"fields,omitempty"`
+	Values        []string         `json:"values,omitempty"`
+}
+
+// buildManifest assembles the manifest from the items registered during Build
+func (g *Generator) buildManifest() Manifest {
+	items := make([]ManifestItem, 0, len(g.GeneratedItems))
+	for _, item := range g.GeneratedItems {
+		goType, pkg := item.GoType, ""
+		if item.GoType != "" {
+			if strings.HasSuffix(item.GoType, "."+item.GoTypeName) {
+				pkg = strings.TrimSuffix(item.GoType, "."+item.GoTypeName)
+			} else {
+				// Enums only record the Go type name
+				pkg = g.P.GetPackageImportPath(item.GoTypeName, g.Config.ModelPath)
+				goType = pkg + "." + item.GoTypeName
+			}
+		}
+
+		// Schema file paths are meaningless when the output is stdout
+		schemaFile := item.OutputFile
+		if g.Config.Output == OutputStdout {
+			schemaFile = ""
+		}
+
+		items = append(items, ManifestItem{
+			Kind:          item.GQLKind,
+			Name:          item.GQLName,
+			GoType:        goType,
+			GoTypeName:    item.GoTypeName,
+			Package:       pkg,
+			SourceFile:    item.GoSourceFile,
+			SchemaFile:    schemaFile,
+			Namespace:     item.Namespace,
+			AutoGenerated: item.AutoGenerated,
+			Extension:     item.Extension,
+			Fields:        item.Fields,
+			Values:        item.Values,
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Name != items[j].Name {
+			return items[i].Name < items[j].Name
+		}
+		return items[i].Kind < items[j].Kind
+	})
+
+	return Manifest{Version: GetVersion(), Items: items}
+}
+
+// manifestPath returns the file the manifest is written to
+func (g *Generator) manifestPath() string {
+	if strings.HasSuffix(g.Config.Output, ".json") {
+		return g.Config.Output
+	}
+	return filepath.Join(g.outputDir(), ManifestFileName)
+}
+
+// manifestFiles returns the JSON manifest of the generated schema items keyed by the file it is written to
+// It replaces the schema files, and goes through the same check, dry-run and write steps
+func (g *Generator) manifestFiles() (map[string]string, error) {
+	data, err := json.MarshalIndent(g.buildManifest(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{g.manifestPath(): string(data) + "\n"}, nil
+}
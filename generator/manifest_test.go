@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestJSONManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlEnum
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"  // @gqlEnumValue(name:"ADMIN")
+	RoleViewer Role = "viewer" // @gqlEnumValue(name:"VIEWER")
+)
+
+// @gqlScalar(name:"Money")
+type Money int64
+
+// @gqlType
+// @gqlInput(name:"UserInput")
+// @gqlTypeExtraField(name:"posts",type:"[String!]!")
+type User struct {
+	ID    string ` + "`gql:\"id,type:ID!,ro\"`" + `
+	Name  string ` + "`gql:\"name\"`" + `
+	Role  Role   ` + "`gql:\"role\"`" + `
+	Money Money  ` + "`gql:\"money,optional\"`" + `
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Packages = []string{tmpDir}
+	cfg.GenStrategy = GenStrategySingle
+	cfg.Output = filepath.Join(tmpDir, "out")
+	cfg.Format = FormatJSON
+
+	if err := Generate(cfg); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if FileExists(filepath.Join(cfg.Output, cfg.OutputFileName)) {
+		t.Error("format json must not write the schema file")
+	}
+
+	data, err := os.ReadFile(filepath.Join(cfg.Output, ManifestFileName))
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("Manifest is not valid JSON: %v\n%s", err, data)
+	}
+
+	items := map[string]ManifestItem{}
+	for _, item := range manifest.Items {
+		items[item.Kind+" "+item.Name] = item
+	}
+
+	user, ok := items["type User"]
+	if !ok {
+		t.Fatalf("Expected type User in manifest, got:\n%s", data)
+	}
+	if user.GoTypeName != "User" || user.Package == "" || user.GoType != user.Package+".User" {
+		t.Errorf("Unexpected Go type info for User: %+v", user)
+	}
+	if user.SourceFile != filepath.Join(tmpDir, "models.go") {
+		t.Errorf("Expected User source file, got %q", user.SourceFile)
+	}
+	wantFields := []GQLSchemaField{
+		{Name: "id", Type: "ID!"},
+		{Name: "name", Type: "String!"},
+		{Name: "role", Type: "Role!"},
+		{Name: "money", Type: "Money"},
+		{Name: "posts", Type: "[String!]!"},
+	}
+	if !slices.Equal(user.Fields, wantFields) {
+		t.Errorf("Unexpected User fields: %+v, want %+v", user.Fields, wantFields)
+	}
+
+	input, ok := items["input UserInput"]
+	if !ok {
+		t.Fatalf("Expected input UserInput in manifest, got:\n%s", data)
+	}
+	wantInputFields := []GQLSchemaField{
+		{Name: "name", Type: "String!"},
+		{Name: "role", Type: "Role!"},
+		{Name: "money", Type: "Money"},
+	}
+	if !slices.Equal(input.Fields, wantInputFields) {
+		t.Errorf("Unexpected UserInput fields: %+v, want %+v", input.Fields, wantInputFields)
+	}
+
+	role, ok := items["enum Role"]
+	if !ok {
+		t.Fatalf("Expected enum Role in manifest, got:\n%s", data)
+	}
+	if role.GoType != user.Package+".Role" {
+		t.Errorf("Expected enum Go type %q, got %q", user.Package+".Role", role.GoType)
+	}
+	if len(role.Values) != 2 || role.Values[0] != "ADMIN" || role.Values[1] != "VIEWER" {
+		t.Errorf("Unexpected Role values: %v", role.Values)
+	}
+
+	if money, ok := items["scalar Money"]; !ok || money.GoTypeName != "Money" {
+		t.Errorf("Expected scalar Money in manifest, got:\n%s", data)
+	}
+}
+
+func TestJSONManifestOutputFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := "package models\n\n// @gqlType\ntype User struct {\n\tID string\n}\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "user.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Packages = []string{tmpDir}
+	cfg.GenStrategy = GenStrategyPackage
+	cfg.Output = filepath.Join(tmpDir, "schema", "types.json")
+	cfg.Format = FormatJSON
+
+	if err := Generate(cfg); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !FileExists(cfg.Output) {
+		t.Fatalf("Expected manifest at %s", cfg.Output)
+	}
+
+	// Check and dry runs compare the manifest like any schema file
+	cfg.CheckOnly = true
+	if err := Generate(cfg); err != nil {
+		t.Errorf("Expected an up-to-date manifest to pass the check, got %v", err)
+	}
+	if err := os.WriteFile(cfg.Output, []byte("{}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	if err := Generate(cfg); !errors.Is(err, ErrSchemaChanged) {
+		t.Errorf("Expected ErrSchemaChanged for a stale manifest, got %v", err)
+	}
+	cfg.CheckOnly = false
+	cfg.DryRun = true
+	if err := Generate(cfg); !errors.Is(err, ErrSchemaChanged) {
+		t.Errorf("Expected a dry run to report the stale manifest, got %v", err)
+	}
+}
+
+func TestConfigValidateFormat(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Normalize()
+	cfg.Packages = []string{"."}
+	cfg.Output = "graph/schema"
+	cfg.Format = "yaml"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected an error for an invalid format")
+	}
+
+	// The manifest is a single document, so stdout works with any strategy
+	cfg.Format = FormatJSON
+	cfg.Output = OutputStdout
+	cfg.GenStrategy = GenStrategyPackage
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected json to stdout to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func generateMapPairsSchema(t *testing.T, src string, configure func(*Config)) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	configure(cfg)
+
+	gen := NewGenerator(p, cfg)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	return string(content)
+}
+
+const mapPairsSource = `package models
+
+// @gqlType
+// @gqlInput
+type Product struct {
+	Name string
+}
+
+// @gqlType
+// @gqlInput
+type Catalog struct {
+	Products map[string]*Product
+	Tags     map[string]string ` + "`gql:\"tags,pairs:false\"`" + `
+}
+`
+
+func TestMapAsPairs(t *testing.T) {
+	schema := generateMapPairsSchema(t, mapPairsSource, func(cfg *Config) {
+		cfg.MapAsPairs = true
+	})
+
+	expected := []string{
+		"products: [StringProductPair!]!",
+		"type StringProductPair {\n  key: String!\n  value: Product!\n}",
+		"products: [StringProductPairInput!]!",
+		"input StringProductPairInput {\n  key: String!\n  value: ProductInput!\n}",
+		"tags: Map!",
+	}
+	for _, want := range expected {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain %q, got:\n%s", want, schema)
+		}
+	}
+	if strings.Count(schema, "type StringProductPair ") != 1 {
+		t.Errorf("Pair type should be declared exactly once, got:\n%s", schema)
+	}
+}
+
+func TestMapAsPairsFieldOverrideAndTypeName(t *testing.T) {
+	src := `package models
+
+// @gqlType
+type Product struct {
+	Name string
+}
+
+// @gqlType
+type Catalog struct {
+	Products map[string]*Product ` + "`gql:\"products,pairs\"`" + `
+	Counts   map[string]int
+}
+`
+	schema := generateMapPairsSchema(t, src, func(cfg *Config) {
+		cfg.MapPairTypeName = "{Value}By{Key}Entry"
+	})
+
+	if !strings.Contains(schema, "products: [ProductByStringEntry!]!") {
+		t.Errorf("Expected pairs override on products, got:\n%s", schema)
+	}
+	if !strings.Contains(schema, "type ProductByStringEntry {") {
+		t.Errorf("Expected custom pair type name, got:\n%s", schema)
+	}
+	if !strings.Contains(schema, "counts: Map!") {
+		t.Errorf("Maps without override should keep the default rendering, got:\n%s", schema)
+	}
+}
+
+func TestMapFieldsRenderAsMapScalar(t *testing.T) {
+	src := `package models
+
+// @gqlType
+// @gqlInput
+type Settings struct {
+	Values   map[string]string
+	Pointer  *map[string]int
+	Groups   map[string][]string
+	Nested   map[string]map[string]int
+	Raw      map[string]any ` + "`gql:\"raw,type:JSON\"`" + `
+}
+`
+	schema := generateMapPairsSchema(t, src, func(cfg *Config) {
+		cfg.AutoGenerate.OutOfScopeTypes = OutOfScopeFail
+	})
+
+	for _, want := range []string{
+		"type Settings {\n  values: Map!\n  pointer: Map!\n  groups: Map!\n  nested: Map!\n  raw: JSON\n}",
+		"input SettingsInput {\n  values: Map!\n  pointer: Map!\n  groups: Map!\n  nested: Map!\n  raw: JSON\n}",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain:\n%s\ngot:\n%s", want, schema)
+		}
+	}
+
+	schema = generateMapPairsSchema(t, src, func(cfg *Config) {
+		cfg.MapScalar = "StringMap"
+		cfg.AutoGenerate.OutOfScopeTypes = OutOfScopeFail
+	})
+	if !strings.Contains(schema, "values: StringMap!") || !strings.Contains(schema, "nested: StringMap!") {
+		t.Errorf("Expected map fields to use the configured map scalar, got:\n%s", schema)
+	}
+}
@@ -0,0 +1,205 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// mergeMarkerPrefix starts the marker opening a block owned by the generator (# gqlschemagen:type=User)
+	mergeMarkerPrefix = "# gqlschemagen:"
+	// mergeEndMarker closes a block owned by the generator
+	mergeEndMarker = mergeMarkerPrefix + "end"
+)
+
+// definitionKeywords are the keywords starting a top-level definition in generated content
+var definitionKeywords = map[string]bool{
+	"type": true, "input": true, "enum": true, "interface": true, "union": true,
+	"scalar": true, "extend": true, "directive": true, "schema": true,
+}
+
+// schemaDefinition is a top-level definition of generated content, description included
+type schemaDefinition struct {
+	Key  string // Marker key, e.g. "type=User" or "extend-type=Query"
+	Text string // Definition text without trailing newlines
+}
+
+// managedSegment is a piece of an existing file: hand-written text, or a block owned by the generator
+type managedSegment struct {
+	Key  string // Marker key, "" for hand-written text
+	Text string // Segment text, markers included for managed blocks
+}
+
+// renderMerged returns the content of path in merge mode: the managed blocks of the existing
+// file replaced by the generated definitions, stale blocks removed and new definitions appended at the end
+func renderMerged(path, content string, config *Config) (string, error) {
+	definitions := splitDefinitions(content)
+	if !FileExists(path) {
+		blocks := make([]string, 0, len(definitions))
+		for _, def := range definitions {
+			blocks = append(blocks, def.block())
+		}
+		return postProcess(path, normalizeNewlines(fileHeader(config)+strings.Join(blocks, "\n")), config)
+	}
+
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	segments, err := splitManagedBlocks(string(existing))
+	if err != nil {
+		return "", fmt.Errorf("merge %s: %w", path, err)
+	}
+
+	replaced := make([]bool, len(segments))
+	var appended []string
+	for _, def := range definitions {
+		found := false
+		for i, segment := range segments {
+			if segment.Key == def.Key && !replaced[i] {
+				segments[i].Text = normalizeNewlines(def.block())
+				replaced[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			appended = append(appended, normalizeNewlines(def.block()))
+		}
+	}
+
+	// The generator owns the managed blocks: those no longer generated (renamed or deleted types) are dropped
+	// Hand-written text is kept verbatim, except the blank lines separating blocks, which collapse
+	// into a single one so dropped blocks don't leave their separators behind
+	var buf strings.Builder
+	for i, segment := range segments {
+		if segment.Key != "" && !replaced[i] {
+			continue
+		}
+		if strings.TrimSpace(segment.Text) == "" {
+			if buf.Len() > 0 && !strings.HasSuffix(buf.String(), "\n\n") {
+				buf.WriteString("\n")
+			}
+			continue
+		}
+		buf.WriteString(segment.Text)
+	}
+	merged := strings.TrimRight(buf.String(), "\n")
+	if len(appended) > 0 {
+		if merged != "" {
+			merged += "\n\n"
+		}
+		merged += strings.Join(appended, "\n")
+	}
+	if merged != "" && !strings.HasSuffix(merged, "\n") {
+		merged += "\n"
+	}
+	return postProcess(path, merged, config)
+}
+
+// block returns the definition wrapped in its merge markers
+func (d schemaDefinition) block() string {
+	return mergeMarkerPrefix + d.Key + "\n" + d.Text + "\n" + mergeEndMarker + "\n"
+}
+
+// splitDefinitions splits generated content into its top-level definitions. A definition
+// starts at its keyword line, or at the description right above it, and ends where the next begins
+func splitDefinitions(content string) []schemaDefinition {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+
+	// Lines inside a top-level """block""" description never start a definition
+	inBlock := make([]bool, len(lines))
+	open := false
+	for i, line := range lines {
+		if line == `"""` {
+			inBlock[i] = true
+			open = !open
+			continue
+		}
+		inBlock[i] = open
+	}
+
+	var starts []int
+	var keys []string
+	for i, line := range lines {
+		if inBlock[i] {
+			continue
+		}
+		key := definitionKey(line)
+		if key == "" {
+			continue
+		}
+		start := i
+		for start > 0 && (inBlock[start-1] || strings.HasPrefix(lines[start-1], `"`)) {
+			start--
+		}
+		starts = append(starts, start)
+		keys = append(keys, key)
+	}
+
+	definitions := make([]schemaDefinition, 0, len(starts))
+	for i, start := range starts {
+		end := len(lines)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		if i == 0 {
+			start = 0
+		}
+		text := strings.Trim(strings.Join(lines[start:end], "\n"), "\n")
+		definitions = append(definitions, schemaDefinition{Key: keys[i], Text: text})
+	}
+	return definitions
+}
+
+// definitionKey returns the merge marker key of a top-level definition line
+// ("type User {" -> "type=User", "extend type Query {" -> "extend-type=Query"), or "" for other lines
+func definitionKey(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || line[0] == ' ' || line[0] == '\t' || !definitionKeywords[fields[0]] {
+		return ""
+	}
+	kind, rest := fields[0], fields[1:]
+	if kind == "extend" && len(rest) > 0 {
+		kind, rest = "extend-"+rest[0], rest[1:]
+	}
+	name := kind
+	if len(rest) > 0 {
+		name = strings.TrimRight(strings.SplitN(rest[0], "(", 2)[0], "{=")
+	}
+	return kind + "=" + name
+}
+
+// splitManagedBlocks splits an existing file into hand-written text and managed blocks, in order
+func splitManagedBlocks(content string) ([]managedSegment, error) {
+	var segments []managedSegment
+	var text strings.Builder
+	key := ""
+	for _, line := range strings.SplitAfter(content, "\n") {
+		trimmed := strings.TrimRight(line, " \t\r\n")
+		switch {
+		case key == "" && strings.HasPrefix(trimmed, mergeMarkerPrefix) && trimmed != mergeEndMarker:
+			if text.Len() > 0 {
+				segments = append(segments, managedSegment{Text: text.String()})
+				text.Reset()
+			}
+			key = strings.TrimPrefix(trimmed, mergeMarkerPrefix)
+			text.WriteString(line)
+		case key != "" && trimmed == mergeEndMarker:
+			text.WriteString(line)
+			segments = append(segments, managedSegment{Key: key, Text: text.String()})
+			text.Reset()
+			key = ""
+		default:
+			text.WriteString(line)
+		}
+	}
+	if key != "" {
+		return nil, fmt.Errorf("block %q has no %q marker", mergeMarkerPrefix+key, mergeEndMarker)
+	}
+	if text.Len() > 0 {
+		segments = append(segments, managedSegment{Text: text.String()})
+	}
+	return segments, nil
+}
@@ -0,0 +1,198 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const mergeModeSource = `package models
+
+// @gqlType
+type User struct {
+	ID   string
+	Name string
+}
+
+// @gqlType
+type Post struct {
+	Title string
+}
+`
+
+func runMergeMode(t *testing.T, tmpDir string) string {
+	t.Helper()
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.MergeMode = true
+
+	if err := NewGenerator(p, cfg).Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	content, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	return string(content)
+}
+
+func TestMergeModeReplacesAndAppendsBlocks(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(mergeModeSource), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	base := `# Hand-maintained schema
+type Query {
+  me: User
+}
+
+# gqlschemagen:type=User
+type User {
+  id: String!
+  legacy: Int
+}
+# gqlschemagen:end
+
+scalar Upload
+
+
+
+scalar Date
+`
+	output := filepath.Join(tmpDir, "schema.graphqls")
+	if err := os.WriteFile(output, []byte(base), 0644); err != nil {
+		t.Fatalf("Failed to write base schema: %v", err)
+	}
+
+	schema := runMergeMode(t, tmpDir)
+
+	// The managed User block is replaced in place
+	wantUser := "# gqlschemagen:type=User\ntype User {\n  id: String!\n  name: String!\n}\n# gqlschemagen:end\n\nscalar Upload\n"
+	if !strings.Contains(schema, wantUser) {
+		t.Errorf("Expected the User block replaced in place, got:\n%s", schema)
+	}
+	if strings.Contains(schema, "legacy") {
+		t.Errorf("Expected the old User body to be gone, got:\n%s", schema)
+	}
+
+	// Hand-written definitions are left intact, without a header or keep sections
+	if !strings.HasPrefix(schema, "# Hand-maintained schema\ntype Query {\n  me: User\n}\n") {
+		t.Errorf("Expected the hand-written content first, got:\n%s", schema)
+	}
+	if strings.Contains(schema, "@gqlKeepBegin") {
+		t.Errorf("Expected no keep sections in merge mode, got:\n%s", schema)
+	}
+	if !strings.Contains(schema, "scalar Upload\n\n\n\nscalar Date\n") {
+		t.Errorf("Expected the hand-written blank lines to be kept, got:\n%s", schema)
+	}
+
+	// Post has no block yet, so it is appended
+	wantPost := "scalar Date\n\n# gqlschemagen:type=Post\ntype Post"
+	if !strings.Contains(schema, wantPost) || !strings.HasSuffix(schema, "# gqlschemagen:end\n") {
+		t.Errorf("Expected a Post block appended, got:\n%s", schema)
+	}
+
+	// Merging again changes nothing
+	if again := runMergeMode(t, tmpDir); again != schema {
+		t.Errorf("Expected a second merge to be a no-op, got:\n%s", again)
+	}
+}
+
+func TestMergeModeRemovesStaleBlocks(t *testing.T) {
+	tmpDir := t.TempDir()
+	source := filepath.Join(tmpDir, "models.go")
+	if err := os.WriteFile(source, []byte(mergeModeSource), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	output := filepath.Join(tmpDir, "schema.graphqls")
+	if err := os.WriteFile(output, []byte("type Query {\n  posts: [Post!]!\n}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write base schema: %v", err)
+	}
+	runMergeMode(t, tmpDir)
+
+	// Post is renamed to Article between runs
+	renamed := strings.Replace(mergeModeSource, "type Post struct", "type Article struct", 1)
+	if err := os.WriteFile(source, []byte(renamed), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	schema := runMergeMode(t, tmpDir)
+
+	if strings.Contains(schema, "type=Post") || strings.Contains(schema, "type Post") {
+		t.Errorf("Expected the stale Post block to be removed, got:\n%s", schema)
+	}
+	for _, want := range []string{"type Query {\n  posts: [Post!]!\n}\n", "# gqlschemagen:type=User\n", "# gqlschemagen:type=Article\ntype Article"} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected %q, got:\n%s", want, schema)
+		}
+	}
+	if strings.Count(schema, mergeEndMarker) != 2 {
+		t.Errorf("Expected only the User and Article blocks, got:\n%s", schema)
+	}
+	if strings.Contains(schema, "\n\n\n") {
+		t.Errorf("Expected the removed block to leave a single blank line, got:\n%s", schema)
+	}
+}
+
+func TestMergeModeNewFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(mergeModeSource), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	schema := runMergeMode(t, tmpDir)
+	if !strings.HasPrefix(schema, "# Code generated by") {
+		t.Errorf("Expected new files to start with the header, got:\n%s", schema)
+	}
+	if strings.Count(schema, mergeEndMarker) != 2 {
+		t.Errorf("Expected a block per type, got:\n%s", schema)
+	}
+	for _, want := range []string{"# gqlschemagen:type=User\ntype User", "# gqlschemagen:type=Post\ntype Post"} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected %q, got:\n%s", want, schema)
+		}
+	}
+}
+
+func TestSplitDefinitions(t *testing.T) {
+	content := "scalar DateTime\n\n\"\"\"\nA user\nof the app\n\"\"\"\ntype User {\n  \"Name\"\n  name: String!\n}\n\n\"Roles\"\nenum Role {\n  ADMIN\n}\n\nextend type Query {\n  me: User\n}\n\nunion Result = User | Post\n"
+
+	defs := splitDefinitions(content)
+	var keys []string
+	for _, def := range defs {
+		keys = append(keys, def.Key)
+	}
+	if got := strings.Join(keys, ","); got != "scalar=DateTime,type=User,enum=Role,extend-type=Query,union=Result" {
+		t.Fatalf("Unexpected definitions %s", got)
+	}
+
+	// Descriptions belong to the definition below them
+	if want := "\"\"\"\nA user\nof the app\n\"\"\"\ntype User {\n  \"Name\"\n  name: String!\n}"; defs[1].Text != want {
+		t.Errorf("Expected User with its description, got:\n%s", defs[1].Text)
+	}
+	if !strings.HasPrefix(defs[2].Text, "\"Roles\"\nenum Role") {
+		t.Errorf("Expected Role with its description, got:\n%s", defs[2].Text)
+	}
+}
+
+func TestMergeModeUnterminatedBlock(t *testing.T) {
+	tmpDir := t.TempDir()
+	output := filepath.Join(tmpDir, "schema.graphqls")
+	if err := os.WriteFile(output, []byte("# gqlschemagen:type=User\ntype User {\n  id: ID!\n}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write base schema: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.MergeMode = true
+	_, err := RenderFileContent(output, "type User {\n  id: ID!\n}\n", cfg)
+	if err == nil || !strings.Contains(err.Error(), mergeEndMarker) {
+		t.Errorf("Expected an error about the missing end marker, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const nameCollisionSource = `package models
+
+// @gqlType
+type User struct {
+	ID string
+}
+
+// @gqlType
+type DBUser struct {
+	ID   string
+	Hash string
+}
+
+// @gqlEnum(name:"Role")
+type UserRole string
+
+const (
+	UserRoleAdmin UserRole = "admin"
+)
+
+// @gqlInput(name:"Role")
+type RoleInput struct {
+	Name string
+}
+`
+
+func buildNameCollisionSchema(t *testing.T, allowDuplicates bool) (map[string]string, error) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(nameCollisionSource), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+	p.MatchEnumConstants()
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.StripPrefix = "DB"
+	cfg.AllowDuplicateNames = allowDuplicates
+
+	return NewGenerator(p, cfg).Build()
+}
+
+func TestNameCollisionsFail(t *testing.T) {
+	_, err := buildNameCollisionSchema(t, false)
+	if err == nil {
+		t.Fatal("Expected generation to fail on duplicate GraphQL names")
+	}
+
+	msg := err.Error()
+	for _, want := range []string{
+		"User is defined by:",
+		"type models.User",
+		"type models.DBUser",
+		"Role is defined by:",
+		"enum UserRole",
+		"input models.RoleInput",
+		"allow_duplicate_names",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected error to contain %q, got:\n%s", want, msg)
+		}
+	}
+}
+
+func TestNameCollisionsAllowed(t *testing.T) {
+	fileContents, err := buildNameCollisionSchema(t, true)
+	if err != nil {
+		t.Fatalf("Expected duplicates to be allowed, got: %v", err)
+	}
+
+	for _, content := range fileContents {
+		if n := strings.Count(content, "type User {"); n != 2 {
+			t.Errorf("Expected both User types to be emitted, got %d:\n%s", n, content)
+		}
+	}
+}
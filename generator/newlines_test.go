@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileNormalizesNewlines(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "schema.graphqls")
+
+	cfg := NewConfig()
+	cfg.DisableHeader = true
+	cfg.KeepSectionPlacement = "start"
+	cfg.Normalize()
+
+	content := "type User {\n  id: ID!\n}\n\n\n\nenum Role {\n  ADMIN\n}\ntype Post {\n  title: String!\n}\n\n\n"
+	if err := WriteFile(path, content, cfg); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+
+	want := cfg.KeepBeginMarker + "\n# You can add custom types or comments here and they will be preserved during code generation.\n" + cfg.KeepEndMarker + "\n\n" +
+		"type User {\n  id: ID!\n}\n\nenum Role {\n  ADMIN\n}\ntype Post {\n  title: String!\n}\n"
+	if string(got) != want {
+		t.Errorf("Expected normalized content:\n%q\ngot:\n%q", want, got)
+	}
+
+	// Trailing keep sections are followed by exactly one newline too
+	cfg.KeepSectionPlacement = "end"
+	if err := WriteFile(path, "type User {\n  id: ID!\n}\n\n", cfg); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	got, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	want = "type User {\n  id: ID!\n}\n\n" + cfg.KeepBeginMarker + "\n# You can add custom types or comments here and they will be preserved during code generation.\n" + cfg.KeepEndMarker + "\n"
+	if string(got) != want {
+		t.Errorf("Expected normalized content:\n%q\ngot:\n%q", want, got)
+	}
+}
+
+func TestWriteFileKeepsKeepSectionsVerbatim(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "schema.graphqls")
+
+	cfg := NewConfig()
+	cfg.DisableHeader = true
+	cfg.Normalize()
+
+	keep := cfg.KeepBeginMarker + "\ntype Custom {\n  id: ID!\n}\n\n\n\nscalar Upload\n" + cfg.KeepEndMarker
+	if err := os.WriteFile(path, []byte("type User {\n  id: ID!\n}\n\n"+keep+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write schema: %v", err)
+	}
+
+	if err := WriteFile(path, "type User {\n  id: ID!\n}\n\n\n", cfg); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+
+	want := "type User {\n  id: ID!\n}\n\n" + keep + "\n"
+	if string(got) != want {
+		t.Errorf("Expected the keep section untouched:\n%q\ngot:\n%q", want, got)
+	}
+}
+
+func TestNormalizeNewlines(t *testing.T) {
+	tests := map[string]string{
+		"":                       "",
+		"\n\n":                   "",
+		"type A {\n}":            "type A {\n}\n",
+		"type A {\n}\n\n\n\n":    "type A {\n}\n",
+		"a\n\n\nb\n\n\n\n\nc\n":  "a\n\nb\n\nc\n",
+		"a\n\nb\n":               "a\n\nb\n",
+		"scalar A\nscalar B\n\n": "scalar A\nscalar B\n",
+		// Blank lines inside block strings belong to the description
+		"\"\"\"\nFirst\n\n\nSecond\n\"\"\"\n\n\ntype A {\n}\n": "\"\"\"\nFirst\n\n\nSecond\n\"\"\"\n\ntype A {\n}\n",
+		"\"\"\"Quote \\\"\"\"\n\n\nstill\"\"\"\n\n\nb\n":       "\"\"\"Quote \\\"\"\"\n\n\nstill\"\"\"\n\nb\n",
+	}
+	for in, want := range tests {
+		if got := normalizeNewlines(in); got != want {
+			t.Errorf("normalizeNewlines(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const omitemptySource = `package models
+
+// @gqlType
+// @gqlInput
+type Contact struct {
+	Name    string   ` + "`json:\"name\"`" + `
+	Email   string   ` + "`json:\"email,omitempty\"`" + `
+	Tags    []string ` + "`json:\"tags,omitempty\"`" + `
+	Phone   string   ` + "`json:\"phone,omitempty\" gql:\"phone,required\"`" + `
+	Country string   ` + "`json:\"country,omitempty\" gql:\"country,type:String!\"`" + `
+}
+`
+
+func TestOmitemptyNullable(t *testing.T) {
+	tests := []struct {
+		name       string
+		enabled    bool
+		useJsonTag bool
+		want       []string
+	}{
+		{
+			name:       "disabled",
+			enabled:    false,
+			useJsonTag: true,
+			want:       []string{"name: String!", "email: String!", "tags: [String!]!", "phone: String!", "country: String!"},
+		},
+		{
+			name:       "enabled",
+			enabled:    true,
+			useJsonTag: true,
+			want:       []string{"name: String!", "email: String\n", "tags: [String!]\n", "phone: String!", "country: String!"},
+		},
+		{
+			name:       "enabled without json tags",
+			enabled:    true,
+			useJsonTag: false,
+			want:       []string{"name: String!", "email: String!", "tags: [String!]!"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(omitemptySource), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			p := NewParser()
+			if err := p.Walk(tmpDir); err != nil {
+				t.Fatalf("Walk() error = %v", err)
+			}
+
+			cfg := NewConfig()
+			cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+			cfg.GenStrategy = GenStrategySingle
+			cfg.OmitemptyNullable = tt.enabled
+			cfg.UseJsonTag = tt.useJsonTag
+
+			files, err := NewGenerator(p, cfg).Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			schema := files[cfg.Output]
+
+			for _, header := range []string{"type Contact {", "input ContactInput {"} {
+				block := schemaBlock(schema, header)
+				for _, want := range tt.want {
+					if !strings.Contains(block, want) {
+						t.Errorf("Expected %q in %s, got:\n%s", want, header, block)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOutOfScopePlaceholder(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+import "example.com/external/geo"
+
+// @gqlType
+type Place struct {
+	Name     string
+	Location *geo.Point
+	Bounds   []geo.Point
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "place.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		scalar      string
+		wantLoc     string
+		wantBounds  string
+		wantComment string
+	}{
+		{
+			name:        "default scalar",
+			scalar:      "",
+			wantLoc:     "location: String!",
+			wantBounds:  "bounds: [String!]!",
+			wantComment: "# TODO: define scalar Point",
+		},
+		{
+			name:        "custom scalar",
+			scalar:      "JSON",
+			wantLoc:     "location: JSON!",
+			wantBounds:  "bounds: [JSON!]!",
+			wantComment: "# TODO: define scalar Point",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser()
+			if err := p.Walk(tmpDir); err != nil {
+				t.Fatalf("Failed to walk: %v", err)
+			}
+
+			cfg := NewConfig()
+			cfg.Output = filepath.Join(t.TempDir(), "schema.graphqls")
+			cfg.GenStrategy = GenStrategySingle
+			cfg.AutoGenerate.OutOfScopeTypes = OutOfScopePlaceholder
+			if tt.scalar != "" {
+				cfg.AutoGenerate.PlaceholderScalar = tt.scalar
+			}
+
+			gen := NewGenerator(p, cfg)
+			if err := gen.Run(); err != nil {
+				t.Fatalf("Generation failed: %v", err)
+			}
+
+			content, err := os.ReadFile(cfg.Output)
+			if err != nil {
+				t.Fatalf("Failed to read schema: %v", err)
+			}
+			schema := string(content)
+
+			for _, want := range []string{tt.wantLoc, tt.wantBounds, tt.wantComment} {
+				if !strings.Contains(schema, want) {
+					t.Errorf("Expected schema to contain %q, got:\n%s", want, schema)
+				}
+			}
+			if strings.Contains(schema, ": Point") || strings.Contains(schema, "[Point") {
+				t.Errorf("Undefined type Point should not be referenced, got:\n%s", schema)
+			}
+		})
+	}
+}
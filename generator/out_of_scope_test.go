@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOutOfScopeActions(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+import "example.com/external/geo"
+
+// @gqlType
+type Place struct {
+	Name     string
+	Visits   int
+	Location *geo.Point
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "place.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	tests := []struct {
+		action       OutOfScopeAction
+		wantErr      bool
+		wantLocation bool
+	}{
+		{action: OutOfScopeWarn, wantLocation: true},
+		{action: OutOfScopeFail, wantErr: true},
+		{action: OutOfScopeIgnore, wantLocation: true},
+		{action: OutOfScopeExclude, wantLocation: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.action), func(t *testing.T) {
+			p := NewParser()
+			if err := p.Walk(tmpDir); err != nil {
+				t.Fatalf("Failed to walk: %v", err)
+			}
+
+			// A config file without known_scalars must still treat built-in scalars as in scope
+			cfgPath := filepath.Join(t.TempDir(), "gqlschemagen.yml")
+			cfgContent := "strategy: single\nauto_generate:\n  out_of_scope_types: " + string(tt.action) + "\n"
+			if err := os.WriteFile(cfgPath, []byte(cfgContent), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			cfg, err := LoadConfigFromFile(cfgPath)
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+
+			gen := NewGenerator(p, cfg)
+			fileContents, err := gen.Build()
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "Type 'Point'") {
+					t.Fatalf("Expected an out-of-scope error for Point, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Generation failed: %v", err)
+			}
+
+			if len(gen.OutOfScopeTypes) != 1 || gen.OutOfScopeTypes["Point"] == nil {
+				t.Errorf("Expected only Point to be out of scope, got %v", gen.OutOfScopeTypes)
+			}
+
+			var schema strings.Builder
+			for _, content := range fileContents {
+				schema.WriteString(content)
+			}
+			if !strings.Contains(schema.String(), "name: String!") || !strings.Contains(schema.String(), "visits: Int!") {
+				t.Errorf("Expected scalar fields to be kept, got:\n%s", schema.String())
+			}
+			if got := strings.Contains(schema.String(), "location: Point!"); got != tt.wantLocation {
+				t.Errorf("Expected location field present=%v, got:\n%s", tt.wantLocation, schema.String())
+			}
+		})
+	}
+}
+
+func TestOutOfScopeEmbeddedType(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+import "example.com/external/geo"
+
+type Named interface {
+	Name() string
+}
+
+// @gqlType
+// @gqlInput
+type Place struct {
+	geo.Point
+	Named
+	Title string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "place.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.AutoGenerate.OutOfScopeTypes = OutOfScopeFail
+
+	_, err := NewGenerator(p, cfg).Build()
+	if err == nil {
+		t.Fatal("Expected an error for the dropped embedded types")
+	}
+	for _, want := range []string{
+		"Type 'Point'",
+		"Type 'Named'",
+		"  - Place (embedded, its fields were dropped)\n    Go: models.Place embeds geo.Point\n",
+		"  - PlaceInput (embedded, its fields were dropped)\n",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain:\n%s\ngot:\n%s", want, err.Error())
+		}
+	}
+
+	// Each embedded type is reported once per generated type or input
+	if strings.Count(err.Error(), "Place (embedded") != 2 {
+		t.Errorf("Expected one report per embedded type, got:\n%s", err.Error())
+	}
+}
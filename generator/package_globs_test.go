@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParsePackagesGlobs(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"a/b/c/models/user.go":   "package models\n\n// @gqlType\ntype User struct {\n\tID string\n}\n",
+		"a/models/post.go":       "package models\n\n// @gqlType\ntype Post struct {\n\tID string\n}\n",
+		"a/b/other/note.go":      "package other\n\n// @gqlType\ntype Note struct {\n\tID string\n}\n",
+		"a/b/c/models/notes.txt": "not go",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+	}
+
+	parse := func(t *testing.T, pkgs ...string) *Parser {
+		t.Helper()
+		p := NewParser()
+		if err := p.ParsePackages(pkgs); err != nil {
+			t.Fatalf("ParsePackages() error = %v", err)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    []string
+		notWant []string
+	}{
+		{"files across levels", "a/**/*.go", []string{"User", "Post", "Note"}, nil},
+		{"nested models dirs", "a/**/models", []string{"User", "Post"}, []string{"Note"}},
+		{"files under models", "a/**/models/*.go", []string{"User", "Post"}, []string{"Note"}},
+		{"single level wildcard", "a/*/other", []string{"Note"}, []string{"User", "Post"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parse(t, filepath.Join(tmpDir, tt.pattern))
+			for _, name := range tt.want {
+				if _, ok := p.StructTypes[name]; !ok {
+					t.Errorf("Expected %s to be parsed with %s", name, tt.pattern)
+				}
+			}
+			for _, name := range tt.notWant {
+				if _, ok := p.StructTypes[name]; ok {
+					t.Errorf("Expected %s not to be parsed with %s", name, tt.pattern)
+				}
+			}
+		})
+	}
+
+	t.Run("no matches", func(t *testing.T) {
+		p := parse(t, filepath.Join(tmpDir, "a/**/missing/*.go"), filepath.Join(tmpDir, "nope/**"))
+		if len(p.StructTypes) != 0 {
+			t.Errorf("Expected no types, got %d", len(p.StructTypes))
+		}
+	})
+
+	t.Run("plain paths unchanged", func(t *testing.T) {
+		p := parse(t, filepath.Join(tmpDir, "a", "models"))
+		if _, ok := p.StructTypes["Post"]; !ok || len(p.StructTypes) != 1 {
+			t.Errorf("Expected only Post, got %d types", len(p.StructTypes))
+		}
+	})
+
+	t.Run("generate", func(t *testing.T) {
+		cfg := NewConfig()
+		cfg.Packages = []string{filepath.Join(tmpDir, "a/**/models")}
+		cfg.GenStrategy = GenStrategySingle
+		cfg.Output = filepath.Join(tmpDir, "out")
+		if err := Generate(cfg); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.Output, cfg.OutputFileName))
+		if err != nil {
+			t.Fatalf("Failed to read schema: %v", err)
+		}
+		if !strings.Contains(string(data), "type User") || !strings.Contains(string(data), "type Post") {
+			t.Errorf("Expected User and Post in schema, got:\n%s", data)
+		}
+	})
+}
+
+func TestPackageRoot(t *testing.T) {
+	tests := map[string]string{
+		"./models":         "./models",
+		"./models/**/*.go": "models",
+		"./**/models":      ".",
+		"/src/app/**":      "/src/app",
+		"/**/models":       "/",
+	}
+	for pkg, want := range tests {
+		if got := PackageRoot(pkg); got != filepath.FromSlash(want) {
+			t.Errorf("PackageRoot(%q) = %q, want %q", pkg, got, want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPackageOutputMap(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.24\n",
+		"internal/billing/invoice.go": `package billing
+
+// @gqlType
+type Invoice struct {
+	ID string
+}
+`,
+		"models/user.go": `package models
+
+// @gqlType
+type User struct {
+	ID string
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	outDir := filepath.Join(tmpDir, "out")
+	cfg := NewConfig()
+	cfg.Output = outDir
+	cfg.GenStrategy = GenStrategyPackage
+	cfg.PackageOutputMap = map[string]string{
+		"*/internal/billing": "billing",
+	}
+
+	gen := NewGenerator(p, cfg)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	if !FileExists(filepath.Join(outDir, "billing", "billing.graphqls")) {
+		t.Errorf("Expected billing package in %s", filepath.Join(outDir, "billing"))
+	}
+	if !FileExists(filepath.Join(outDir, "models.graphqls")) {
+		t.Errorf("Expected unmapped models package at the output root")
+	}
+	if FileExists(filepath.Join(outDir, "billing.graphqls")) {
+		t.Errorf("Mapped package should not be written to the output root")
+	}
+}
+
+func TestMatchPackagePattern(t *testing.T) {
+	tests := []struct {
+		pattern    string
+		importPath string
+		want       bool
+	}{
+		{"*/internal/billing", "github.com/acme/app/internal/billing", true},
+		{"*/internal/billing", "github.com/acme/app/internal/billing/v2", false},
+		{"github.com/acme/*/models", "github.com/acme/app/models", true},
+		{"models", "github.com/acme/app/models", true},
+		{"*/internal/*", "github.com/acme/app/models", false},
+	}
+	for _, tt := range tests {
+		if got := matchPackagePattern(tt.pattern, tt.importPath); got != tt.want {
+			t.Errorf("matchPackagePattern(%q, %q) = %v, want %v", tt.pattern, tt.importPath, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// writeManyModels writes count files spread over a few packages, each with an annotated struct,
+// an enum whose values live in the next file, and a struct referencing the previous file
+func writeManyModels(tb testing.TB, count int) string {
+	tb.Helper()
+	tmpDir := tb.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/app\n\ngo 1.24\n"), 0644); err != nil {
+		tb.Fatalf("Failed to write go.mod: %v", err)
+	}
+	for i := range count {
+		dir := filepath.Join(tmpDir, fmt.Sprintf("pkg%d", i%4))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			tb.Fatalf("Failed to create dir: %v", err)
+		}
+		src := fmt.Sprintf(`package models
+
+// @gqlEnum
+type Kind%[1]d string
+
+const (
+	Kind%[2]dA Kind%[2]d = "a"
+	Kind%[2]dB Kind%[2]d = "b"
+)
+
+// @gqlType
+// @gqlInput
+type Model%[1]d struct {
+	ID    string
+	Count int
+	Tags  []string
+}
+
+// @gqlType
+type Holder%[1]d struct {
+	Model Model%[1]d
+	Kind  Kind%[1]d
+}
+`, i, (i+count-1)%count)
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("model%d.go", i)), []byte(src), 0644); err != nil {
+			tb.Fatalf("Failed to write test file: %v", err)
+		}
+	}
+	return tmpDir
+}
+
+func TestParallelParseMatchesSerial(t *testing.T) {
+	tmpDir := writeManyModels(t, 40)
+
+	build := func(workers int) (*Parser, string) {
+		p := NewParser()
+		p.Workers = workers
+		if err := p.Walk(tmpDir); err != nil {
+			t.Fatalf("Walk() error = %v", err)
+		}
+		p.MatchEnumConstants()
+
+		cfg := NewConfig()
+		cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+		cfg.GenStrategy = GenStrategySingle
+		files, err := NewGenerator(p, cfg).Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		return p, files[cfg.Output]
+	}
+
+	serial, serialSchema := build(1)
+	parallel, parallelSchema := build(8)
+
+	if !slices.Equal(serial.TypeNames, parallel.TypeNames) {
+		t.Errorf("TypeNames differ:\nserial:   %v\nparallel: %v", serial.TypeNames, parallel.TypeNames)
+	}
+	if !slices.Equal(serial.EnumNames, parallel.EnumNames) {
+		t.Errorf("EnumNames differ:\nserial:   %v\nparallel: %v", serial.EnumNames, parallel.EnumNames)
+	}
+	if serialSchema != parallelSchema {
+		t.Errorf("Parallel schema differs from serial schema:\nserial:\n%s\nparallel:\n%s", serialSchema, parallelSchema)
+	}
+	if len(serial.EnumNames) != 40 || len(serial.EnumTypes["Kind0"].Values) != 2 {
+		t.Errorf("Expected 40 enums with their values, got %d", len(serial.EnumNames))
+	}
+}
+
+func TestParallelParseReportsFirstError(t *testing.T) {
+	tmpDir := writeManyModels(t, 8)
+	for _, name := range []string{"broken_a.go", "broken_b.go"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, "pkg0", name), []byte("package models\n\ntype {"), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+	}
+
+	// The first broken file in walk order is reported, whichever worker parsed it
+	err := NewParser().Walk(tmpDir)
+	if err == nil {
+		t.Fatal("Expected a parse error")
+	}
+	want := "parse " + filepath.Join(tmpDir, "pkg0", "broken_a.go")
+	if got := err.Error(); len(got) < len(want) || got[:len(want)] != want {
+		t.Errorf("Expected error to start with %q, got %q", want, got)
+	}
+}
+
+func benchmarkWalk(b *testing.B, workers int) {
+	tmpDir := writeManyModels(b, 400)
+	b.ResetTimer()
+	for range b.N {
+		p := NewParser()
+		p.Workers = workers
+		if err := p.Walk(tmpDir); err != nil {
+			b.Fatalf("Walk() error = %v", err)
+		}
+	}
+}
+
+func BenchmarkWalkSerial(b *testing.B) { benchmarkWalk(b, 1) }
+
+func BenchmarkWalkParallel(b *testing.B) { benchmarkWalk(b, 0) }
@@ -3,12 +3,19 @@ package generator
 import (
 	"fmt"
 	"go/ast"
+	"go/build"
 	"go/parser"
 	"go/token"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
 	"golang.org/x/tools/go/packages"
 )
@@ -17,6 +24,7 @@ import (
 type EnumValue struct {
 	GoName      string      // e.g., "PermissionRead"
 	GraphQLName string      // e.g., "READ"
+	GoValue     string      // Go identifier @goEnum references instead of GoName (value: param), optionally qualified by an import path or an alias imported by the file
 	Value       interface{} // The actual value (string literal or int)
 	Description string      // From comment
 	Deprecated  string      // Deprecation reason if any
@@ -33,6 +41,18 @@ type EnumType struct {
 	Values      []EnumValue
 	TypeSpec    *ast.TypeSpec
 	GenDecl     *ast.GenDecl
+	// @gqlDeprecated(reason:"...") on the enum type, rendered according to Config.TypeDeprecationMode
+	Deprecated       bool
+	DeprecatedReason string
+}
+
+// ScalarType represents a custom scalar declared with @gqlScalar on a Go type
+type ScalarType struct {
+	Name        string // GraphQL scalar name (custom or derived from Go type)
+	GoTypeName  string // Original Go type name
+	Description string
+	TypeSpec    *ast.TypeSpec
+	GenDecl     *ast.GenDecl
 }
 
 // Parser collects type specs and related AST nodes across a root dir
@@ -47,13 +67,21 @@ type Parser struct {
 	// Enum support
 	EnumTypes map[string]*EnumType
 	EnumNames []string // ordered list for deterministic output
+	// Custom scalar support (@gqlScalar)
+	ScalarTypes map[string]*ScalarType // Go type name -> scalar
+	ScalarNames []string               // ordered list for deterministic output
+	// Interface declarations, so fields of interface type can be mapped to Config.AnyScalar
+	InterfaceTypes map[string]*ast.InterfaceType // Go type name -> interface
 	// Enum candidates collected across all files before matching
 	enumCandidates map[string]*enumCandidate
 	// Const blocks collected for later matching to enum types
 	constBlocks []*constBlockInfo
+	// Plain type aliases (type PublicUser = User) waiting for their target struct to be parsed
+	typeAliases map[string]*typeAliasInfo
 	// Namespace support - maps type/enum name to namespace
-	TypeNamespaces map[string]string // type name -> namespace
-	EnumNamespaces map[string]string // enum name -> namespace
+	TypeNamespaces   map[string]string // type name -> namespace
+	EnumNamespaces   map[string]string // enum name -> namespace
+	ScalarNamespaces map[string]string // scalar name -> namespace
 	// Source files for enums
 	EnumSourceFiles map[string]string // enum name -> source file path
 	// Type parameters for generic types
@@ -63,8 +91,21 @@ type Parser struct {
 	ScannedTypes map[string]*ScannedTypeInfo
 	// Package cache for import path resolution
 	pkgCache map[string]*packages.Package // dir path -> package info
+	// Packages loaded on-demand, nil when loading failed
+	externalPkgs map[string]*packages.Package // import path -> package
+	// Import paths of on-demand loaded packages whose types were already registered
+	registeredPkgs map[string]bool
 	// External types loaded on-demand (not from scanned packages)
 	ExternalTypes map[string]bool // type name -> true if loaded on-demand from external package
+	// Single .go files passed to Walk instead of a package directory
+	singleFiles map[string]bool // cleaned file path -> true
+	// Build tags used to select which files are scanned (e.g., "experimental")
+	// When empty, build constraints are not evaluated and every .go file is scanned
+	BuildTags []string
+	// Globs of files and directories that are never parsed (see Config.ExcludePaths)
+	ExcludePaths []string
+	// Number of files parsed concurrently by Walk, runtime.NumCPU() when zero (1 parses serially)
+	Workers int
 	// Import paths for package aliases (e.g., "uuid" -> "github.com/google/uuid")
 	// This is per-file and gets updated during parsing
 	fileImports map[string]string // package alias/name -> import path
@@ -72,33 +113,43 @@ type Parser struct {
 
 // ScannedTypeInfo stores metadata about a scanned type
 type ScannedTypeInfo struct {
-	TypeName            string   // Go type name
-	HasTypeDirective    bool     // Has @gqlType annotation
-	HasInputDirective   bool     // Has @gqlInput annotation
-	HasIncludeDirective bool     // Has @gqlInclude annotation
-	IsExternal          bool     // Loaded on-demand from external package (not in scanned packages)
-	GeneratedTypes      []string // List of GraphQL type names generated from this struct (from @gqlType)
-	GeneratedInputs     []string // List of GraphQL input names generated from this struct (from @gqlInput)
+	TypeName              string   // Go type name
+	HasTypeDirective      bool     // Has @gqlType annotation
+	HasInputDirective     bool     // Has @gqlInput annotation
+	HasInterfaceDirective bool     // Has @gqlInterface annotation
+	HasUnionDirective     bool     // Has @gqlUnion annotation
+	HasIncludeDirective   bool     // Has @gqlInclude annotation
+	IsExternal            bool     // Loaded on-demand from external package (not in scanned packages)
+	GeneratedTypes        []string // List of GraphQL type names generated from this struct (from @gqlType)
+	GeneratedInputs       []string // List of GraphQL input names generated from this struct (from @gqlInput)
 }
 
+// loadPackages is packages.Load, every package load of the parser goes through it
+var loadPackages = packages.Load
+
 func NewParser() *Parser {
 	return &Parser{
-		StructTypes:     make(map[string]*ast.TypeSpec),
-		PackageNames:    make(map[string]string),
-		PackagePaths:    make(map[string]string),
-		SourceFiles:     make(map[string]string),
-		TypeToDecl:      make(map[string]*ast.GenDecl),
-		EnumTypes:       make(map[string]*EnumType),
-		enumCandidates:  make(map[string]*enumCandidate),
-		constBlocks:     make([]*constBlockInfo, 0),
-		TypeNamespaces:  make(map[string]string),
-		EnumNamespaces:  make(map[string]string),
-		EnumSourceFiles: make(map[string]string),
-		TypeParameters:  make(map[string][]string),
-		ScannedTypes:    make(map[string]*ScannedTypeInfo),
-		pkgCache:        make(map[string]*packages.Package),
-		ExternalTypes:   make(map[string]bool),
-		fileImports:     make(map[string]string),
+		StructTypes:      make(map[string]*ast.TypeSpec),
+		PackageNames:     make(map[string]string),
+		PackagePaths:     make(map[string]string),
+		SourceFiles:      make(map[string]string),
+		TypeToDecl:       make(map[string]*ast.GenDecl),
+		EnumTypes:        make(map[string]*EnumType),
+		ScalarTypes:      make(map[string]*ScalarType),
+		ScalarNamespaces: make(map[string]string),
+		InterfaceTypes:   make(map[string]*ast.InterfaceType),
+		enumCandidates:   make(map[string]*enumCandidate),
+		constBlocks:      make([]*constBlockInfo, 0),
+		typeAliases:      make(map[string]*typeAliasInfo),
+		TypeNamespaces:   make(map[string]string),
+		EnumNamespaces:   make(map[string]string),
+		EnumSourceFiles:  make(map[string]string),
+		TypeParameters:   make(map[string][]string),
+		ScannedTypes:     make(map[string]*ScannedTypeInfo),
+		pkgCache:         make(map[string]*packages.Package),
+		ExternalTypes:    make(map[string]bool),
+		singleFiles:      make(map[string]bool),
+		fileImports:      make(map[string]string),
 	}
 }
 
@@ -117,7 +168,8 @@ func (p *Parser) Walk(root string) error {
 
 	// If it's a file, parse it directly
 	if !info.IsDir() {
-		if strings.HasSuffix(root, ".go") && !strings.HasSuffix(root, "_test.go") {
+		if strings.HasSuffix(root, ".go") && !strings.HasSuffix(root, "_test.go") && !p.isExcludedPath(filepath.Base(root)) {
+			p.singleFiles[root] = true
 			if err := p.parseFile(root); err != nil {
 				return err
 			}
@@ -129,32 +181,192 @@ func (p *Parser) Walk(root string) error {
 		}
 	}
 
+	p.resolveTypeAliases()
+
 	return nil
 }
 
-func (p *Parser) walkDir(root string) error {
+// ParsePackages walks every package directory (or single .go file) in pkgs and then
+// matches enum constants, so enums declared and valued across files or packages resolve
+// Entries may be globs where "**" crosses directories (./models/**/*.go, ./**/models)
+func (p *Parser) ParsePackages(pkgs []string) error {
+	for _, pkg := range pkgs {
+		walk := p.Walk
+		if _, pattern := splitPackageGlob(pkg); pattern != "" {
+			walk = p.walkGlob
+		}
+		if err := walk(PkgDir(pkg)); err != nil {
+			return fmt.Errorf("parse error for package %s: %w", pkg, err)
+		}
+	}
+
+	p.MatchEnumConstants()
+	return nil
+}
+
+// walkGlob walks every file and directory matching a package glob; matched directories are
+// walked recursively. A glob matching nothing is not an error
+func (p *Parser) walkGlob(glob string) error {
+	root, pattern := splitPackageGlob(glob)
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil
+	}
+
 	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
+		if path == root {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if p.isExcludedPath(rel) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !matchGlob(pattern, filepath.ToSlash(rel)) {
+			return nil
+		}
+
+		if err := p.Walk(path); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// Already walked recursively
+			return fs.SkipDir
+		}
+		return nil
+	})
+}
+
+func (p *Parser) walkDir(root string) error {
+	var paths []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Skip excluded files and directories before reading them
+		if path != root {
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			if p.isExcludedPath(rel) {
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+		}
+
 		if d.IsDir() {
 			return nil
 		}
 		if strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") {
-			if err := p.parseFile(path); err != nil {
-				return fmt.Errorf("parse %s: %w", path, err)
-			}
+			paths = append(paths, path)
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// Files are parsed concurrently but registered in walk order, so the output does not
+	// depend on which worker finishes first
+	for _, parsed := range p.parseFiles(paths) {
+		if parsed.err != nil {
+			return fmt.Errorf("parse %s: %w", parsed.path, parsed.err)
+		}
+		if parsed.file != nil {
+			p.registerFile(parsed.path, parsed.file)
+		}
+	}
+	return nil
+}
+
+// parsedFile is the result of reading one file in parseFiles
+type parsedFile struct {
+	path string
+	file *ast.File // nil when the file is skipped by build tags or @gqlExclude
+	err  error
+}
+
+// parseFiles reads and parses paths with a pool of Workers goroutines (runtime.NumCPU() when unset)
+// Each worker writes its own result slots, results are returned in the order of paths
+func (p *Parser) parseFiles(paths []string) []parsedFile {
+	results := make([]parsedFile, len(paths))
+	workers := p.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	workers = min(workers, len(paths))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				f, err := p.readFile(paths[i])
+				results[i] = parsedFile{path: paths[i], file: f, err: err}
+			}
+		}()
+	}
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
 }
 
 func (p *Parser) parseFile(path string) error {
+	f, err := p.readFile(path)
+	if err != nil || f == nil {
+		return err
+	}
+	p.registerFile(path, f)
+	return nil
+}
+
+// readFile parses the file at path, returning nil when its build constraints don't match
+// the configured build tags or it has a file-level @gqlExclude directive
+// It does not touch the parser maps, so it is safe to call concurrently
+func (p *Parser) readFile(path string) (*ast.File, error) {
+	if len(p.BuildTags) > 0 {
+		match, err := p.matchBuildTags(path)
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			return nil, nil
+		}
+	}
+
 	fset := token.NewFileSet()
 	f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// Skip files marked with a file-level @gqlExclude directive
+	if hasFileExcludeDirective(f) {
+		return nil, nil
 	}
+	return f, nil
+}
+
+// registerFile adds the types, enum candidates and const blocks of a parsed file to the parser
+func (p *Parser) registerFile(path string, f *ast.File) {
 	pkgName := f.Name.Name
 
 	// Get the package import path for this file
@@ -180,6 +392,45 @@ func (p *Parser) parseFile(path string) error {
 					continue
 				}
 
+				// Check if it's a custom scalar (any underlying type, including structs)
+				if hasGqlScalarDirective(genDecl) {
+					name := t.Name.Name
+					scalarName, scalarDesc, scalarNamespace := parseScalarDirective(genDecl.Doc, name)
+					p.ScalarTypes[name] = &ScalarType{
+						Name:        scalarName,
+						GoTypeName:  name,
+						Description: scalarDesc,
+						TypeSpec:    t,
+						GenDecl:     genDecl,
+					}
+					p.ScalarNames = appendIfMissing(p.ScalarNames, name)
+					p.PackageNames[name] = pkgName
+					p.PackagePaths[name] = pkgImportPath
+					p.SourceFiles[name] = path
+
+					// Store namespace: scalar-level override takes precedence over file-level
+					if scalarNamespace != "" {
+						p.ScalarNamespaces[name] = scalarNamespace
+					} else if fileNamespace != "" {
+						p.ScalarNamespaces[name] = fileNamespace
+					}
+					continue
+				}
+
+				// Plain aliases (type PublicUser = User) are registered once their target struct is known
+				if target := aliasTargetName(t); target != "" && !hasGqlEnumDirective(genDecl) {
+					p.typeAliases[t.Name.Name] = &typeAliasInfo{
+						TypeSpec:   t,
+						GenDecl:    genDecl,
+						Target:     target,
+						PkgName:    pkgName,
+						FilePath:   path,
+						ImportPath: pkgImportPath,
+						Namespace:  fileNamespace,
+					}
+					continue
+				}
+
 				// Check if it's a struct
 				if _, ok := t.Type.(*ast.StructType); ok {
 					name := t.Name.Name
@@ -210,6 +461,12 @@ func (p *Parser) parseFile(path string) error {
 					continue
 				}
 
+				// Record interfaces so fields of interface type resolve to the any scalar
+				if iface, ok := t.Type.(*ast.InterfaceType); ok {
+					p.InterfaceTypes[t.Name.Name] = iface
+					continue
+				}
+
 				// Check if it's a type alias to a generic instantiation
 				if _, ok := t.Type.(*ast.IndexListExpr); ok {
 					name := t.Name.Name
@@ -276,8 +533,6 @@ func (p *Parser) parseFile(path string) error {
 			FilePath: path,
 		})
 	}
-
-	return nil
 }
 
 // enumCandidate holds info about a potential enum type before we find its const values
@@ -291,6 +546,70 @@ type enumCandidate struct {
 	Namespace  string // File-level namespace
 }
 
+// typeAliasInfo holds a plain type alias until its target struct has been parsed
+type typeAliasInfo struct {
+	TypeSpec   *ast.TypeSpec
+	GenDecl    *ast.GenDecl
+	Target     string // Target type name (package qualifier dropped)
+	PkgName    string
+	FilePath   string
+	ImportPath string
+	Namespace  string // File-level namespace
+}
+
+// aliasTargetName returns the type a plain alias (type X = Y or type X = pkg.Y) refers to,
+// or "" when t is not an alias or is an alias to a generic instantiation
+func aliasTargetName(t *ast.TypeSpec) string {
+	if !t.Assign.IsValid() {
+		return ""
+	}
+	switch target := t.Type.(type) {
+	case *ast.Ident:
+		return target.Name
+	case *ast.SelectorExpr:
+		return target.Sel.Name
+	}
+	return ""
+}
+
+// resolveTypeAliases registers every pending alias whose target struct is known as a struct
+// with the alias name and the target's fields. Aliases of aliases resolve in dependency order;
+// aliases of non-struct types stay pending and are ignored
+func (p *Parser) resolveTypeAliases() {
+	for resolved := true; resolved; {
+		resolved = false
+		for _, name := range sortedKeys(p.typeAliases) {
+			alias := p.typeAliases[name]
+			target, ok := p.StructTypes[alias.Target]
+			if !ok || p.TypeParameters[alias.Target] != nil {
+				continue
+			}
+			structType, ok := target.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+
+			p.StructTypes[name] = &ast.TypeSpec{
+				Doc:    alias.TypeSpec.Doc,
+				Name:   alias.TypeSpec.Name,
+				Assign: alias.TypeSpec.Assign,
+				Type:   structType,
+			}
+			p.PackageNames[name] = alias.PkgName
+			p.PackagePaths[name] = alias.ImportPath
+			p.SourceFiles[name] = alias.FilePath
+			p.TypeToDecl[name] = alias.GenDecl
+			p.TypeNames = appendIfMissing(p.TypeNames, name)
+			if alias.Namespace != "" {
+				p.TypeNamespaces[name] = alias.Namespace
+			}
+
+			delete(p.typeAliases, name)
+			resolved = true
+		}
+	}
+}
+
 // constBlockInfo holds info about a const block for later matching to enum types
 type constBlockInfo struct {
 	GenDecl  *ast.GenDecl
@@ -307,6 +626,113 @@ func appendIfMissing(list []string, v string) []string {
 	return append(list, v)
 }
 
+// isExcludedPath reports whether rel, a path relative to the scanned root, matches one of
+// the ExcludePaths globs, either as a whole or by its base name
+func (p *Parser) isExcludedPath(rel string) bool {
+	rel = filepath.ToSlash(rel)
+	base := path.Base(rel)
+	for _, pattern := range p.ExcludePaths {
+		pattern = filepath.ToSlash(strings.TrimPrefix(pattern, "./"))
+		if matchGlob(pattern, rel) || matchGlob(pattern, base) {
+			return true
+		}
+	}
+	return false
+}
+
+// isExcludedExternalFile reports whether a file of an on-demand loaded package matches ExcludePaths,
+// by its base name or by its path relative to the working directory when it is inside it
+func (p *Parser) isExcludedExternalFile(filePath string) bool {
+	if len(p.ExcludePaths) == 0 {
+		return false
+	}
+	rel := filepath.Base(filePath)
+	if wd, err := os.Getwd(); err == nil {
+		if r, err := filepath.Rel(wd, filePath); err == nil && r != ".." && !strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+			rel = r
+		}
+	}
+	return p.isExcludedPath(rel)
+}
+
+// matchGlob matches a slash-separated name against a glob pattern where "**" as a
+// whole segment matches zero or more segments and other segments use path.Match
+func matchGlob(pattern, name string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchGlobSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// matchBuildTags reports whether the file at path satisfies its build constraints
+// (//go:build lines and _GOOS/_GOARCH file name suffixes) with the configured build tags
+func (p *Parser) matchBuildTags(path string) (bool, error) {
+	ctx := build.Default
+	ctx.BuildTags = p.BuildTags
+	return ctx.MatchFile(filepath.Dir(path), filepath.Base(path))
+}
+
+// buildFlags returns the go/packages build flags for the configured build tags
+func (p *Parser) buildFlags() []string {
+	if len(p.BuildTags) == 0 {
+		return nil
+	}
+	return []string{"-tags=" + strings.Join(p.BuildTags, ",")}
+}
+
+// hasFileExcludeDirective checks if the file has a file-level @gqlExclude directive
+// The directive must appear before the first declaration to apply to the whole file
+func hasFileExcludeDirective(f *ast.File) bool {
+	var firstDoc *ast.CommentGroup
+	if len(f.Decls) > 0 {
+		if genDecl, ok := f.Decls[0].(*ast.GenDecl); ok {
+			firstDoc = genDecl.Doc
+		}
+	}
+
+	for _, commentGroup := range f.Comments {
+		if len(f.Decls) > 0 && commentGroup.Pos() >= f.Decls[0].Pos() {
+			break
+		}
+		// The doc comment of the first declaration belongs to that declaration, not the file
+		if commentGroup == firstDoc {
+			continue
+		}
+		for _, comment := range commentGroup.List {
+			text := strings.TrimPrefix(comment.Text, "//")
+			text = strings.TrimPrefix(text, "/*")
+			text = strings.TrimSuffix(text, "*/")
+			for _, line := range strings.Split(text, "\n") {
+				line = strings.TrimSpace(line)
+				line = strings.TrimPrefix(line, "*")
+				line = strings.TrimSpace(line)
+				if hasDirectiveName(line, "Exclude") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 // extractFileNamespace extracts namespace from file-level @gqlNamespace directive
 // Looks for @gqlNamespace directive in comments anywhere in the file header
 func extractFileNamespace(f *ast.File) string {
@@ -356,7 +782,7 @@ func findNamespaceInComments(cg *ast.CommentGroup) string {
 				if idx := strings.Index(lowerLine, "@gqlnamespace"); idx != -1 {
 					rest := line[idx:]
 					// Find name parameter
-					if nameIdx := strings.Index(rest, "name:"); nameIdx != -1 {
+					if nameIdx := strings.Index(strings.ToLower(rest), "name:"); nameIdx != -1 {
 						nameStart := rest[nameIdx+5:]
 						// Extract quoted value
 						nameStart = strings.TrimSpace(nameStart)
@@ -377,6 +803,12 @@ func findNamespaceInComments(cg *ast.CommentGroup) string {
 func (p *Parser) GetPackageImportPath(typeName string, modelPath string) string {
 	pkgName := p.PackageNames[typeName]
 
+	// Types of a single scanned file resolve through the directory containing it, modelPath
+	// names the package roots and would point them at the module root
+	if p.isSingleFileType(typeName) {
+		modelPath = ""
+	}
+
 	pkgPath, ok := p.PackagePaths[typeName]
 	if !ok {
 		// No package path info, use modelPath if provided (for local types only), otherwise package name
@@ -418,11 +850,12 @@ func (p *Parser) GetPackageImportPath(typeName string, modelPath string) string
 
 	// Load package info using go/packages
 	cfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedFiles | packages.NeedModule,
-		Dir:  dir,
+		Mode:       packages.NeedName | packages.NeedFiles | packages.NeedModule,
+		Dir:        dir,
+		BuildFlags: p.buildFlags(),
 	}
 
-	pkgs, err := packages.Load(cfg, ".")
+	pkgs, err := loadPackages(cfg, ".")
 	if err != nil || len(pkgs) == 0 || pkgs[0].PkgPath == "" {
 		// Fallback to modelPath if package loading fails, or package name if no modelPath
 		p.pkgCache[dir] = nil // cache the failure
@@ -437,6 +870,14 @@ func (p *Parser) GetPackageImportPath(typeName string, modelPath string) string
 	return pkg.PkgPath
 }
 
+// isSingleFileType reports whether typeName is declared in a single .go file passed to Walk
+func (p *Parser) isSingleFileType(typeName string) bool {
+	if path, ok := p.SourceFiles[typeName]; ok {
+		return p.singleFiles[path]
+	}
+	return p.singleFiles[p.EnumSourceFiles[typeName]]
+}
+
 // GetPackageImportPathFromFile builds the import path from a file path using go/packages
 func (p *Parser) GetPackageImportPathFromFile(filePath string, pkgName string, modelPath string) string {
 	// Get the directory containing the file
@@ -451,11 +892,12 @@ func (p *Parser) GetPackageImportPathFromFile(filePath string, pkgName string, m
 
 	// Load package info using go/packages
 	cfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedFiles | packages.NeedModule,
-		Dir:  dir,
+		Mode:       packages.NeedName | packages.NeedFiles | packages.NeedModule,
+		Dir:        dir,
+		BuildFlags: p.buildFlags(),
 	}
 
-	pkgs, err := packages.Load(cfg, ".")
+	pkgs, err := loadPackages(cfg, ".")
 	if err != nil || len(pkgs) == 0 || pkgs[0].PkgPath == "" {
 		// Fallback to modelPath if package loading fails, or package name if no modelPath
 		p.pkgCache[dir] = nil // cache the failure
@@ -517,6 +959,20 @@ func hasGqlEnumDirective(decl *ast.GenDecl) bool {
 	return false
 }
 
+// hasGqlScalarDirective checks if a type declaration has a @gqlScalar directive
+func hasGqlScalarDirective(decl *ast.GenDecl) bool {
+	if decl.Doc == nil {
+		return false
+	}
+	for _, comment := range decl.Doc.List {
+		text := strings.ToLower(comment.Text)
+		if strings.Contains(text, "@gqlscalar") {
+			return true
+		}
+	}
+	return false
+}
+
 // getBaseTypeName extracts the base type name from a type expression
 func getBaseTypeName(expr ast.Expr) string {
 	switch t := expr.(type) {
@@ -577,23 +1033,33 @@ func (p *Parser) parseConstBlock(constBlock *constBlockInfo, enumCandidates map[
 		return
 	}
 
+	// Values declared inline with @gqlEnum(values:"...") replace the const blocks
+	if _, _, _, inlineValues := parseEnumDirective(candidate.GenDecl.Doc, enumTypeName); len(inlineValues) > 0 {
+		return
+	}
+
 	// Parse the const values
+	// iota is the index of the spec in the block, and a spec without values repeats the
+	// expressions of the previous one (e.g., "B" after "A Kind = iota + 1" is iota + 1)
 	var values []EnumValue
-	iotaValue := 0
+	var lastValues []ast.Expr
 
-	for _, spec := range genDecl.Specs {
+	for iotaValue, spec := range genDecl.Specs {
 		valueSpec, ok := spec.(*ast.ValueSpec)
 		if !ok {
 			continue
 		}
+		if len(valueSpec.Values) > 0 {
+			lastValues = valueSpec.Values
+		}
 
 		for i, name := range valueSpec.Names {
 			goName := name.Name
 
 			// Extract value
 			var value interface{}
-			if i < len(valueSpec.Values) {
-				value = p.extractConstValue(valueSpec.Values[i], iotaValue, enumType)
+			if i < len(lastValues) {
+				value = p.extractConstValue(lastValues[i], iotaValue, enumType)
 			} else {
 				// No explicit value, use iota for int or empty for string
 				if enumType == "int" {
@@ -602,59 +1068,119 @@ func (p *Parser) parseConstBlock(constBlock *constBlockInfo, enumCandidates map[
 			}
 
 			// Extract GraphQL name and description from comment
-			graphQLName, description, deprecated := p.parseValueDirective(valueSpec.Comment, goName, enumTypeName)
+			graphQLName, goValue, description, deprecated := p.parseValueDirective(valueSpec.Doc, valueSpec.Comment, goName, enumTypeName)
 
 			values = append(values, EnumValue{
 				GoName:      goName,
 				GraphQLName: graphQLName,
+				GoValue:     goValue,
 				Value:       value,
 				Description: description,
 				Deprecated:  deprecated,
 				PackagePath: constBlock.FilePath,
 				PackageName: constBlock.PkgName,
 			})
+		}
+	}
 
-			iotaValue++
+	// Values split across blocks (or files) are merged into the enum of the first block
+	// A block matched again (e.g., after an on-demand package load) adds nothing
+	if existing, exists := p.EnumTypes[enumTypeName]; exists {
+		for _, value := range values {
+			if !slices.ContainsFunc(existing.Values, func(v EnumValue) bool { return v.GoName == value.GoName }) {
+				existing.Values = append(existing.Values, value)
+			}
 		}
+		return
 	}
 
 	// Create the EnumType and store it
 	if len(values) > 0 {
-		// Parse @gqlEnum directive for custom name, description, and namespace
-		enumName, enumDesc, enumNamespace := parseEnumDirective(candidate.GenDecl.Doc, enumTypeName)
+		p.registerEnum(enumTypeName, candidate, values)
+	}
+}
 
-		enumType := &EnumType{
-			Name:        enumName,
-			GoTypeName:  enumTypeName,
-			BaseType:    candidate.BaseType,
-			Description: enumDesc,
-			Values:      values,
-			TypeSpec:    candidate.TypeSpec,
-			GenDecl:     candidate.GenDecl,
-		}
+// registerInlineEnum creates the enum of a candidate declaring its values inline with
+// @gqlEnum(values:"A,B,C"), which needs no Go consts. The values are GraphQL names as written
+func (p *Parser) registerInlineEnum(enumTypeName string, candidate *enumCandidate) {
+	if _, exists := p.EnumTypes[enumTypeName]; exists {
+		return
+	}
+	_, _, _, inlineValues := parseEnumDirective(candidate.GenDecl.Doc, enumTypeName)
+	if len(inlineValues) == 0 {
+		return
+	}
 
-		p.EnumTypes[enumTypeName] = enumType
-		p.EnumNames = appendIfMissing(p.EnumNames, enumTypeName)
-		p.PackageNames[enumTypeName] = candidate.PkgName
-		p.PackagePaths[enumTypeName] = candidate.ImportPath  // Use import path instead of file path
-		p.EnumSourceFiles[enumTypeName] = candidate.FilePath // Store source file path
+	values := make([]EnumValue, 0, len(inlineValues))
+	for _, name := range inlineValues {
+		value := EnumValue{GraphQLName: name}
+		if candidate.BaseType == "string" {
+			value.Value = name
+		}
+		values = append(values, value)
+	}
+	p.registerEnum(enumTypeName, candidate, values)
+}
 
-		// Store namespace: enum-level override takes precedence over file-level
-		if enumNamespace != "" {
-			p.EnumNamespaces[enumTypeName] = enumNamespace
-		} else if candidate.Namespace != "" {
-			p.EnumNamespaces[enumTypeName] = candidate.Namespace
+// registerEnum stores the enum of a candidate with its values
+func (p *Parser) registerEnum(enumTypeName string, candidate *enumCandidate, values []EnumValue) {
+	// Parse @gqlEnum directive for custom name, description, and namespace
+	enumName, enumDesc, enumNamespace, _ := parseEnumDirective(candidate.GenDecl.Doc, enumTypeName)
+
+	enumType := &EnumType{
+		Name:        enumName,
+		GoTypeName:  enumTypeName,
+		BaseType:    candidate.BaseType,
+		Description: enumDesc,
+		Values:      values,
+		TypeSpec:    candidate.TypeSpec,
+		GenDecl:     candidate.GenDecl,
+	}
+	if candidate.GenDecl.Doc != nil {
+		for _, line := range strings.Split(candidate.GenDecl.Doc.Text(), "\n") {
+			line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*"))
+			if ok, reason := parseDeprecatedDirective(line); ok {
+				enumType.Deprecated, enumType.DeprecatedReason = true, reason
+			}
 		}
 	}
+
+	p.EnumTypes[enumTypeName] = enumType
+	p.EnumNames = appendIfMissing(p.EnumNames, enumTypeName)
+	p.PackageNames[enumTypeName] = candidate.PkgName
+	p.PackagePaths[enumTypeName] = candidate.ImportPath  // Use import path instead of file path
+	p.EnumSourceFiles[enumTypeName] = candidate.FilePath // Store source file path
+
+	// Store namespace: enum-level override takes precedence over file-level
+	if enumNamespace != "" {
+		p.EnumNamespaces[enumTypeName] = enumNamespace
+	} else if candidate.Namespace != "" {
+		p.EnumNamespaces[enumTypeName] = candidate.Namespace
+	}
 }
 
 // MatchEnumConstants matches all collected const blocks to enum candidates
 // This should be called after all packages have been parsed to support cross-file and cross-package enums
 func (p *Parser) MatchEnumConstants() {
+	// Match blocks in source order (by file, then position) so enum values split across files
+	// keep the same order whatever order the files were walked in
+	sort.SliceStable(p.constBlocks, func(i, j int) bool {
+		a, b := p.constBlocks[i], p.constBlocks[j]
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		return a.GenDecl.Pos() < b.GenDecl.Pos()
+	})
+
 	// Process all collected const blocks
 	for _, constBlock := range p.constBlocks {
 		p.parseConstBlock(constBlock, p.enumCandidates)
 	}
+
+	// Enums declaring their values inline need no const block
+	for _, enumTypeName := range sortedKeys(p.enumCandidates) {
+		p.registerInlineEnum(enumTypeName, p.enumCandidates[enumTypeName])
+	}
 }
 
 // extractConstValue extracts the value from a const value expression
@@ -675,13 +1201,11 @@ func (p *Parser) extractConstValue(expr ast.Expr, iotaValue int, baseType string
 		if v.Name == "iota" {
 			return iotaValue
 		}
-	case *ast.BinaryExpr:
-		// Handle expressions like: iota * 10, iota + 1, 1 << iota
-		// For simplicity, we'll just use the iota value
-		// More complex evaluation could be added if needed
-		return iotaValue
-	case *ast.UnaryExpr:
-		// Handle expressions like: -1
+	case *ast.BinaryExpr, *ast.UnaryExpr, *ast.ParenExpr:
+		// Handle expressions like: iota * 10, iota + 1, 1 << iota, -1
+		if intVal, ok := evalIntConstExpr(expr, iotaValue); ok {
+			return intVal
+		}
 		return iotaValue
 	}
 
@@ -692,44 +1216,121 @@ func (p *Parser) extractConstValue(expr ast.Expr, iotaValue int, baseType string
 	return ""
 }
 
-// parseValueDirective extracts @gqlEnumValue or @GqlEnumValue directive from comment
-func (p *Parser) parseValueDirective(commentGroup *ast.CommentGroup, goName string, enumTypeName string) (graphQLName, description, deprecated string) {
-	// Default: auto-generate GraphQL name by stripping enum type prefix
-	graphQLName = stripEnumPrefix(goName, enumTypeName)
-
-	if commentGroup == nil {
-		return
+// evalIntConstExpr evaluates an integer constant expression built from int literals and iota
+func evalIntConstExpr(expr ast.Expr, iotaValue int) (int, bool) {
+	switch v := expr.(type) {
+	case *ast.BasicLit:
+		if v.Kind != token.INT {
+			return 0, false
+		}
+		intVal, err := strconv.ParseInt(v.Value, 0, 64)
+		return int(intVal), err == nil
+	case *ast.Ident:
+		return iotaValue, v.Name == "iota"
+	case *ast.ParenExpr:
+		return evalIntConstExpr(v.X, iotaValue)
+	case *ast.UnaryExpr:
+		x, ok := evalIntConstExpr(v.X, iotaValue)
+		switch {
+		case !ok:
+			return 0, false
+		case v.Op == token.SUB:
+			return -x, true
+		case v.Op == token.ADD:
+			return x, true
+		}
+	case *ast.BinaryExpr:
+		x, okX := evalIntConstExpr(v.X, iotaValue)
+		y, okY := evalIntConstExpr(v.Y, iotaValue)
+		if !okX || !okY {
+			return 0, false
+		}
+		switch v.Op {
+		case token.ADD:
+			return x + y, true
+		case token.SUB:
+			return x - y, true
+		case token.MUL:
+			return x * y, true
+		case token.QUO, token.REM:
+			if y == 0 {
+				return 0, false
+			}
+			if v.Op == token.QUO {
+				return x / y, true
+			}
+			return x % y, true
+		case token.SHL, token.SHR:
+			if y < 0 {
+				return 0, false
+			}
+			if v.Op == token.SHL {
+				return x << y, true
+			}
+			return x >> y, true
+		case token.OR:
+			return x | y, true
+		case token.AND:
+			return x & y, true
+		}
 	}
+	return 0, false
+}
 
-	hasDirective := false
-
-	for _, comment := range commentGroup.List {
-		text := comment.Text
+// parseValueDirective extracts @gqlEnumValue or @GqlEnumValue directive from the const's comments
+// The doc comment above the const and its trailing comment are both read; their plain lines
+// become the description when the directive has no description parameter
+func (p *Parser) parseValueDirective(doc, comment *ast.CommentGroup, goName string, enumTypeName string) (graphQLName, goValue, description, deprecated string) {
+	// Default: auto-generate GraphQL name by stripping enum type prefix
+	graphQLName = stripEnumPrefix(goName, enumTypeName)
 
-		// Extract @gqlEnumValue or @GqlEnumValue directive
-		lowerText := strings.ToLower(text)
-		if strings.Contains(lowerText, "@gqlenumvalue") {
-			hasDirective = true
-			// Parse @gqlEnumValue(name:"CUSTOM_NAME", description:"...", deprecated:"...")
-			if name := extractDirectiveParam(text, "name"); name != "" {
-				graphQLName = name
-			}
-			if desc := extractDirectiveParam(text, "description"); desc != "" {
-				description = desc
-			}
-			if depr := extractDirectiveParam(text, "deprecated"); depr != "" {
-				deprecated = depr
+	var plainLines []string
+	for _, commentGroup := range []*ast.CommentGroup{doc, comment} {
+		if commentGroup == nil {
+			continue
+		}
+		for _, c := range commentGroup.List {
+			text := c.Text
+
+			// Extract @gqlEnumValue or @GqlEnumValue directive
+			lowerText := strings.ToLower(text)
+			if strings.Contains(lowerText, "@gqlenumvalue") {
+				// Parse @gqlEnumValue(name:"CUSTOM_NAME", value:"GoConst", description:"...", deprecated:"...")
+				if name := extractDirectiveParam(text, "name"); name != "" {
+					graphQLName = name
+				}
+				if value := extractDirectiveParam(text, "value"); value != "" {
+					goValue = value
+				}
+				if desc := extractDirectiveParam(text, "description"); desc != "" {
+					description = desc
+				}
+				if depr := extractDirectiveParam(text, "deprecated"); depr != "" {
+					deprecated = depr
+				}
+				continue
 			}
-		} else if !hasDirective {
-			// Only use regular comment as description if no @gqlEnumValue directive was found
-			desc := strings.TrimPrefix(text, "//")
-			desc = strings.TrimSpace(desc)
-			if desc != "" && description == "" {
-				description = desc
+
+			// Regular comment lines form the description
+			text = strings.TrimPrefix(text, "/*")
+			text = strings.TrimSuffix(text, "*/")
+			for _, line := range strings.Split(text, "\n") {
+				line = strings.TrimSpace(line)
+				line = strings.TrimPrefix(line, "//")
+				line = strings.TrimPrefix(line, "*")
+				line = strings.TrimSpace(line)
+				if line == "" || strings.HasPrefix(line, "@") || isPragmaComment(line) {
+					continue
+				}
+				plainLines = append(plainLines, line)
 			}
 		}
 	}
 
+	if description == "" {
+		description = strings.Join(plainLines, " ")
+	}
+
 	return
 }
 
@@ -758,9 +1359,38 @@ func toScreamingSnakeCase(s string) string {
 	return strings.ToUpper(string(result))
 }
 
-// parseEnumDirective extracts custom name, description, and namespace from @gqlEnum or @GqlEnum directive
+// parseEnumDirective extracts custom name, description, namespace and inline values from @gqlEnum or @GqlEnum directive
+// Returns (customName or defaultName, description, namespace, values), values is the values:"A,B,C" list
+func parseEnumDirective(commentGroup *ast.CommentGroup, defaultName string) (string, string, string, []string) {
+	name, description, namespace := parseNamedTypeDirective(commentGroup, defaultName, "@gqlenum")
+	if commentGroup == nil {
+		return name, description, namespace, nil
+	}
+
+	var values []string
+	for _, line := range strings.Split(commentGroup.Text(), "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*"))
+		if !strings.HasPrefix(strings.ToLower(line), "@gqlenum") {
+			continue
+		}
+		for _, value := range strings.Split(extractDirectiveParam(line, "values"), ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return name, description, namespace, values
+}
+
+// parseScalarDirective extracts custom name, description, and namespace from @gqlScalar or @GqlScalar directive
 // Returns (customName or defaultName, description, namespace)
-func parseEnumDirective(commentGroup *ast.CommentGroup, defaultName string) (string, string, string) {
+func parseScalarDirective(commentGroup *ast.CommentGroup, defaultName string) (string, string, string) {
+	return parseNamedTypeDirective(commentGroup, defaultName, "@gqlscalar")
+}
+
+// parseNamedTypeDirective extracts name, description, and namespace parameters from the given
+// (lower-cased) directive, using regular comment lines as the description fallback
+func parseNamedTypeDirective(commentGroup *ast.CommentGroup, defaultName string, directive string) (string, string, string) {
 	name := defaultName
 	var description string
 	var namespace string
@@ -785,9 +1415,9 @@ func parseEnumDirective(commentGroup *ast.CommentGroup, defaultName string) (str
 			line = strings.TrimPrefix(line, "*")
 			line = strings.TrimSpace(line)
 
-			// Check for @gqlEnum or @GqlEnum directive
+			// Check for the directive (@gqlEnum, @GqlEnum, ...)
 			lowerLine := strings.ToLower(line)
-			if strings.HasPrefix(lowerLine, "@gqlenum") {
+			if strings.HasPrefix(lowerLine, directive) {
 				// Extract name parameter if present
 				if customName := extractDirectiveParam(line, "name"); customName != "" {
 					name = customName
@@ -800,13 +1430,15 @@ func parseEnumDirective(commentGroup *ast.CommentGroup, defaultName string) (str
 				if ns := extractDirectiveParam(line, "namespace"); ns != "" {
 					namespace = ns
 				}
-			} else if !strings.HasPrefix(line, "@") && line != "" && description == "" {
-				// Use regular comment as description if no @gqlEnum description
-				description = line
 			}
 		}
 	}
 
+	// Use the plain doc comment lines as description if the directive has no description
+	if description == "" {
+		description = extractDescription(commentGroup)
+	}
+
 	return name, description, namespace
 }
 
@@ -814,10 +1446,65 @@ func parseEnumDirective(commentGroup *ast.CommentGroup, defaultName string) (str
 // This loads and parses the type on-demand if it's not already scanned
 // parentTypeName is the name of the type that contains the field (used to find the source file for import resolution)
 func (p *Parser) HasGQLAnnotations(fieldExpr ast.Expr, parentTypeName string) bool {
-	// Extract package selector and type name from the field expression
-	var pkgPath string
-	var typeIdent *ast.Ident
+	pkgPath, typeName := p.resolveTypeRef(fieldExpr, parentTypeName)
+	if typeName == "" {
+		return false
+	}
+
+	// If no package path (same package or already scanned), check ScannedTypes
+	if pkgPath == "" {
+		if info, exists := p.ScannedTypes[typeName]; exists {
+			return info.HasTypeDirective || info.HasInputDirective || info.HasInterfaceDirective || info.HasUnionDirective
+		}
+		return false
+	}
+
+	// Check if we already know about this type
+	qualifiedName := pkgPath + "." + typeName
+	if info, exists := p.ScannedTypes[qualifiedName]; exists {
+		return info.HasTypeDirective || info.HasInputDirective || info.HasInterfaceDirective || info.HasUnionDirective
+	}
+	if info, exists := p.ScannedTypes[typeName]; exists {
+		return info.HasTypeDirective || info.HasInputDirective || info.HasInterfaceDirective || info.HasUnionDirective
+	}
+
+	// Load the package on-demand to check for annotations
+	pkg := p.loadExternalPackage(pkgPath, parentTypeName)
+	if pkg == nil {
+		return false
+	}
+
+	return p.registerExternalPackage(pkg, typeName)
+}
+
+// LoadExternalStruct returns the struct a field expression (e.g., an embedded base.Model) refers to,
+// loading its package on-demand when the struct was not scanned
+// parentTypeName is the name of the type that contains the field (used to find the source file for import resolution)
+func (p *Parser) LoadExternalStruct(fieldExpr ast.Expr, parentTypeName string) (*ast.TypeSpec, bool) {
+	pkgPath, typeName := p.resolveTypeRef(fieldExpr, parentTypeName)
+	if typeName == "" {
+		return nil, false
+	}
+	if typeSpec, exists := p.StructTypes[typeName]; exists {
+		return typeSpec, true
+	}
+	if pkgPath == "" {
+		return nil, false
+	}
+
+	pkg := p.loadExternalPackage(pkgPath, parentTypeName)
+	if pkg == nil {
+		return nil, false
+	}
+	p.registerExternalPackage(pkg, typeName)
 
+	typeSpec, exists := p.StructTypes[typeName]
+	return typeSpec, exists
+}
+
+// resolveTypeRef returns the import path and name of the type a field expression refers to
+// The import path is empty for types of the parent's own package
+func (p *Parser) resolveTypeRef(fieldExpr ast.Expr, parentTypeName string) (string, string) {
 	// Unwrap pointers, arrays, slices
 	expr := fieldExpr
 	for {
@@ -828,89 +1515,116 @@ func (p *Parser) HasGQLAnnotations(fieldExpr ast.Expr, parentTypeName string) bo
 			expr = t.Elt
 		case *ast.SelectorExpr:
 			// pkg.TypeName
-			if ident, ok := t.X.(*ast.Ident); ok {
-				// Get the package path from the source file of the parent type
-				if parentFilePath, exists := p.SourceFiles[parentTypeName]; exists {
-					// Parse the file to get imports
-					fset := token.NewFileSet()
-					f, err := parser.ParseFile(fset, parentFilePath, nil, parser.ParseComments)
-					if err == nil {
-						pkgAlias := ident.Name
-						// Find the import path for this package alias
-						for _, imp := range f.Imports {
-							impPath := strings.Trim(imp.Path.Value, `"`)
-							impName := filepath.Base(impPath)
-							if imp.Name != nil {
-								impName = imp.Name.Name
-							}
-							if impName == pkgAlias {
-								pkgPath = impPath
-								break
-							}
-						}
-					}
-				}
-				typeIdent = t.Sel
+			ident, ok := t.X.(*ast.Ident)
+			if !ok {
+				return "", ""
 			}
-			goto done
+			return p.importPathForAlias(ident.Name, parentTypeName), t.Sel.Name
 		case *ast.Ident:
 			// Just TypeName (same package)
-			typeIdent = t
-			goto done
-		case *ast.IndexExpr, *ast.IndexListExpr:
+			return "", t.Name
+		case *ast.IndexExpr:
 			// Generic instantiation - extract base type
-			if idx, ok := t.(*ast.IndexExpr); ok {
-				expr = idx.X
-			} else if idxList, ok := t.(*ast.IndexListExpr); ok {
-				expr = idxList.X
-			}
+			expr = t.X
+		case *ast.IndexListExpr:
+			expr = t.X
 		default:
-			return false
+			return "", ""
 		}
 	}
-done:
+}
 
-	if typeIdent == nil {
-		return false
+// importPathForAlias finds the import path of a package alias in the source file of the parent type
+func (p *Parser) importPathForAlias(pkgAlias string, parentTypeName string) string {
+	parentFilePath, exists := p.SourceFiles[parentTypeName]
+	if !exists {
+		return ""
 	}
+	return importPathInFile(pkgAlias, parentFilePath)
+}
 
-	typeName := typeIdent.Name
-
-	// If no package path (same package or already scanned), check ScannedTypes
-	if pkgPath == "" {
-		if info, exists := p.ScannedTypes[typeName]; exists {
-			return info.HasTypeDirective || info.HasInputDirective
+// importPathInFile finds the import path of a package alias among the imports of a Go file
+func importPathInFile(pkgAlias string, filePath string) string {
+	// Parse the file to get imports
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filePath, nil, parser.ImportsOnly)
+	if err != nil {
+		return ""
+	}
+	for _, imp := range f.Imports {
+		impPath := strings.Trim(imp.Path.Value, `"`)
+		impName := filepath.Base(impPath)
+		if imp.Name != nil {
+			impName = imp.Name.Name
+		}
+		if impName == pkgAlias {
+			return impPath
 		}
-		return false
 	}
+	return ""
+}
 
-	// Check if we already know about this type
-	qualifiedName := pkgPath + "." + typeName
-	if info, exists := p.ScannedTypes[qualifiedName]; exists {
-		return info.HasTypeDirective || info.HasInputDirective
+// loadExternalPackage loads a package that is not in the scan list, resolving its import path
+// from the directory of the parent type so packages of the parent's module are found
+// Results (including failures) are cached by import path
+func (p *Parser) loadExternalPackage(pkgPath string, parentTypeName string) *packages.Package {
+	if p.externalPkgs == nil {
+		p.externalPkgs = make(map[string]*packages.Package)
 	}
-	if info, exists := p.ScannedTypes[typeName]; exists {
-		return info.HasTypeDirective || info.HasInputDirective
+	if pkg, cached := p.externalPkgs[pkgPath]; cached {
+		return pkg
 	}
 
-	// Load the package on-demand to check for annotations
 	cfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes,
+		Mode:       packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes,
+		BuildFlags: p.buildFlags(),
+	}
+	if parentFilePath, exists := p.SourceFiles[parentTypeName]; exists {
+		cfg.Dir = filepath.Dir(parentFilePath)
 	}
 
-	pkgs, err := packages.Load(cfg, pkgPath)
-	if err != nil || len(pkgs) == 0 {
-		return false
+	pkgs, err := loadPackages(cfg, pkgPath)
+	if err != nil || len(pkgs) == 0 || (len(pkgs[0].Errors) > 0 && len(pkgs[0].Syntax) == 0) {
+		p.externalPkgs[pkgPath] = nil
+		return nil
 	}
+	p.externalPkgs[pkgPath] = pkgs[0]
+	return pkgs[0]
+}
 
-	pkg := pkgs[0]
+// registerExternalPackage adds the struct and enum types of an on-demand loaded package to the parser
+// and reports whether typeName was found with GQL annotations
+// The package is registered on the first call, later calls for other types of the same package
+// only look the type up
+func (p *Parser) registerExternalPackage(pkg *packages.Package, typeName string) bool {
+	if p.registeredPkgs == nil {
+		p.registeredPkgs = make(map[string]bool)
+	}
+	if !p.registeredPkgs[pkg.PkgPath] {
+		p.registeredPkgs[pkg.PkgPath] = true
+		p.registerPackageTypes(pkg)
+	}
 
-	// When loading a package on-demand, add ALL struct types from it to the parser
-	// This ensures that non-annotated types (like Connection[T]) are available for embedded field expansion
-	var foundRequestedType bool
-	var requestedTypeHasAnnotations bool
+	if candidate, ok := p.enumCandidates[typeName]; ok && candidate.ImportPath == pkg.PkgPath {
+		return true
+	}
+	if p.PackagePaths[typeName] != pkg.PkgPath {
+		// Not part of the package, or shadowed by a scanned type
+		return false
+	}
+	info, ok := p.ScannedTypes[typeName]
+	return ok && info.IsExternal
+}
 
+// registerPackageTypes adds ALL struct and enum types of an on-demand loaded package to the parser
+// This ensures that non-annotated types (like Connection[T]) are available for embedded field expansion
+func (p *Parser) registerPackageTypes(pkg *packages.Package) {
 	for _, file := range pkg.Syntax {
+		filePath := pkg.Fset.File(file.Pos()).Name()
+		if p.isExcludedExternalFile(filePath) {
+			continue
+		}
+
 		// First pass: process type declarations
 		for _, decl := range file.Decls {
 			genDecl, ok := decl.(*ast.GenDecl)
@@ -944,20 +1658,20 @@ done:
 							GenDecl:    genDecl,
 							BaseType:   baseType,
 							PkgName:    pkg.Name,
-							FilePath:   pkg.Fset.File(file.Pos()).Name(),
+							FilePath:   filePath,
 							ImportPath: pkg.PkgPath,
 							Namespace:  "", // Could extract from file-level directives if needed
 						}
-
-						// Track if this is the requested type
-						if currentTypeName == typeName {
-							foundRequestedType = true
-							requestedTypeHasAnnotations = true
-						}
+						p.registerInlineEnum(currentTypeName, p.enumCandidates[currentTypeName])
 					}
 					continue
 				}
 
+				// Scanned types take precedence over same-named types of the loaded package
+				if existingPath, exists := p.PackagePaths[currentTypeName]; exists && existingPath != pkg.PkgPath {
+					continue
+				}
+
 				// Parse directives for struct types
 				directives := ParseDirectives(typeSpec, genDecl)
 
@@ -966,16 +1680,7 @@ done:
 				p.TypeToDecl[currentTypeName] = genDecl
 				p.PackageNames[currentTypeName] = pkg.Name
 				p.PackagePaths[currentTypeName] = pkg.PkgPath
-
-				// Find source file
-				for _, f := range pkg.Syntax {
-					for _, d := range f.Decls {
-						if gd, ok := d.(*ast.GenDecl); ok && gd == genDecl {
-							p.SourceFiles[currentTypeName] = pkg.Fset.File(f.Pos()).Name()
-							break
-						}
-					}
-				}
+				p.SourceFiles[currentTypeName] = filePath
 
 				// Store type parameters if it's a generic type
 				if typeSpec.TypeParams != nil && typeSpec.TypeParams.NumFields() > 0 {
@@ -989,7 +1694,7 @@ done:
 				}
 
 				// If this type has annotations, add to TypeNames (for generation) and ScannedTypes
-				if directives.HasTypeDirective || directives.HasInputDirective || directives.HasIncludeDirective {
+				if directives.HasTypeDirective || directives.HasInputDirective || directives.HasInterfaceDirective || directives.HasUnionDirective || directives.HasIncludeDirective {
 					// Add to TypeNames for generation (if not already there)
 					found := false
 					for _, name := range p.TypeNames {
@@ -1007,13 +1712,15 @@ done:
 
 					// Cache in ScannedTypes
 					info := &ScannedTypeInfo{
-						TypeName:            currentTypeName,
-						HasTypeDirective:    directives.HasTypeDirective,
-						HasInputDirective:   directives.HasInputDirective,
-						HasIncludeDirective: directives.HasIncludeDirective,
-						IsExternal:          true, // This type was loaded on-demand
-						GeneratedTypes:      make([]string, 0),
-						GeneratedInputs:     make([]string, 0),
+						TypeName:              currentTypeName,
+						HasTypeDirective:      directives.HasTypeDirective,
+						HasInputDirective:     directives.HasInputDirective,
+						HasInterfaceDirective: directives.HasInterfaceDirective,
+						HasUnionDirective:     directives.HasUnionDirective,
+						HasIncludeDirective:   directives.HasIncludeDirective,
+						IsExternal:            true, // This type was loaded on-demand
+						GeneratedTypes:        make([]string, 0),
+						GeneratedInputs:       make([]string, 0),
 					}
 
 					for _, typeDef := range directives.Types {
@@ -1034,12 +1741,6 @@ done:
 
 					p.ScannedTypes[currentTypeName] = info
 				}
-
-				// Track if this is the requested type and if it has annotations
-				if currentTypeName == typeName {
-					foundRequestedType = true
-					requestedTypeHasAnnotations = directives.HasTypeDirective || directives.HasInputDirective || directives.HasIncludeDirective
-				}
 			}
 		}
 
@@ -1053,7 +1754,7 @@ done:
 			// Store const block info for later matching
 			constBlock := &constBlockInfo{
 				GenDecl:  genDecl,
-				FilePath: pkg.Fset.File(file.Pos()).Name(),
+				FilePath: filePath,
 				PkgName:  pkg.Name,
 			}
 			p.constBlocks = append(p.constBlocks, constBlock)
@@ -1062,6 +1763,4 @@ done:
 			p.parseConstBlock(constBlock, p.enumCandidates)
 		}
 	}
-
-	return foundRequestedType && requestedTypeHasAnnotations
 }
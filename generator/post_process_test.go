@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPostProcessHook(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := "package models\n\n// @gqlType\ntype User struct {\n\tName string\n}\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "user.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	var processed []string
+	cfg := NewConfig()
+	cfg.Packages = []string{tmpDir}
+	cfg.GenStrategy = GenStrategySingle
+	cfg.Output = filepath.Join(tmpDir, "schema")
+	cfg.PostProcess = func(filePath string, content string) (string, error) {
+		processed = append(processed, filePath)
+		return strings.ToUpper(content), nil
+	}
+
+	if err := Generate(cfg); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	outFile := filepath.Join(cfg.Output, cfg.OutputFileName)
+	if len(processed) != 1 || processed[0] != outFile {
+		t.Errorf("Expected the hook to run once for %s, got %v", outFile, processed)
+	}
+	content, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	// The hook sees the final file, including the header and keep sections
+	for _, want := range []string{"# CODE GENERATED BY", "TYPE USER {", "NAME: STRING!", "@GQLKEEPBEGIN"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Expected %q in the post-processed file, got:\n%s", want, content)
+		}
+	}
+
+	// Check runs compare the post-processed content
+	cfg.CheckOnly = true
+	if err := Generate(cfg); err != nil {
+		t.Errorf("Expected the post-processed file to be current, got %v", err)
+	}
+
+	// Hook errors stop generation
+	cfg.CheckOnly = false
+	cfg.PostProcess = func(string, string) (string, error) {
+		return "", errors.New("rejected")
+	}
+	if err := Generate(cfg); err == nil || !strings.Contains(err.Error(), "rejected") {
+		t.Errorf("Expected the hook error, got %v", err)
+	}
+}
+
+func TestPostProcessHookOnStdout(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := "package models\n\n// @gqlType\ntype User struct {\n\tName string\n}\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "user.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	var processed []string
+	cfg := NewConfig()
+	cfg.Packages = []string{tmpDir}
+	cfg.GenStrategy = GenStrategySingle
+	cfg.Output = OutputStdout
+	cfg.PostProcess = func(filePath string, content string) (string, error) {
+		processed = append(processed, filePath)
+		return "# License: MIT\n" + content, nil
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	genErr := Generate(cfg)
+	os.Stdout = stdout
+	_ = w.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read stdout: %v", err)
+	}
+	if genErr != nil {
+		t.Fatalf("Generate failed: %v", genErr)
+	}
+
+	if len(processed) != 1 || processed[0] != OutputStdout {
+		t.Errorf("Expected the hook to run once for %q, got %v", OutputStdout, processed)
+	}
+	// Stdout gets no header or keep sections, only the hook's changes
+	if want := "# License: MIT\ntype User {\n  name: String!\n}\n"; string(out) != want {
+		t.Errorf("Expected post-processed stdout:\n%s\ngot:\n%s", want, out)
+	}
+}
@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTypeRawName(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlType(rawName:true)
+type UserDTO struct {
+	ID string
+}
+
+// @gqlType
+type AccountDTO struct {
+	Owner UserDTO
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.StripSuffix = "DTO"
+	cfg.AddTypePrefix = "Api"
+
+	files, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[cfg.Output]
+
+	// rawName keeps the Go name, other types still get the configured transforms
+	if !strings.Contains(schema, "type UserDTO {") {
+		t.Errorf("Expected type UserDTO to keep its Go name, got:\n%s", schema)
+	}
+	if !strings.Contains(schema, "type ApiAccount {") {
+		t.Errorf("Expected type ApiAccount from the global rules, got:\n%s", schema)
+	}
+	if !strings.Contains(schema, "owner: UserDTO!") {
+		t.Errorf("Expected the reference to UserDTO, got:\n%s", schema)
+	}
+}
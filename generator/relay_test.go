@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func generateRelaySchema(t *testing.T, src string, configure func(cfg *Config)) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.UseGqlGenDirectives = true
+	cfg.ModelPath = "example.com/models"
+	configure(cfg)
+
+	gen := NewGenerator(p, cfg)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	return string(content)
+}
+
+const relaySource = `package models
+
+// @gqlType(relay:true)
+type Comment struct {
+	ID   string ` + "`gql:\"id,type:ID!\"`" + `
+	Body string
+}
+
+// @gqlType(name:"Article", relay:true)
+type Post struct {
+	ID    string ` + "`gql:\"id,type:ID!\"`" + `
+	Title string
+}
+
+type CommentEdge struct {
+	Cursor string
+	Node   *Comment
+}
+`
+
+func TestRelayConnections(t *testing.T) {
+	schema := generateRelaySchema(t, relaySource, func(cfg *Config) {
+		cfg.Relay.Enabled = true
+	})
+
+	for _, want := range []string{
+		"interface Node {\n  id: ID!\n}",
+		"type PageInfo {\n  hasNextPage: Boolean!\n  hasPreviousPage: Boolean!\n  startCursor: String\n  endCursor: String\n}",
+		"type Comment implements Node @goModel(model: \"example.com/models.Comment\") {",
+		"type Article implements Node @goModel(model: \"example.com/models.Post\") {",
+		// Existing Go model: generated from the struct, with @goModel
+		"type CommentEdge @goModel(model: \"example.com/models.CommentEdge\") {\n  cursor: String!\n  node: Comment!\n}",
+		// No Go model: synthesized without @goModel
+		"type CommentConnection {\n  edges: [CommentEdge!]!\n  pageInfo: PageInfo!\n}",
+		"type ArticleEdge {\n  cursor: String!\n  node: Article!\n}",
+		"type ArticleConnection {\n  edges: [ArticleEdge!]!\n  pageInfo: PageInfo!\n}",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain:\n%s\ngot:\n%s", want, schema)
+		}
+	}
+
+	if n := strings.Count(schema, "interface Node"); n != 1 {
+		t.Errorf("Expected the Node interface once, got %d", n)
+	}
+	if n := strings.Count(schema, "type PageInfo"); n != 1 {
+		t.Errorf("Expected PageInfo once, got %d", n)
+	}
+	if n := strings.Count(schema, "type CommentEdge"); n != 1 {
+		t.Errorf("Expected CommentEdge once, got %d", n)
+	}
+}
+
+func TestRelayConnectionSuffixes(t *testing.T) {
+	schema := generateRelaySchema(t, relaySource, func(cfg *Config) {
+		cfg.Relay.Enabled = true
+		cfg.Relay.ConnectionSuffix = "Page"
+		cfg.Relay.EdgeSuffix = "Link"
+	})
+
+	for _, want := range []string{
+		"type ArticleLink {",
+		"type ArticlePage {\n  edges: [ArticleLink!]!\n  pageInfo: PageInfo!\n}",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain:\n%s\ngot:\n%s", want, schema)
+		}
+	}
+}
+
+func TestRelayDisabled(t *testing.T) {
+	schema := generateRelaySchema(t, relaySource, func(cfg *Config) {})
+
+	for _, unwanted := range []string{"interface Node", "type PageInfo", "implements Node", "ArticleConnection"} {
+		if strings.Contains(schema, unwanted) {
+			t.Errorf("Did not expect %q without relay enabled, got:\n%s", unwanted, schema)
+		}
+	}
+}
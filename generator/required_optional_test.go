@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRequiredAndOptionalOverrideNullability(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlType
+// @gqlInput
+type Article struct {
+	Title    *string   ` + "`gql:\"title,required\"`" + `
+	Summary  string    ` + "`gql:\"summary,optional\"`" + `
+	Tags     *[]string ` + "`gql:\"tags,required\"`" + `
+	Labels   []string  ` + "`gql:\"labels,optional\"`" + `
+	Subtitle *string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+
+	files, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[cfg.Output]
+
+	for _, header := range []string{"type Article", "input ArticleInput"} {
+		block := schemaBlock(schema, header)
+		for _, want := range []string{
+			// required wins over pointer-derived nullability
+			"title: String!\n",
+			"tags: [String!]!\n",
+			// optional strips the non-null marker of a value field
+			"summary: String\n",
+			"labels: [String!]\n",
+			"subtitle: String!\n",
+		} {
+			if !strings.Contains(block, want) {
+				t.Errorf("Expected %q in %s, got:\n%s", want, header, block)
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const rootTypesSource = `package models
+
+// @gqlType
+type User struct {
+	ID string
+}
+
+// @gqlType(root:"query")
+// @gqlTypeExtraField(name:"user",type:"User",args:"id: ID!")
+type UserQueries struct {
+	Users []User
+}
+
+// @gqlType(root:"Query")
+// @gqlTypeExtraField(name:"version",type:"String!",description:"API version")
+// @gqlTypeExtraField(name:"users",type:"[User!]!")
+type SystemQueries struct{}
+
+// @gqlType(root:"mutation")
+// @gqlTypeExtraField(name:"deleteUser",type:"Boolean!",args:"id: ID!")
+type UserMutations struct{}
+`
+
+func buildRootTypesSchema(t *testing.T, strategy GenStrategy) (map[string]string, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(rootTypesSource), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.GenStrategy = strategy
+	cfg.Output = filepath.Join(tmpDir, "schema")
+	if strategy == GenStrategySingle {
+		cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	}
+
+	files, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return files, cfg.Output
+}
+
+func TestRootTypesMergeFields(t *testing.T) {
+	files, output := buildRootTypesSchema(t, GenStrategySingle)
+	schema := files[output]
+
+	// Both query structs contribute to a single Query type
+	query := schemaBlock(schema, "type Query")
+	for _, want := range []string{
+		"users: [User!]!",
+		"user(id: ID!): User",
+		"\"API version\"\n  version: String!",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("Expected %q in Query, got:\n%s", want, query)
+		}
+	}
+	if strings.Count(schema, "type Query {") != 1 {
+		t.Errorf("Expected a single Query type, got:\n%s", schema)
+	}
+
+	// The field defined by both structs is kept once
+	if strings.Count(query, "users:") != 1 {
+		t.Errorf("Expected the users field once, got:\n%s", query)
+	}
+
+	if mutation := schemaBlock(schema, "type Mutation"); !strings.Contains(mutation, "deleteUser(id: ID!): Boolean!") {
+		t.Errorf("Expected deleteUser in Mutation, got:\n%s", mutation)
+	}
+
+	// The contributing structs are not types of their own
+	for _, name := range []string{"UserQueries", "SystemQueries", "UserMutations"} {
+		if strings.Contains(schema, "type "+name) {
+			t.Errorf("Expected no %s type, got:\n%s", name, schema)
+		}
+	}
+	if !strings.Contains(schema, "type User {") {
+		t.Errorf("Expected the User type, got:\n%s", schema)
+	}
+}
+
+func TestRootTypesMultipleStrategy(t *testing.T) {
+	files, output := buildRootTypesSchema(t, GenStrategyMultiple)
+
+	// The root type goes to the file of the first contributing struct
+	var queryFiles []string
+	for file, content := range files {
+		if strings.Contains(content, "type Query {") {
+			queryFiles = append(queryFiles, file)
+		}
+	}
+	if len(queryFiles) != 1 || filepath.Dir(queryFiles[0]) != output {
+		t.Fatalf("Expected Query in one file of %s, got %v", output, queryFiles)
+	}
+	if !strings.Contains(files[queryFiles[0]], "version: String!") {
+		t.Errorf("Expected the SystemQueries fields in Query, got:\n%s", files[queryFiles[0]])
+	}
+}
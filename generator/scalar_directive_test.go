@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGqlScalarDirective(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+import "time"
+
+// @gqlScalar(name:"DateTime", description:"RFC3339 timestamp")
+type Timestamp time.Time
+
+// @gqlScalar
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// @gqlType
+type Order struct {
+	ID        string
+	PlacedAt  Timestamp
+	Total     *Money
+	Refunds   []Money
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "order.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+
+	if _, ok := p.ScalarTypes["Timestamp"]; !ok {
+		t.Fatalf("Expected Timestamp to be collected as a scalar, got %v", p.ScalarTypes)
+	}
+	if _, ok := p.StructTypes["Money"]; ok {
+		t.Error("Did not expect @gqlScalar struct to be collected as a struct type")
+	}
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.UseGqlGenDirectives = true
+	cfg.ModelPath = "example.com/models"
+	cfg.AutoGenerate.OutOfScopeTypes = OutOfScopeFail
+
+	gen := NewGenerator(p, cfg)
+	if err := gen.Run(); err != nil {
+		t.Fatalf("Generation failed (scalars must be in scope): %v", err)
+	}
+
+	content, err := os.ReadFile(cfg.Output)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	schema := string(content)
+
+	for _, want := range []string{
+		"\"RFC3339 timestamp\"\nscalar DateTime @goModel(model: \"example.com/models.Timestamp\")",
+		"scalar Money @goModel(model: \"example.com/models.Money\")",
+		"placedAt: DateTime!",
+		"total: Money",
+		"refunds: [Money!]!",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain %q, got:\n%s", want, schema)
+		}
+	}
+
+	// Scalars are written before types
+	if strings.Index(schema, "scalar DateTime") > strings.Index(schema, "type Order") {
+		t.Errorf("Expected scalars before types, got:\n%s", schema)
+	}
+}
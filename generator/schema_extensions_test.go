@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSchemaExtensionsSingleFileOutput(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlType
+type User struct {
+	ID string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		output     string
+		extensions []string
+		wantFile   string
+	}{
+		{name: "graphqls", output: "schema.graphqls", wantFile: "schema.graphqls"},
+		{name: "graphql", output: "schema.graphql", wantFile: "schema.graphql"},
+		{name: "gql", output: "schema.gql", wantFile: "schema.gql"},
+		{name: "upper case", output: "schema.GQL", wantFile: "schema.GQL"},
+		{name: "custom extension", output: "api.sdl", extensions: []string{"sdl"}, wantFile: "api.sdl"},
+		{name: "not configured", output: "schema.gql", extensions: []string{".graphqls"}, wantFile: filepath.Join("schema.gql", "gqlschemagen.graphqls")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser()
+			if err := p.Walk(tmpDir); err != nil {
+				t.Fatalf("Walk() error = %v", err)
+			}
+
+			cfg := NewConfig()
+			cfg.Output = filepath.Join(tmpDir, tt.output)
+			cfg.GenStrategy = GenStrategySingle
+			if tt.extensions != nil {
+				cfg.SchemaExtensions = tt.extensions
+			}
+			cfg.Normalize()
+
+			files, err := NewGenerator(p, cfg).Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			want := filepath.Join(tmpDir, tt.wantFile)
+			if !strings.Contains(files[want], "type User {") {
+				t.Errorf("Expected the schema in %s, got files %v", want, sortedKeys(files))
+			}
+		})
+	}
+}
+
+func TestConfigValidateCustomSchemaExtension(t *testing.T) {
+	cfg := NewConfig()
+	cfg.SchemaExtensions = []string{".sdl"}
+	cfg.Normalize()
+	cfg.Packages = []string{"."}
+	cfg.Output = "graph/schema.sdl"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected an error for a .sdl file output with the multiple strategy")
+	}
+}
+
+func TestNormalizeDoesNotMutateSchemaExtensions(t *testing.T) {
+	extensions := []string{"sdl", ".graphqls"}
+	cfg := NewConfig()
+	cfg.SchemaExtensions = extensions
+	cfg.Normalize()
+
+	if got := cfg.SchemaExtensions; len(got) != 2 || got[0] != ".sdl" || got[1] != ".graphqls" {
+		t.Errorf("Expected the extensions normalized to [.sdl .graphqls], got %v", got)
+	}
+	if extensions[0] != "sdl" {
+		t.Errorf("Expected the caller's slice to be left as is, got %v", extensions)
+	}
+}
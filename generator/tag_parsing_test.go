@@ -58,6 +58,20 @@ func TestComplexTagParsing(t *testing.T) {
 			wantInclude: nil,
 			wantIgnore:  nil,
 		},
+		{
+			name:        "gqlName overrides the positional name",
+			tag:         `gql:"legacy,gqlName:displayName,ro"`,
+			wantName:    "displayName",
+			wantInclude: nil,
+			wantIgnore:  nil,
+		},
+		{
+			name:        "gqlName without a positional name",
+			tag:         `gql:"gqlName:displayName,include:User" json:"display_name"`,
+			wantName:    "displayName",
+			wantInclude: []string{"User"},
+			wantIgnore:  nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -129,6 +143,36 @@ type Test struct {
 	}
 }
 
+// TestResolveFieldNamePrecedence tests gqlName: > positional name > json tag > field name
+func TestResolveFieldNamePrecedence(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		want string
+	}{
+		{name: "gqlName", tag: "`gql:\"legacy,gqlName:displayName\" json:\"display_name\"`", want: "displayName"},
+		{name: "positional name", tag: "`gql:\"legacy,ro\" json:\"display_name\"`", want: "legacy"},
+		{name: "json tag", tag: "`gql:\",ro\" json:\"display_name\"`", want: "display_name"},
+		{name: "field name", tag: "`gql:\"ro\"`", want: "displayName"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package test\ntype Test struct {\n\tDisplayName string " + tt.tag + "\n}"
+			f, err := parser.ParseFile(token.NewFileSet(), "test.go", src, 0)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			field := f.Decls[0].(*ast.GenDecl).Specs[0].(*ast.TypeSpec).Type.(*ast.StructType).Fields.List[0]
+
+			cfg := &Config{UseJsonTag: true, FieldCase: FieldCaseCamel}
+			if got := ResolveFieldName(field, cfg); got != tt.want {
+				t.Errorf("ResolveFieldName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestSplitParamsWithLists tests the low-level splitting function
 func TestSplitParamsWithLists(t *testing.T) {
 	tests := []struct {
@@ -227,3 +271,66 @@ func TestSplitParamsWithLists(t *testing.T) {
 		})
 	}
 }
+
+// TestTagPriority tests field names read from a custom tag precedence list
+func TestTagPriority(t *testing.T) {
+	tests := []struct {
+		name       string
+		tag        string
+		want       string
+		wantIgnore bool
+		wantRO     bool
+	}{
+		{name: "graphql tag", tag: "`graphql:\"displayName\" gql:\"legacy\" json:\"display_name\"`", want: "displayName"},
+		{name: "gql options kept", tag: "`graphql:\"displayName\" gql:\",ro\"`", want: "displayName", wantRO: true},
+		{name: "graphql ignore", tag: "`graphql:\"-\" gql:\"legacy\"`", wantIgnore: true},
+		{name: "gql fallback", tag: "`gql:\"legacy\" json:\"display_name\"`", want: "legacy"},
+		{name: "db last resort", tag: "`db:\"display_name\"`", want: "display_name"},
+		{name: "field name", tag: "`yaml:\"display\"`", want: "displayName"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package test\ntype Test struct {\n\tDisplayName string " + tt.tag + "\n}"
+			f, err := parser.ParseFile(token.NewFileSet(), "test.go", src, 0)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			field := f.Decls[0].(*ast.GenDecl).Specs[0].(*ast.TypeSpec).Type.(*ast.StructType).Fields.List[0]
+
+			cfg := &Config{UseJsonTag: true, FieldCase: FieldCaseCamel, TagPriority: []string{"graphql", "gql", "json", "db"}}
+			opts := ParseFieldOptions(field, cfg)
+			if opts.Ignore != tt.wantIgnore {
+				t.Fatalf("ParseFieldOptions().Ignore = %v, want %v", opts.Ignore, tt.wantIgnore)
+			}
+			if tt.wantIgnore {
+				return
+			}
+			if gotRO := len(opts.ReadOnly) > 0; gotRO != tt.wantRO {
+				t.Errorf("ParseFieldOptions().ReadOnly = %v, want ro %v", opts.ReadOnly, tt.wantRO)
+			}
+			if got := ResolveFieldName(field, cfg); got != tt.want {
+				t.Errorf("ResolveFieldName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTagPriorityDefault tests that the default list reads gql then json, and that json is skipped when disabled
+func TestTagPriorityDefault(t *testing.T) {
+	src := "package test\ntype Test struct {\n\tDisplayName string `graphql:\"name\" json:\"display_name\"`\n}"
+	f, err := parser.ParseFile(token.NewFileSet(), "test.go", src, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	field := f.Decls[0].(*ast.GenDecl).Specs[0].(*ast.TypeSpec).Type.(*ast.StructType).Fields.List[0]
+
+	cfg := NewConfig()
+	if got := ResolveFieldName(field, cfg); got != "display_name" {
+		t.Errorf("ResolveFieldName() = %q, want %q", got, "display_name")
+	}
+	cfg.UseJsonTag = false
+	if got := ResolveFieldName(field, cfg); got != "displayName" {
+		t.Errorf("ResolveFieldName() without json = %q, want %q", got, "displayName")
+	}
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTypeAliases(t *testing.T) {
+	tmpDir := t.TempDir()
+	// The aliases are declared before their target, in another file
+	aliases := `package models
+
+// @gqlType
+type PublicUser = User
+
+// @gqlType(name:"Viewer")
+type CurrentUser = PublicUser
+
+// @gqlScalar
+type Email = string
+
+// @gqlType
+type Account struct {
+	Owner   PublicUser ` + "`gql:\"owner\"`" + `
+	Contact Email      ` + "`gql:\"contact\"`" + `
+}
+`
+	models := `package models
+
+// @gqlType
+type User struct {
+	ID   string ` + "`gql:\"id,type:ID!\"`" + `
+	Name string ` + "`gql:\"name\"`" + `
+}
+`
+	for name, src := range map[string]string{"aliases.go": aliases, "user.go": models} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(src), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+	config.UseGqlGenDirectives = true
+
+	files, err := NewGenerator(p, config).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[config.Output]
+
+	for _, want := range []string{
+		"type PublicUser @goModel(model: \"" + p.PackagePaths["PublicUser"] + ".PublicUser\") {\n  id: ID!\n  name: String!\n}",
+		"type Viewer @goModel(model: \"" + p.PackagePaths["CurrentUser"] + ".CurrentUser\") {\n  id: ID!\n  name: String!\n}",
+		"scalar Email",
+		"  owner: PublicUser!",
+		"  contact: Email!",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected %q, got:\n%s", want, schema)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const typeDeprecationSource = `package models
+
+// User of the legacy API
+// @gqlType
+// @gqlDeprecated(reason:"Use Account instead")
+// @gqlTypeExtraField(name:"avatar",type:"String")
+type User struct {
+	ID    string
+	Email string ` + "`gql:\"email,deprecated:\\\"Use login\\\"\"`" + `
+}
+
+// Legacy roles
+// @gqlEnum
+// @gqlDeprecated
+type Role string
+
+const (
+	RoleAdmin Role = "admin"
+	RoleGuest Role = "guest" // @gqlEnumValue(deprecated:"Guests are gone")
+)
+`
+
+func buildTypeDeprecationSchema(t *testing.T, mode TypeDeprecationMode) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(typeDeprecationSource), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	p.MatchEnumConstants()
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.TypeDeprecationMode = mode
+
+	files, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return files[cfg.Output]
+}
+
+func TestTypeDeprecationFields(t *testing.T) {
+	schema := buildTypeDeprecationSchema(t, TypeDeprecationFields)
+
+	user := schemaBlock(schema, "type User")
+	for _, want := range []string{
+		`id: String! @deprecated(reason: "Use Account instead")`,
+		`email: String! @deprecated(reason: "Use login")`,
+		`avatar: String @deprecated(reason: "Use Account instead")`,
+	} {
+		if !strings.Contains(user, want) {
+			t.Errorf("Expected %q in User, got:\n%s", want, user)
+		}
+	}
+	if !strings.Contains(schema, `"User of the legacy API"`) {
+		t.Errorf("Expected the description to be kept, got:\n%s", schema)
+	}
+
+	role := schemaBlock(schema, "enum Role")
+	for _, want := range []string{"ADMIN @deprecated\n", `GUEST @deprecated(reason: "Guests are gone")`} {
+		if !strings.Contains(role, want) {
+			t.Errorf("Expected %q in Role, got:\n%s", want, role)
+		}
+	}
+}
+
+func TestTypeDeprecationDescription(t *testing.T) {
+	schema := buildTypeDeprecationSchema(t, TypeDeprecationDescription)
+
+	if !strings.Contains(schema, `"[DEPRECATED] Use Account instead User of the legacy API"`) {
+		t.Errorf("Expected a deprecated User description, got:\n%s", schema)
+	}
+	if !strings.Contains(schema, "\"[DEPRECATED] Legacy roles\"\nenum Role") {
+		t.Errorf("Expected a deprecated Role description, got:\n%s", schema)
+	}
+
+	// Only fields and values deprecated on their own carry @deprecated
+	if got := strings.Count(schema, "@deprecated"); got != 2 {
+		t.Errorf("Expected 2 @deprecated directives, got %d:\n%s", got, schema)
+	}
+}
+
+func TestConfigValidateTypeDeprecationMode(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Normalize()
+	cfg.Packages = []string{"."}
+	cfg.Output = "graph/schema"
+	cfg.TypeDeprecationMode = "directive"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected an error for an invalid type_deprecation_mode")
+	}
+}
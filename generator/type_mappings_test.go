@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTypeMappings(t *testing.T) {
+	src := `package models
+
+import (
+	"example.com/decimal"
+	"example.com/money"
+	"example.com/uuid"
+)
+
+// @gqlType
+// @gqlInput
+type Invoice struct {
+	ID        uuid.UUID         ` + "`gql:\"id\"`" + `
+	Total     decimal.Decimal   ` + "`gql:\"total\"`" + `
+	Lines     []decimal.Decimal ` + "`gql:\"lines\"`" + `
+	Owner     *uuid.UUID        ` + "`gql:\"owner\"`" + `
+	Timeout   time.Duration     ` + "`gql:\"timeout\"`" + `
+	Payload   json.RawMessage   ` + "`gql:\"payload\"`" + `
+	CreatedAt time.Time         ` + "`gql:\"createdAt\"`" + `
+	Price     money.Money       ` + "`gql:\"price\"`" + `
+}
+`
+
+	build := func(t *testing.T, mappings map[string]string) (string, *Config, error) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(tmpDir, "invoice.go"), []byte(src), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+
+		p := NewParser()
+		if err := p.Walk(tmpDir); err != nil {
+			t.Fatalf("Walk() error = %v", err)
+		}
+
+		config := NewConfig()
+		config.Output = filepath.Join(tmpDir, "schema.graphqls")
+		config.GenStrategy = GenStrategySingle
+		config.AutoGenerate.OutOfScopeTypes = OutOfScopeFail
+		config.TypeMappings = mappings
+
+		files, err := NewGenerator(p, config).Build()
+		return files[config.Output], config, err
+	}
+
+	generate := func(t *testing.T, mappings map[string]string) (string, *Config) {
+		t.Helper()
+		schema, config, err := build(t, mappings)
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		return schema, config
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		schema, config := generate(t, map[string]string{"Money": "Money"})
+
+		for _, want := range []string{
+			"  id: UUID!",
+			"  total: Decimal!",
+			"  lines: [Decimal!]!",
+			"  owner: UUID!",
+			"  timeout: Duration!",
+			"  payload: JSON!",
+			"  createdAt: DateTime!",
+			"  price: Money!", // bare name fallback
+		} {
+			if strings.Count(schema, want) != 2 {
+				t.Errorf("Expected %q in both the type and the input, got:\n%s", want, schema)
+			}
+		}
+
+		for _, scalar := range []string{"Duration", "Decimal", "Money"} {
+			if !slices.Contains(config.KnownScalars, scalar) {
+				t.Errorf("Expected mapped scalar %s to be known, got %v", scalar, config.KnownScalars)
+			}
+		}
+	})
+
+	t.Run("disabled default", func(t *testing.T) {
+		// Without the mapping decimal.Decimal is an unknown type again
+		_, config, err := build(t, map[string]string{"Money": "Money", "decimal.Decimal": ""})
+		if err == nil || !strings.Contains(err.Error(), "Type 'Decimal'") {
+			t.Errorf("Expected an out-of-scope error for Decimal, got %v", err)
+		}
+		if slices.Contains(config.KnownScalars, "Decimal") {
+			t.Errorf("Expected Decimal not to be known, got %v", config.KnownScalars)
+		}
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		schema, _ := generate(t, map[string]string{
+			"time.Time":   "Timestamp",
+			"money.Money": "Int",
+			"uuid.UUID":   "ID",
+		})
+
+		for _, want := range []string{"  createdAt: Timestamp!", "  price: Int!", "  id: ID!", "  total: Decimal!"} {
+			if strings.Count(schema, want) != 2 {
+				t.Errorf("Expected %q in both the type and the input, got:\n%s", want, schema)
+			}
+		}
+	})
+}
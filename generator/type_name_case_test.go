@@ -0,0 +1,146 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTypeNameCase(t *testing.T) {
+	src := `package models
+
+// @gqlType
+// @gqlInput
+type UserProfile struct {
+	Bio string ` + "`gql:\"bio\"`" + `
+}
+
+// @gqlType(name:"AccountSettings")
+// @gqlInput(name:"AccountSettingsInput")
+type Settings struct {
+	Theme string ` + "`gql:\"theme\"`" + `
+}
+`
+
+	tests := []struct {
+		typeNameCase TypeNameCase
+		want         []string
+	}{
+		{TypeNameCasePascal, []string{"type UserProfile @goModel", "input UserProfileInput @goModel"}},
+		{TypeNameCaseSnake, []string{"type user_profile @goModel", "input user_profile_input @goModel"}},
+		{TypeNameCaseScreaming, []string{"type USER_PROFILE @goModel", "input USER_PROFILE_INPUT @goModel"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.typeNameCase), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			p := NewParser()
+			if err := p.Walk(tmpDir); err != nil {
+				t.Fatalf("Walk() error = %v", err)
+			}
+
+			config := NewConfig()
+			config.Output = filepath.Join(tmpDir, "schema.graphqls")
+			config.GenStrategy = GenStrategySingle
+			config.UseGqlGenDirectives = true
+			config.TypeNameCase = tt.typeNameCase
+
+			files, err := NewGenerator(p, config).Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			schema := files[config.Output]
+
+			for _, want := range tt.want {
+				if !strings.Contains(schema, want) {
+					t.Errorf("Expected %q, got:\n%s", want, schema)
+				}
+			}
+
+			// @goModel keeps the Go type name
+			if !strings.Contains(schema, ".UserProfile\")") {
+				t.Errorf("Expected @goModel to reference the Go type, got:\n%s", schema)
+			}
+
+			// Explicit names bypass the transform
+			for _, want := range []string{"type AccountSettings @goModel", "input AccountSettingsInput @goModel"} {
+				if !strings.Contains(schema, want) {
+					t.Errorf("Expected %q, got:\n%s", want, schema)
+				}
+			}
+		})
+	}
+}
+
+func TestTypeNameCaseRenamesReferences(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlType
+// @gqlInput
+type UserProfile struct {
+	Name string
+}
+
+// @gqlEnum(name:"AccountRole")
+type Role string
+
+const (
+	RoleAdmin Role = "admin"
+)
+
+// @gqlType
+// @gqlInput
+type Account struct {
+	Profile *UserProfile
+	Others  []UserProfile
+	Role    Role
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	p.MatchEnumConstants()
+
+	config := NewConfig()
+	config.Output = filepath.Join(tmpDir, "schema.graphqls")
+	config.GenStrategy = GenStrategySingle
+	config.TypeNameCase = TypeNameCaseSnake
+
+	gen := NewGenerator(p, config)
+	files, err := gen.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	schema := files[config.Output]
+
+	// References use the names the definitions are generated with
+	for header, want := range map[string]string{
+		"type account {":        "  profile: user_profile!\n  others: [user_profile!]!\n  role: AccountRole!\n",
+		"input account_input {": "  profile: user_profile_input!\n  others: [user_profile_input!]!\n  role: AccountRole!\n",
+	} {
+		if block := schemaBlock(schema, header); !strings.Contains(block, want) {
+			t.Errorf("Expected %q in %s, got:\n%s", want, header, schema)
+		}
+	}
+	for _, want := range []string{"type user_profile {", "enum AccountRole {"} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected %q, got:\n%s", want, schema)
+		}
+	}
+
+	// Renamed references are not mistaken for out-of-scope types
+	if len(gen.OutOfScopeTypes) > 0 {
+		t.Errorf("Expected no out-of-scope types, got %v", gen.OutOfScopeTypes)
+	}
+}
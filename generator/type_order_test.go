@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTypeOrder(t *testing.T) {
+	files := map[string]string{
+		"b.go": `package models
+
+// @gqlType
+type Order struct {
+	Customer Customer
+	Lines    []Line
+}
+
+// @gqlType
+type Line struct {
+	Product Product
+}
+
+// @gqlType
+type Product struct {
+	Name string
+}
+`,
+		"a.go": `package models
+
+// @gqlType
+type Customer struct {
+	Name string
+}
+
+// @gqlType
+type Author struct {
+	Posts []Post
+}
+
+// @gqlType
+type Post struct {
+	Author *Author
+}
+`,
+	}
+
+	generate := func(t *testing.T, order TypeOrder) []string {
+		t.Helper()
+		tmpDir := t.TempDir()
+		for name, src := range files {
+			if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(src), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+		}
+
+		p := NewParser()
+		if err := p.Walk(tmpDir); err != nil {
+			t.Fatalf("Walk() error = %v", err)
+		}
+
+		config := NewConfig()
+		config.Output = filepath.Join(tmpDir, "schema.graphqls")
+		config.GenStrategy = GenStrategySingle
+		config.TypeOrder = order
+
+		generated, err := NewGenerator(p, config).Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+
+		var types []string
+		for _, line := range strings.Split(generated[config.Output], "\n") {
+			if name, ok := strings.CutPrefix(line, "type "); ok {
+				types = append(types, strings.Fields(name)[0])
+			}
+		}
+		return types
+	}
+
+	tests := []struct {
+		order TypeOrder
+		want  []string
+	}{
+		// Layers: Customer, Post, Product; Author, Line; Order
+		// The Author/Post cycle is broken where it is entered (Author is measured first)
+		{TypeOrderTopological, []string{"Customer", "Post", "Product", "Author", "Line", "Order"}},
+		{TypeOrderAlphabetical, []string{"Author", "Customer", "Line", "Order", "Post", "Product"}},
+		{TypeOrderSource, []string{"Customer", "Author", "Post", "Order", "Line", "Product"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			if got := generate(t, tt.order); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected types in order %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConfigValidateTypeOrder(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Normalize()
+	cfg.Packages = []string{"."}
+	cfg.Output = "graph/schema"
+	cfg.TypeOrder = "random"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected an error for an invalid type_order")
+	}
+}
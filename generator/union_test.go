@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeUnionFixtures(t *testing.T, src string) *Parser {
+	t.Helper()
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Failed to walk: %v", err)
+	}
+	return p
+}
+
+func TestGqlUnion(t *testing.T) {
+	p := writeUnionFixtures(t, `package models
+
+// @gqlType
+type UserDTO struct {
+	ID string
+}
+
+// @gqlType
+type PostDTO struct {
+	Title string
+}
+
+// @gqlType(name:"Remark")
+type CommentDTO struct {
+	Body string
+}
+
+// @gqlUnion(name:"SearchResult", description:"Anything searchable", members:"UserDTO,PostDTO,CommentDTO")
+type SearchResult struct{}
+`)
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(t.TempDir(), "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.StripSuffix = "DTO"
+
+	files, err := NewGenerator(p, cfg).Build()
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	schema := files[cfg.Output]
+
+	want := "\"Anything searchable\"\nunion SearchResult = User | Post | Remark\n"
+	if !strings.Contains(schema, want) {
+		t.Errorf("Expected union:\n%s\ngot:\n%s", want, schema)
+	}
+	if strings.Contains(schema, "type SearchResult") {
+		t.Errorf("Did not expect an object type for the union struct, got:\n%s", schema)
+	}
+}
+
+func TestGqlUnionNamespaceAndPackage(t *testing.T) {
+	src := `package models
+
+// @gqlType
+type User struct {
+	ID string
+}
+
+// @gqlType
+type Post struct {
+	Title string
+}
+
+// @gqlUnion(members:"User,Post", namespace:"search")
+type SearchResult struct{}
+`
+
+	t.Run("namespace", func(t *testing.T) {
+		// Namespace generation is enabled by a file-level namespace
+		p := writeUnionFixtures(t, strings.Replace(src, "package models\n", "package models\n\n/**\n * @gqlNamespace(name:\"common\")\n */\n", 1))
+		cfg := NewConfig()
+		cfg.Output = t.TempDir()
+		cfg.GenStrategy = GenStrategySingle
+
+		files, err := NewGenerator(p, cfg).Build()
+		if err != nil {
+			t.Fatalf("Generation failed: %v", err)
+		}
+
+		content := files[filepath.Join(cfg.Output, "search"+cfg.OutputFileExtension)]
+		if !strings.Contains(content, "union SearchResult = User | Post") {
+			t.Errorf("Expected union in namespace file, got files: %v", files)
+		}
+	})
+
+	t.Run("package", func(t *testing.T) {
+		p := writeUnionFixtures(t, strings.ReplaceAll(src, `, namespace:"search"`, ""))
+		cfg := NewConfig()
+		cfg.Output = t.TempDir()
+		cfg.GenStrategy = GenStrategyPackage
+
+		files, err := NewGenerator(p, cfg).Build()
+		if err != nil {
+			t.Fatalf("Generation failed: %v", err)
+		}
+
+		content := files[filepath.Join(cfg.Output, "models"+cfg.OutputFileExtension)]
+		if !strings.Contains(content, "union SearchResult = User | Post") {
+			t.Errorf("Expected union in package file, got files: %v", files)
+		}
+	})
+}
@@ -1,10 +1,13 @@
 package generator
 
 import (
+	"fmt"
 	"go/ast"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -128,32 +131,65 @@ func ExprToGraphQLType(expr ast.Expr) string {
 // ExprToGraphQLTypeWithContext converts an ast.Expr to a GraphQL type string with context support
 // The context provides type substitutions for generic type parameters and config for unresolved types
 func ExprToGraphQLTypeWithContext(expr ast.Expr, config *Config, ctx *GenerationContext, gen *Generator) string {
+	r := newFieldTypeResolver(config, ctx, gen, false)
+	return r.resolve(expr)
+}
+
+// fieldTypeResolver converts a field type expression to its GraphQL type and collects the Go types
+// the field references. Fields are rendered with it and the dependency graph follows its references,
+// so auto-generation and the field renderer always agree on what a field depends on
+type fieldTypeResolver struct {
+	config       *Config
+	ctx          *GenerationContext
+	gen          *Generator
+	knownScalars []string
+	enumTypes    map[string]*EnumType
+	forInput     bool
+	// track registers generic instantiations for generation, the dependency graph resolves without it
+	track bool
+	refs  []string
+}
+
+// newFieldTypeResolver returns a resolver that renders types (or inputs) and tracks generic instantiations
+func newFieldTypeResolver(config *Config, ctx *GenerationContext, gen *Generator, forInput bool) *fieldTypeResolver {
+	r := &fieldTypeResolver{config: config, ctx: ctx, gen: gen, forInput: forInput, track: true}
+	if config != nil {
+		r.knownScalars = config.KnownScalars
+	}
+	if gen != nil {
+		r.enumTypes = gen.P.EnumTypes
+	}
+	return r
+}
+
+// resolveFieldType returns the GraphQL type of a field type expression and the Go types it references
+// It never tracks generic instantiations, so it is safe to call on types that are not generated
+func (g *Generator) resolveFieldType(expr ast.Expr) (string, []string) {
+	r := newFieldTypeResolver(g.Config, nil, g, false)
+	r.track = false
+	graphQLType := r.resolve(expr)
+	return graphQLType, r.refs
+}
+
+// resolve returns the GraphQL type of expr, or "" for inputs referencing a type without an input variant
+func (r *fieldTypeResolver) resolve(expr ast.Expr) string {
 	// FIRST: Check scalar mappings if we have a generator (needed to resolve package paths)
-	if gen != nil && config != nil && config.Scalars != nil {
-		if scalarName := resolveScalarMapping(expr, gen, config); scalarName != "" {
-			// Check if it's a pointer or array and preserve those modifiers
-			if _, isPtr := expr.(*ast.StarExpr); isPtr {
-				return scalarName // GraphQL doesn't have nullable scalars, already handled
-			}
-			if _, isArray := expr.(*ast.ArrayType); isArray {
-				return "[" + scalarName + "!]!"
-			}
-			return scalarName + "!"
+	if r.gen != nil && r.config != nil && r.config.Scalars != nil {
+		if scalarName := resolveScalarMapping(expr, r.gen, r.config); scalarName != "" {
+			// Preserve the pointer and list modifiers around the mapped scalar
+			return wrapMappedScalar(expr, scalarName)
 		}
 	}
 
 	switch t := expr.(type) {
 	case *ast.Ident:
-		// FIRST: Check if this identifier has a substitution in context (for generic type parameters)
-		if ctx != nil && ctx.TypeSubstitutions != nil {
-			if substitutedExpr, exists := ctx.TypeSubstitutions[t.Name]; exists {
-				// Recursively convert the substituted expression
-				// This handles Result[*User] where T maps to *User expression
-				return ExprToGraphQLTypeWithContext(substitutedExpr, config, ctx, gen)
+		// Type parameters resolve to their substitution in context (Result[*User] where T maps to *User)
+		if r.ctx != nil && r.ctx.TypeSubstitutions != nil {
+			if substitutedExpr, exists := r.ctx.TypeSubstitutions[t.Name]; exists {
+				return r.resolve(substitutedExpr)
 			}
 		}
 
-		// SECOND: Check built-in types
 		switch t.Name {
 		case "string":
 			return "String!"
@@ -165,70 +201,267 @@ func ExprToGraphQLTypeWithContext(expr ast.Expr, config *Config, ctx *Generation
 			return "Float!"
 		case "bool":
 			return "Boolean!"
-		case "interface{}":
-			return "JSON!"
+		case "any":
+			return anyScalarName(r.config) + "!"
 		case "Time", "time.Time":
 			return "DateTime!"
-		default:
-			// THIRD: Check if it's an unresolved type parameter and config specifies replacement
-			if config != nil && config.AutoGenerate.UnresolvedGenericType != "" {
-				// Use simple heuristic for type parameters
-				if isLikelyTypeParameter(t.Name) {
-					return config.AutoGenerate.UnresolvedGenericType + "!"
-				}
-			}
-			return t.Name + "!"
 		}
+
+		// Unresolved type parameters use the configured replacement
+		if unresolved := unresolvedGenericType(t.Name, r.config, r.gen); unresolved != "" {
+			return unresolved
+		}
+		// Named interfaces have no GraphQL shape, they render as the any scalar
+		if r.gen != nil && r.gen.P.InterfaceTypes[t.Name] != nil {
+			return anyScalarName(r.config) + "!"
+		}
+
+		if !isBuiltinType(t.Name) {
+			r.refs = append(r.refs, t.Name)
+		}
+		if r.forInput {
+			return r.inputTypeName(t.Name)
+		}
+		return r.typeName(t.Name)
 	case *ast.StarExpr:
-		// Pass context through
-		return ExprToGraphQLTypeWithContext(t.X, config, ctx, gen)
+		inner := r.resolve(t.X)
+		if _, isArray := t.X.(*ast.ArrayType); isArray {
+			// *[]T: the list itself is nullable
+			return strings.TrimSuffix(inner, "!")
+		}
+		return inner
 	case *ast.ArrayType:
-		return "[" + ExprToGraphQLTypeWithContext(t.Elt, config, ctx, gen) + "]!"
+		// Slices and fixed-size arrays ([3]float64) are both lists, GraphQL has no fixed-length lists
+		elemType := r.resolve(t.Elt)
+		if elemType == "" {
+			// Element type has no input variant - skip the whole field
+			return ""
+		}
+		if isNullableListElement(t.Elt, elemType, r.knownScalars, r.gen) {
+			// []*string: the elements are nullable
+			elemType = strings.TrimSuffix(elemType, "!")
+		}
+		return "[" + elemType + "]!"
+	case *ast.MapType:
+		// Maps are a scalar, their key and value are only referenced when rendered as pairs (see fieldReferences)
+		return mapScalarName(r.config) + "!"
+	case *ast.InterfaceType:
+		return anyScalarName(r.config) + "!"
 	case *ast.SelectorExpr:
-		return t.Sel.Name + "!"
+		if scalar := typeMappingScalar(t, r.config); scalar != "" {
+			return scalar + "!"
+		}
+		r.addSelectorRefs(t)
+		if r.forInput {
+			return r.inputTypeName(t.Sel.Name)
+		}
+		return r.typeName(t.Sel.Name)
 	case *ast.IndexExpr:
-		// Handle generic instantiation like Repository[Post] or Edge[Comment]
-		// First, resolve the type argument through the context (for nested generics like Edge[T] where T=*Comment)
-		typeArg := t.Index
-		if ctx != nil && ctx.TypeSubstitutions != nil {
-			if ident, ok := typeArg.(*ast.Ident); ok {
-				if substitutedExpr, exists := ctx.TypeSubstitutions[ident.Name]; exists {
-					typeArg = substitutedExpr
+		// Generic instantiation like Repository[Post] or Edge[Comment]
+		return r.resolveGeneric(t.X, []ast.Expr{t.Index})
+	case *ast.IndexListExpr:
+		// Generic instantiation with multiple params like Map[K, V]
+		return r.resolveGeneric(t.X, t.Indices)
+	default:
+		return "String!"
+	}
+}
+
+// typeName returns the type a named type is referenced as in types, named like its definition
+func (r *fieldTypeResolver) typeName(name string) string {
+	if r.gen != nil {
+		return r.gen.referenceTypeName(name) + "!"
+	}
+	return name + "!"
+}
+
+// inputTypeName returns the input a named type is referenced as in inputs
+// Enums and known scalars are unchanged, structs use their (custom) input name and
+// structs without an input return "" so the field is skipped
+func (r *fieldTypeResolver) inputTypeName(name string) string {
+	if r.enumTypes != nil {
+		if enumType, isEnum := r.enumTypes[name]; isEnum {
+			return enumType.Name + "!"
+		}
+	}
+	if slices.Contains(r.knownScalars, name) {
+		return name + "!"
+	}
+	// If we have generator context, check what inputs are actually defined
+	if r.gen != nil {
+		if typeSpec := r.gen.P.StructTypes[name]; typeSpec != nil {
+			if genDecl := r.gen.P.TypeToDecl[name]; genDecl != nil {
+				d := ParseDirectives(typeSpec, genDecl)
+				// If the type has custom-named inputs, use the first one
+				if len(d.Inputs) > 0 && d.Inputs[0].Name != "" {
+					return d.Inputs[0].Name + "!"
+				}
+				// If the type has no input directive at all, return empty to skip this field
+				if !d.HasInputDirective && !r.gen.AutoGeneratedInputs[name] {
+					return ""
 				}
+				// Use the same name the input definition is generated with
+				return r.gen.defaultInputName(d) + "!"
 			}
 		}
+	}
+	return name + "Input!"
+}
+
+// addSelectorRefs records a package-qualified struct (pkg.User) by its name and its qualified name
+func (r *fieldTypeResolver) addSelectorRefs(sel *ast.SelectorExpr) {
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok || r.gen == nil {
+		return
+	}
+	for _, name := range []string{sel.Sel.Name, ident.Name + "." + sel.Sel.Name} {
+		if ts, exists := r.gen.P.StructTypes[name]; exists {
+			if _, isStruct := ts.Type.(*ast.StructType); isStruct {
+				r.refs = append(r.refs, name)
+			}
+		}
+	}
+}
+
+// resolveGeneric returns the concrete type of a generic instantiation (Edge[*Comment] -> CommentEdge)
+// The generic type and its type arguments are referenced, so auto-generation follows the generic's fields
+func (r *fieldTypeResolver) resolveGeneric(base ast.Expr, args []ast.Expr) string {
+	// Resolve the type arguments through the context (for nested generics like Edge[T] where T=*Comment)
+	typeArgs := make([]ast.Expr, len(args))
+	for i, arg := range args {
+		typeArgs[i] = arg
+		if r.ctx != nil {
+			typeArgs[i] = substituteTypeParams(arg, r.ctx.TypeSubstitutions)
+		}
+	}
+
+	r.collectRefs(base)
+	for _, arg := range typeArgs {
+		r.collectRefs(arg)
+	}
 
-		// Track this instantiation and generate a concrete type name
-		baseName := extractBaseTypeName(t.X)
-		if gen != nil && baseName != "" {
-			concreteTypeName := gen.trackGenericInstantiation(baseName, []ast.Expr{typeArg}, ctx)
-			return concreteTypeName + "!"
+	baseName := extractBaseTypeName(base)
+	if r.gen == nil || baseName == "" {
+		return r.resolve(base)
+	}
+
+	concreteTypeName := baseName
+	if r.track {
+		concreteTypeName = r.gen.trackGenericInstantiation(baseName, typeArgs, r.ctx)
+	} else if _, isGeneric := r.gen.P.TypeParameters[baseName]; isGeneric {
+		concreteTypeName = r.gen.generateConcreteTypeName(baseName, typeArgs)
+	}
+	if r.forInput {
+		return concreteTypeName + "Input!"
+	}
+	return concreteTypeName + "!"
+}
+
+// collectRefs records the types expr references without rendering it or tracking instantiations
+func (r *fieldTypeResolver) collectRefs(expr ast.Expr) {
+	sub := fieldTypeResolver{config: r.config, gen: r.gen, knownScalars: r.knownScalars, enumTypes: r.enumTypes}
+	sub.resolve(expr)
+	r.refs = append(r.refs, sub.refs...)
+}
+
+// substituteTypeParams returns expr with every type parameter replaced by its type argument,
+// at any depth: with T=*User, Edge[*T] becomes Edge[**User] and []T becomes []*User.
+// The substitutions hold already resolved expressions, so they are not substituted again
+func substituteTypeParams(expr ast.Expr, subs map[string]ast.Expr) ast.Expr {
+	if len(subs) == 0 {
+		return expr
+	}
+
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if substituted, exists := subs[t.Name]; exists {
+			return substituted
 		}
-		return ExprToGraphQLTypeWithContext(t.X, config, ctx, gen)
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: substituteTypeParams(t.X, subs)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: t.Len, Elt: substituteTypeParams(t.Elt, subs)}
+	case *ast.MapType:
+		return &ast.MapType{Key: substituteTypeParams(t.Key, subs), Value: substituteTypeParams(t.Value, subs)}
+	case *ast.IndexExpr:
+		return &ast.IndexExpr{X: t.X, Index: substituteTypeParams(t.Index, subs)}
 	case *ast.IndexListExpr:
-		// Handle generic instantiation with multiple params like Map[K, V]
-		// Resolve each type argument through the context
-		typeArgs := make([]ast.Expr, len(t.Indices))
-		for i, arg := range t.Indices {
-			typeArgs[i] = arg
-			if ctx != nil && ctx.TypeSubstitutions != nil {
-				if ident, ok := arg.(*ast.Ident); ok {
-					if substitutedExpr, exists := ctx.TypeSubstitutions[ident.Name]; exists {
-						typeArgs[i] = substitutedExpr
-					}
-				}
-			}
+		indices := make([]ast.Expr, len(t.Indices))
+		for i, index := range t.Indices {
+			indices[i] = substituteTypeParams(index, subs)
 		}
+		return &ast.IndexListExpr{X: t.X, Indices: indices}
+	}
+	return expr
+}
+
+// isNullableListElement reports whether a list element is nullable: a pointer to a scalar or enum
+// ([]*string -> [String]!). Pointers to objects keep non-null elements ([]*User -> [User!]!):
+// gqlgen binds object lists to pointer slices whether or not the elements are nullable, so the
+// pointer carries no intent there. A type: override is the way to get nullable objects
+func isNullableListElement(elt ast.Expr, elemType string, knownScalars []string, gen *Generator) bool {
+	if _, isPtr := elt.(*ast.StarExpr); !isPtr {
+		return false
+	}
+
+	baseName := strings.Trim(elemType, "[]!")
+	if IsBuiltInScalar(baseName) || slices.Contains(knownScalars, baseName) {
+		return true
+	}
 
-		baseName := extractBaseTypeName(t.X)
-		if gen != nil && baseName != "" {
-			concreteTypeName := gen.trackGenericInstantiation(baseName, typeArgs, ctx)
-			return concreteTypeName + "!"
+	if gen != nil {
+		goName := extractBaseTypeName(elt)
+		if gen.P.EnumTypes[goName] != nil || gen.P.ScalarTypes[goName] != nil {
+			return true
 		}
-		return ExprToGraphQLTypeWithContext(t.X, config, ctx, gen)
+	}
+	return false
+}
+
+// wrapMappedScalar wraps a mapped scalar in the list and nullability modifiers of expr
+// A pointer to a scalar makes it nullable, []*T makes the elements nullable and *[]T the list
+func wrapMappedScalar(expr ast.Expr, scalarName string) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		if arr, isArray := t.X.(*ast.ArrayType); isArray {
+			return strings.TrimSuffix(wrapMappedScalar(arr, scalarName), "!")
+		}
+		return scalarName
+	case *ast.ArrayType:
+		return "[" + wrapMappedScalar(t.Elt, scalarName) + "]!"
 	default:
-		return "String!"
+		return scalarName + "!"
+	}
+}
+
+// typeMappingScalar returns the scalar Config.TypeMappings maps a package-qualified type to,
+// looked up by its selector text (time.Time), then by its bare name (Time)
+func typeMappingScalar(sel *ast.SelectorExpr, config *Config) string {
+	if config == nil || len(config.TypeMappings) == 0 {
+		return ""
 	}
+	if pkg, ok := sel.X.(*ast.Ident); ok {
+		if scalar, exists := config.TypeMappings[pkg.Name+"."+sel.Sel.Name]; exists {
+			return scalar
+		}
+	}
+	return config.TypeMappings[sel.Sel.Name]
+}
+
+// mapScalarName returns the scalar map fields are rendered as
+func mapScalarName(config *Config) string {
+	if config != nil && config.MapScalar != "" {
+		return config.MapScalar
+	}
+	return "Map"
+}
+
+// anyScalarName returns the scalar any, interface{} and interface fields are rendered as
+func anyScalarName(config *Config) string {
+	if config != nil && config.AnyScalar != "" {
+		return config.AnyScalar
+	}
+	return "JSON"
 }
 
 // extractBaseTypeName extracts the base type name from an expression
@@ -244,6 +477,27 @@ func extractBaseTypeName(expr ast.Expr) string {
 	return ""
 }
 
+// unresolvedGenericType returns the configured replacement (AutoGenerate.UnresolvedGenericType)
+// for a type parameter left without a type argument, or "" when name is not one or no
+// replacement is configured. The replacement is nullable since the parameter may be
+// instantiated with any type
+func unresolvedGenericType(name string, config *Config, gen *Generator) string {
+	if config == nil || config.AutoGenerate.UnresolvedGenericType == "" {
+		return ""
+	}
+
+	// With a generator, only parameters declared by parsed generic types qualify
+	if gen != nil {
+		if !gen.isDeclaredTypeParameter(name) {
+			return ""
+		}
+	} else if !isLikelyTypeParameter(name) {
+		return ""
+	}
+
+	return config.AutoGenerate.UnresolvedGenericType
+}
+
 // isLikelyTypeParameter checks if a name looks like a generic type parameter
 // This is a simple heuristic used when we don't have access to the Generator
 func isLikelyTypeParameter(name string) bool {
@@ -274,154 +528,10 @@ func ExprToGraphQLTypeForInput(expr ast.Expr, knownScalars []string, enumTypes m
 
 // ExprToGraphQLTypeForInputWithContext converts with context support for type substitutions
 func ExprToGraphQLTypeForInputWithContext(expr ast.Expr, knownScalars []string, enumTypes map[string]*EnumType, config *Config, ctx *GenerationContext, gen *Generator) string {
-	// FIRST: Check scalar mappings if we have a generator
-	if gen != nil && config != nil && config.Scalars != nil {
-		if scalarName := resolveScalarMapping(expr, gen, config); scalarName != "" {
-			// Check if it's a pointer or array and preserve those modifiers
-			if _, isPtr := expr.(*ast.StarExpr); isPtr {
-				return scalarName // GraphQL doesn't have nullable scalars
-			}
-			if _, isArray := expr.(*ast.ArrayType); isArray {
-				return "[" + scalarName + "!]!"
-			}
-			return scalarName + "!"
-		}
-	}
-
-	switch t := expr.(type) {
-	case *ast.Ident:
-		// FIRST: Check if this identifier has a substitution in context (for generic type parameters)
-		if ctx != nil && ctx.TypeSubstitutions != nil {
-			if substitutedExpr, exists := ctx.TypeSubstitutions[t.Name]; exists {
-				// Recursively convert the substituted expression
-				return ExprToGraphQLTypeForInputWithContext(substitutedExpr, knownScalars, enumTypes, config, ctx, gen)
-			}
-		}
-
-		// SECOND: Check built-in types
-		switch t.Name {
-		case "string":
-			return "String!"
-		case "int", "int32":
-			return "Int!"
-		case "int64":
-			return "Int64!"
-		case "float32", "float64":
-			return "Float!"
-		case "bool":
-			return "Boolean!"
-		case "interface{}":
-			return "JSON!"
-		case "Time", "time.Time":
-			return "DateTime!"
-		default:
-			// THIRD: Check if it's an unresolved type parameter
-			if config != nil && config.AutoGenerate.UnresolvedGenericType != "" && isLikelyTypeParameter(t.Name) {
-				return config.AutoGenerate.UnresolvedGenericType + "!"
-			}
-
-			// Check if it's an enum first (enums don't need Input suffix)
-			if enumTypes != nil {
-				if _, isEnum := enumTypes[t.Name]; isEnum {
-					return t.Name + "!"
-				}
-			}
-			// Check if it's a known scalar
-			for _, scalar := range knownScalars {
-				if scalar == t.Name {
-					return t.Name + "!"
-				}
-			}
-			// It's a custom type, convert to input
-			// If we have generator context, check what inputs are actually defined
-			if gen != nil {
-				// Check if this type exists in our parsed types
-				if typeSpec := gen.P.StructTypes[t.Name]; typeSpec != nil {
-					if genDecl := gen.P.TypeToDecl[t.Name]; genDecl != nil {
-						d := ParseDirectives(typeSpec, genDecl)
-						// If the type has custom-named inputs, use the first one
-						if len(d.Inputs) > 0 {
-							return d.Inputs[0].Name + "!"
-						}
-						// If the type has no input directive at all, return empty to skip this field
-						if !d.HasInputDirective && !gen.AutoGeneratedInputs[t.Name] {
-							return ""
-						}
-					}
-				}
-			}
-			return t.Name + "Input!"
-		}
-	case *ast.StarExpr:
-		// Pass context through
-		return ExprToGraphQLTypeForInputWithContext(t.X, knownScalars, enumTypes, config, ctx, gen)
-	case *ast.ArrayType:
-		return "[" + ExprToGraphQLTypeForInputWithContext(t.Elt, knownScalars, enumTypes, config, ctx, gen) + "]!"
-	case *ast.SelectorExpr:
-		// Check if it's an enum
-		if enumTypes != nil {
-			if _, isEnum := enumTypes[t.Sel.Name]; isEnum {
-				return t.Sel.Name + "!"
-			}
-		}
-		// Check if we have generator context for external types
-		if gen != nil {
-			typeName := t.Sel.Name
-			if typeSpec := gen.P.StructTypes[typeName]; typeSpec != nil {
-				if genDecl := gen.P.TypeToDecl[typeName]; genDecl != nil {
-					d := ParseDirectives(typeSpec, genDecl)
-					if len(d.Inputs) > 0 {
-						return d.Inputs[0].Name + "!"
-					}
-					if !d.HasInputDirective && !gen.AutoGeneratedInputs[typeName] {
-						return ""
-					}
-				}
-			}
-		}
-		return t.Sel.Name + "Input!"
-	case *ast.IndexExpr:
-		// Handle generic instantiation - track and generate concrete type with Input suffix
-		// First, resolve the type argument through the context (for nested generics)
-		typeArg := t.Index
-		if ctx != nil && ctx.TypeSubstitutions != nil {
-			if ident, ok := typeArg.(*ast.Ident); ok {
-				if substitutedExpr, exists := ctx.TypeSubstitutions[ident.Name]; exists {
-					typeArg = substitutedExpr
-				}
-			}
-		}
-
-		baseName := extractBaseTypeName(t.X)
-		if gen != nil && baseName != "" {
-			concreteTypeName := gen.trackGenericInstantiation(baseName, []ast.Expr{typeArg}, ctx)
-			return concreteTypeName + "Input!"
-		}
-		return ExprToGraphQLTypeForInputWithContext(t.X, knownScalars, enumTypes, config, ctx, gen)
-	case *ast.IndexListExpr:
-		// Handle generic instantiation with multiple params
-		// Resolve each type argument through the context
-		typeArgs := make([]ast.Expr, len(t.Indices))
-		for i, arg := range t.Indices {
-			typeArgs[i] = arg
-			if ctx != nil && ctx.TypeSubstitutions != nil {
-				if ident, ok := arg.(*ast.Ident); ok {
-					if substitutedExpr, exists := ctx.TypeSubstitutions[ident.Name]; exists {
-						typeArgs[i] = substitutedExpr
-					}
-				}
-			}
-		}
-
-		baseName := extractBaseTypeName(t.X)
-		if gen != nil && baseName != "" {
-			concreteTypeName := gen.trackGenericInstantiation(baseName, typeArgs, ctx)
-			return concreteTypeName + "Input!"
-		}
-		return ExprToGraphQLTypeForInputWithContext(t.X, knownScalars, enumTypes, config, ctx, gen)
-	default:
-		return "String!"
-	}
+	r := newFieldTypeResolver(config, ctx, gen, true)
+	r.knownScalars = knownScalars
+	r.enumTypes = enumTypes
+	return r.resolve(expr)
 }
 
 func WriteFile(path, content string, config *Config) error {
@@ -433,51 +543,126 @@ func WriteFile(path, content string, config *Config) error {
 		}
 	}
 
+	content, err := RenderFileContent(path, content, config)
+	if err != nil {
+		return err
+	}
+
+	// Write file (atomic write could be added if desired)
+	return os.WriteFile(path, []byte(content), 0o644)
+}
+
+// RenderFileContent returns the exact content WriteFile would write to path:
+// the generated-code header, the schema, and the keep sections of the existing file,
+// passed through the PostProcess hook. In merge mode the schema is merged into the existing file instead
+func RenderFileContent(path, content string, config *Config) (string, error) {
+	if config.MergeMode {
+		return renderMerged(path, content, config)
+	}
+
 	// check for # @gqlKeepBegin and # @gqlKeepEnd markers to preserve content (can have multiple)
+	var preservedSections []string
 	if FileExists(path) {
-		var preservedSections []string
-		var gqlKeepRegex = regexp.MustCompile(`(?s)` + config.KeepBeginMarker + `(.*?)` + config.KeepEndMarker + `(?s)`)
 		existingContent, err := os.ReadFile(path)
 		if err != nil {
-			return err
+			return "", err
 		}
-		existingStr := string(existingContent)
-		matches := gqlKeepRegex.FindAllStringSubmatch(existingStr, -1)
-		for _, match := range matches {
-			if len(match) < 2 {
-				continue
-			}
-			innerContent := match[1]
-			// Replace the corresponding section in content
-			preservedSections = append(preservedSections, innerContent)
+		preservedSections = extractKeepSections(string(existingContent), config)
+	}
+
+	// Keep sections are written verbatim: only the generated content has its blank lines normalized
+	keep := strings.Join(preservedSections, "\n\n")
+	if len(preservedSections) == 0 {
+		// New files get the placeholder markers too, so regenerating an unchanged schema is a no-op
+		keep = config.KeepBeginMarker + "\n# You can add custom types or comments here and they will be preserved during code generation.\n" + config.KeepEndMarker
+	}
+	keep = strings.Trim(keep, "\n")
+	generated := strings.Trim(normalizeNewlines(content), "\n")
+
+	// Depending on placement config, insert the keep sections
+	parts := []string{generated, keep}
+	if config.KeepSectionPlacement == "start" {
+		parts = []string{keep, generated}
+	}
+	var body []string
+	for _, part := range parts {
+		if part != "" {
+			body = append(body, part)
 		}
+	}
 
-		if len(preservedSections) > 0 {
-			combinedSections := config.KeepBeginMarker + strings.Join(preservedSections, "\n") + config.KeepEndMarker
+	return postProcess(path, fileHeader(config)+strings.Join(body, "\n\n")+"\n", config)
+}
 
-			// Depending on placement config, insert preserved sections
-			if config.KeepSectionPlacement == "start" {
-				content = combinedSections + "\n" + content
-			} else {
-				content = content + "\n" + combinedSections + "\n"
-			}
-		} else {
-			placeholder := config.KeepBeginMarker + "\n# You can add custom types or comments here and they will be preserved during code generation.\n" + config.KeepEndMarker
-			// Depending on placement config, insert placeholder markers
-			if config.KeepSectionPlacement == "start" {
-				content = placeholder + "\n\n" + content
-			} else {
-				content = content + "\n\n" + placeholder + "\n\n"
-			}
+// postProcess runs the configured PostProcess hook on the final content of a file
+// Blank lines are normalized by the callers, which know which parts of the content are generated
+func postProcess(path, content string, config *Config) (string, error) {
+	if config.PostProcess == nil {
+		return content, nil
+	}
+	processed, err := config.PostProcess(path, content)
+	if err != nil {
+		return "", fmt.Errorf("post-process %s: %w", path, err)
+	}
+	return processed, nil
+}
+
+// normalizeNewlines collapses runs of blank lines into a single one and ends non-empty content
+// with exactly one newline, so every strategy writes files with the same layout
+// Blank lines inside """ block strings are part of a description and are left alone
+func normalizeNewlines(content string) string {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	out := make([]string, 0, len(lines))
+	inBlockString := false
+	for _, line := range lines {
+		if !inBlockString && line == "" && len(out) > 0 && out[len(out)-1] == "" {
+			continue
 		}
+		out = append(out, line)
+		// Escaped \""" sequences don't open or close a block string
+		if (strings.Count(line, `"""`)-strings.Count(line, `\"""`))%2 == 1 {
+			inBlockString = !inBlockString
+		}
+	}
+	content = strings.Join(out, "\n")
+	if content == "" {
+		return ""
 	}
+	return content + "\n"
+}
 
-	// add a notice at the top
-	content = "# Code generated by https://github.com/pablor21/gqlschemagen " + GetVersion() + ".\r\n" +
-		"# PUT YOUR CUSTOM CONTENT BETWEEN @gqlKeep(Begin|End) markers, see:  https://github.com/pablor21/gqlschemagen#keeping-schema-modifications \n" + content
+// fileHeader returns the generated-code notice written at the top of every file, or "" when disabled
+func fileHeader(config *Config) string {
+	if config.DisableHeader {
+		return ""
+	}
 
-	// Write file (atomic write could be added if desired)
-	return os.WriteFile(path, []byte(content), 0o644)
+	text := config.HeaderText
+	if text == "" {
+		text = DefaultHeaderText
+	}
+
+	var sb strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if !strings.HasPrefix(line, "#") {
+			line = "# " + line
+		}
+		sb.WriteString(line + "\n")
+	}
+	// In merge mode everything outside the managed blocks is kept, there are no keep sections
+	if config.MergeMode {
+		return sb.String()
+	}
+	sb.WriteString("# PUT YOUR CUSTOM CONTENT BETWEEN @gqlKeep(Begin|End) markers, see: https://github.com/pablor21/gqlschemagen#keeping-schema-modifications\n")
+	return sb.String()
+}
+
+// extractKeepSections returns every block between the keep markers in content,
+// markers included, in the order they appear
+func extractKeepSections(content string, config *Config) []string {
+	gqlKeepRegex := regexp.MustCompile(`(?s)` + regexp.QuoteMeta(config.KeepBeginMarker) + `.*?` + regexp.QuoteMeta(config.KeepEndMarker))
+	return gqlKeepRegex.FindAllString(content, -1)
 }
 
 // helper to normalize package dir path for go run usage
@@ -489,6 +674,33 @@ func PkgDir(in string) string {
 	return in
 }
 
+// PackageRoot returns the directory a Config.Packages entry is rooted at: the entry itself,
+// or for a glob the path before its first wildcard segment (./models/**/*.go -> models)
+func PackageRoot(pkg string) string {
+	root, _ := splitPackageGlob(pkg)
+	return root
+}
+
+// splitPackageGlob splits a package entry into its static root and the slash-separated glob
+// matched below it, which is empty when the entry has no wildcards
+func splitPackageGlob(pkg string) (root, pattern string) {
+	segments := strings.Split(filepath.ToSlash(filepath.Clean(pkg)), "/")
+	for i, segment := range segments {
+		if !strings.ContainsAny(segment, "*?[") {
+			continue
+		}
+		root = strings.Join(segments[:i], "/")
+		if root == "" {
+			root = "."
+			if i > 0 {
+				root = "/" // absolute glob like /**/models
+			}
+		}
+		return filepath.FromSlash(root), strings.Join(segments[i:], "/")
+	}
+	return pkg, ""
+}
+
 // ExprToGoType extracts the Go type name from an ast.Expr
 // This returns the type as it appears in Go code (e.g., "outofscope.AnotherOutOfScope", "*User", "[]string")
 func ExprToGoType(expr ast.Expr) string {
@@ -498,7 +710,18 @@ func ExprToGoType(expr ast.Expr) string {
 	case *ast.StarExpr:
 		return "*" + ExprToGoType(t.X)
 	case *ast.ArrayType:
-		return "[]" + ExprToGoType(t.Elt)
+		// Fixed-size arrays keep their length: [3]float64
+		length := ""
+		switch l := t.Len.(type) {
+		case *ast.BasicLit:
+			length = l.Value
+		case *ast.Ident:
+			length = l.Name
+		case nil:
+		default:
+			length = "..."
+		}
+		return "[" + length + "]" + ExprToGoType(t.Elt)
 	case *ast.SelectorExpr:
 		// For package-qualified types like "outofscope.AnotherOutOfScope"
 		if pkg, ok := t.X.(*ast.Ident); ok {
@@ -513,3 +736,8 @@ func ExprToGoType(expr ast.Expr) string {
 		return "unknown"
 	}
 }
+
+// sortedKeys returns the keys of m in sorted order, for deterministic output
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
@@ -0,0 +1,42 @@
+package generator
+
+// DiagnosticSeverity tells whether a Diagnostic fails validation
+type DiagnosticSeverity string
+
+const (
+	SeverityError   DiagnosticSeverity = "error"   // The generated schema would be invalid
+	SeverityWarning DiagnosticSeverity = "warning" // Generation works, but the schema may not be what was intended
+)
+
+// Diagnostic is a problem found by Validate
+type Diagnostic struct {
+	Severity DiagnosticSeverity
+	Message  string
+}
+
+// Validate runs the generation pipeline without writing any files and collects every problem,
+// where Build stops at the first one. Missing input fields, illegal enum values, input cycles and
+// name collisions are errors. Out-of-scope type references (including unresolved generic type
+// parameters) are warnings, unless out_of_scope_types is set to fail
+func (g *Generator) Validate() []Diagnostic {
+	fileContents, err := g.render()
+	if err != nil {
+		// Nothing was rendered, so there is nothing else to check
+		return []Diagnostic{{Severity: SeverityError, Message: err.Error()}}
+	}
+
+	var diagnostics []Diagnostic
+	for _, err := range g.checkSchema(fileContents) {
+		diagnostics = append(diagnostics, Diagnostic{Severity: SeverityError, Message: err.Error()})
+	}
+
+	if len(g.OutOfScopeTypes) > 0 {
+		severity := SeverityWarning
+		if g.Config.AutoGenerate.OutOfScopeTypes == OutOfScopeFail {
+			severity = SeverityError
+		}
+		diagnostics = append(diagnostics, Diagnostic{Severity: severity, Message: g.outOfScopeReport()})
+	}
+
+	return diagnostics
+}
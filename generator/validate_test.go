@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const validateSource = `package models
+
+import "example.com/external/geo"
+
+// @gqlEnum
+type Status string
+
+const (
+	StatusNone Status = "none" // @gqlEnumValue(name:"null")
+)
+
+// @gqlType(name:"Thing")
+type A struct {
+	ID       string
+	Location geo.Point
+}
+
+// @gqlType(name:"Thing")
+type B struct {
+	Name string
+}
+`
+
+func newValidateGenerator(t *testing.T, outOfScope OutOfScopeAction) *Generator {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(validateSource), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := NewParser()
+	if err := p.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	p.MatchEnumConstants()
+
+	cfg := NewConfig()
+	cfg.Output = filepath.Join(tmpDir, "schema.graphqls")
+	cfg.GenStrategy = GenStrategySingle
+	cfg.AutoGenerate.OutOfScopeTypes = outOfScope
+	return NewGenerator(p, cfg)
+}
+
+func TestValidateCollectsAllProblems(t *testing.T) {
+	gen := newValidateGenerator(t, OutOfScopeFail)
+	diagnostics := gen.Validate()
+
+	// Build stops at the first problem, Validate reports each of them
+	want := []string{
+		"invalid enum values:",
+		"duplicate GraphQL names:",
+		"Type 'Point' (not found in scanned packages)",
+	}
+	if len(diagnostics) != len(want) {
+		t.Fatalf("Expected %d diagnostics, got %d: %+v", len(want), len(diagnostics), diagnostics)
+	}
+	for i, d := range diagnostics {
+		if d.Severity != SeverityError {
+			t.Errorf("Expected diagnostic %d to be an error, got %s", i, d.Severity)
+		}
+		if !strings.Contains(d.Message, want[i]) {
+			t.Errorf("Expected diagnostic %d to contain %q, got:\n%s", i, want[i], d.Message)
+		}
+	}
+
+	if FileExists(gen.Config.Output) {
+		t.Error("Validate should not write any files")
+	}
+}
+
+func TestValidateOutOfScopeSeverity(t *testing.T) {
+	tests := []struct {
+		name       string
+		outOfScope OutOfScopeAction
+		want       DiagnosticSeverity
+	}{
+		{"default", NewConfig().AutoGenerate.OutOfScopeTypes, SeverityWarning},
+		{"exclude", OutOfScopeExclude, SeverityWarning},
+		{"placeholder", OutOfScopePlaceholder, SeverityWarning},
+		{"fail", OutOfScopeFail, SeverityError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := newValidateGenerator(t, tt.outOfScope).Validate()
+
+			// Only the fail policy turns out-of-scope references into errors
+			last := diagnostics[len(diagnostics)-1]
+			if last.Severity != tt.want || !strings.Contains(last.Message, "Type 'Point'") {
+				t.Errorf("Expected the out-of-scope report as %s, got %+v", tt.want, last)
+			}
+		})
+	}
+}
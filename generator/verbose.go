@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"log/slog"
+	"os"
+)
+
+// verbose logs a generation decision when Config.Verbose is set (--verbose)
+// Output always goes to stderr, so it never mixes with a schema written to stdout
+func (g *Generator) verbose(msg string, args ...any) {
+	if !g.Config.Verbose {
+		return
+	}
+	if g.verboseLog == nil {
+		out := g.verboseOutput
+		if out == nil {
+			out = os.Stderr
+		}
+		g.verboseLog = slog.New(slog.NewTextHandler(out, nil))
+	}
+	g.verboseLog.Info(msg, args...)
+}
+
+// logTypeDirectives logs the directives parsed for every scanned struct and whether auto-generation picked it up
+func (g *Generator) logTypeDirectives() {
+	if !g.Config.Verbose {
+		return
+	}
+	for _, typeName := range g.P.TypeNames {
+		typeSpec, exists := g.P.StructTypes[typeName]
+		if !exists {
+			continue
+		}
+		d := ParseDirectives(typeSpec, g.P.TypeToDecl[typeName])
+
+		var types, inputs []string
+		for _, typeDef := range d.Types {
+			types = append(types, g.typeDefName(typeName, d, typeDef))
+		}
+		for _, inputDef := range d.Inputs {
+			name := inputDef.Name
+			if name == "" {
+				name = g.defaultInputName(d)
+			}
+			inputs = append(inputs, name)
+		}
+
+		g.verbose("type directives",
+			"goType", typeName,
+			"types", types,
+			"inputs", inputs,
+			"interface", d.HasInterfaceDirective,
+			"union", d.HasUnionDirective,
+			"include", d.HasIncludeDirective,
+			"ignored", d.SkipType,
+			"ignoreAll", d.IgnoreAll,
+			"autoType", g.AutoGeneratedTypes[typeName],
+			"autoInput", g.AutoGeneratedInputs[typeName],
+			"file", d.OutputFile)
+	}
+}
+
+// fieldExclusionReason explains why shouldIncludeField excluded a field, following the same precedence
+func fieldExclusionReason(opt FieldOptions, ignoreAll bool, forInput bool, typeName string) string {
+	switch {
+	case len(opt.ReadOnly) > 0:
+		if forInput {
+			return "read-only (ro) field in an input"
+		}
+		return "ro does not list " + typeName
+	case len(opt.WriteOnly) > 0:
+		if !forInput {
+			return "write-only (wo) field in a type"
+		}
+		return "wo does not list " + typeName
+	case len(opt.ReadWrite) > 0:
+		return "rw does not list " + typeName
+	case len(opt.IgnoreList) > 0 && matchesTypeList(opt.IgnoreList, typeName):
+		return "omitted for " + typeName
+	case len(opt.IncludeList) > 0:
+		return "include does not list " + typeName
+	case opt.Ignore:
+		return `ignored by tag (e.g., gql:"-" or json:"-")`
+	case opt.Omit:
+		return "omitted by tag"
+	case ignoreAll:
+		return "ignoreAll without include"
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVerboseLogsDecisions(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := `package models
+
+// @gqlType
+// @gqlInput(name:"UserInput")
+type User struct {
+	ID       string   ` + "`gql:\"id,ro\"`" + `
+	Password string   ` + "`gql:\"password,wo\"`" + `
+	Secret   string   ` + "`json:\"-\"`" + `
+	Avatar   external ` + "`gql:\"avatar\"`" + `
+	Name     string
+}
+
+// @gqlType
+// @gqlIgnoreAll
+type Profile struct {
+	Bio  string ` + "`gql:\"bio,include\"`" + `
+	Note string
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	build := func(t *testing.T, verbose bool) string {
+		t.Helper()
+		p := NewParser()
+		if err := p.Walk(tmpDir); err != nil {
+			t.Fatalf("Walk() error = %v", err)
+		}
+
+		config := NewConfig()
+		config.Output = filepath.Join(tmpDir, "schema.graphqls")
+		config.GenStrategy = GenStrategySingle
+		config.AutoGenerate.OutOfScopeTypes = OutOfScopeExclude
+		config.Verbose = verbose
+
+		var buf bytes.Buffer
+		gen := NewGenerator(p, config)
+		gen.verboseOutput = &buf
+		if _, err := gen.Build(); err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		return buf.String()
+	}
+
+	if out := build(t, false); out != "" {
+		t.Fatalf("Expected no verbose output by default, got:\n%s", out)
+	}
+
+	out := build(t, true)
+	for _, want := range []string{
+		`msg="type directives" goType=User types=[User] inputs=[UserInput]`,
+		`msg="type directives" goType=Profile types=[Profile] inputs=[] interface=false union=false include=false ignored=false ignoreAll=true`,
+		`msg="field excluded" type=UserInput field=ID reason="read-only (ro) field in an input"`,
+		`msg="field excluded" type=User field=Password reason="write-only (wo) field in a type"`,
+		`msg="field excluded" type=User field=Secret reason="ignored by tag (e.g., gql:\"-\" or json:\"-\")"`,
+		`msg="field excluded" type=Profile field=Note reason="ignoreAll without include"`,
+		`msg="field excluded" type=User field=Avatar reason="out-of-scope type external"`,
+		`msg="field included" type=Profile field=Bio name=bio gqlType=String!`,
+		`msg=generated kind=type name=User goType=User autoGenerated=false file=` + filepath.Join(tmpDir, "schema.graphqls"),
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected verbose output to contain %s, got:\n%s", want, out)
+		}
+	}
+}
@@ -898,7 +898,7 @@ LastName  string
 		},
 		{
 			name:     "fullName description",
-			contains: `"""Computed full name"""`,
+			contains: `"Computed full name"`,
 			desc:     "fullName should have description",
 		},
 	}
@@ -17,6 +17,8 @@
 //	gqlschemagen init                                          # Create default configuration file
 //	gqlschemagen generate --pkg ./models                       # Generate schema from Go structs
 //	gqlschemagen generate --watch                              # Watch for changes and regenerate
+//	gqlschemagen generate --pkg ./models -s single -o -        # Write the schema to stdout
+//	gqlschemagen validate --pkg ./models                       # Report schema problems without writing files
 //
 // For more information and examples, visit: https://github.com/pablor21/gqlschemagen
 package main